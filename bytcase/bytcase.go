@@ -29,6 +29,10 @@ func clamp(n int) int {
 // The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
 func Compare(s, t []byte) int {
 	// TODO: move next to hasPrefixUnicode
+	if i := mismatchFold(s, t); i > 0 {
+		s = s[i:]
+		t = t[i:]
+	}
 	i := 0
 	for ; i < len(s) && i < len(t); i++ {
 		sr := s[i]
@@ -80,6 +84,23 @@ hasUnicode:
 	return -1
 }
 
+// minMismatchFold is the minimum length at which it is faster to use
+// bytealg.MismatchFold than to compare slices a byte at a time.
+const minMismatchFold = 16
+
+// mismatchFold returns the length of the longest common prefix of s and t
+// that is ASCII and equal ignoring case, or 0 if s or t are too short for
+// bytealg.MismatchFold to be used.
+func mismatchFold(s, t []byte) int {
+	if len(t) < len(s) {
+		s, t = t, s
+	}
+	if bytealg.NativeMismatchFold && len(s) >= minMismatchFold {
+		return bytealg.MismatchFold(s, t)
+	}
+	return 0
+}
+
 // EqualFold reports whether s and t, interpreted as UTF-8 strings,
 // are equal under simple Unicode case-folding, which is a more general
 // form of case-insensitivity.
@@ -157,6 +178,10 @@ func hasPrefixUnicode(s, prefix []byte) (bool, bool) {
 	}
 
 	// ASCII fast path
+	if i := mismatchFold(s, prefix); i > 0 {
+		s = s[i:]
+		prefix = prefix[i:]
+	}
 	i := 0
 	for ; i < len(s) && i < len(prefix); i++ {
 		sr := s[i]
@@ -201,6 +226,72 @@ hasUnicode:
 	return true, len(s) == 0 // s exhausted
 }
 
+// hasPrefixUnicodeLen is hasPrefixUnicode but also returns the length in
+// bytes of the match in s, which may differ from len(prefix), or 0 if s does
+// not begin with prefix. This is used by Index to find the end of a match.
+//
+// NB: this is a copy of hasPrefixUnicode since the additional result makes
+// HasPrefix ~15% slower for short strings.
+func hasPrefixUnicodeLen(s, prefix []byte) (bool, bool, int) {
+	// The max difference in encoded lengths between cases is 2 bytes for
+	// [kK] (1 byte) and Kelvin 'K' (3 bytes).
+	n := len(s)
+	if len(prefix) > n*3 || (len(prefix) > n*2 && !containsKelvin(prefix)) {
+		return false, true, 0
+	}
+
+	// ASCII fast path
+	if i := mismatchFold(s, prefix); i > 0 {
+		s = s[i:]
+		prefix = prefix[i:]
+	}
+	i := 0
+	for ; i < len(s) && i < len(prefix); i++ {
+		sr := s[i]
+		tr := prefix[i]
+		if (sr|tr)&utf8.RuneSelf != 0 {
+			goto hasUnicode
+		}
+		if tr == sr || _lower[sr] == _lower[tr] {
+			continue
+		}
+		return false, i == len(s)-1, 0
+	}
+	// Check if we've exhausted s
+	if i != len(prefix) {
+		return false, i == len(s), 0
+	}
+	return true, i == len(s), n - len(s) + i
+
+hasUnicode:
+	s = s[i:]
+	t := prefix[i:]
+	for len(t) > 0 {
+		// If s is exhausted the strings are not equal.
+		if len(s) == 0 {
+			return false, true, 0
+		}
+		var sr, tr rune
+		if t[0] < utf8.RuneSelf {
+			tr, t = rune(_lower[t[0]]), t[1:]
+		} else {
+			r, size := utf8.DecodeRune(t)
+			tr, t = r, t[size:]
+		}
+		if s[0] < utf8.RuneSelf {
+			sr, s = rune(_lower[s[0]]), s[1:]
+		} else {
+			r, size := utf8.DecodeRune(s)
+			sr, s = r, s[size:]
+		}
+		if tr == sr || tables.CaseFold(tr) == tables.CaseFold(sr) {
+			continue
+		}
+		return false, len(s) == 0, 0
+	}
+	return true, len(s) == 0, n - len(s) // s exhausted
+}
+
 // TrimPrefix returns s without the provided leading prefix string.
 // If s doesn't start with prefix, s is returned unchanged.
 func TrimPrefix(s, prefix []byte) []byte {
@@ -226,6 +317,9 @@ func TrimPrefix(s, prefix []byte) []byte {
 		}
 		return s
 	}
+	if i < len(prefix) {
+		return s // s is shorter than prefix
+	}
 	return s[i:]
 
 hasUnicode:
@@ -284,8 +378,19 @@ func hasSuffixUnicode(s, suffix []byte) (bool, int) {
 	}
 
 	t := suffix
-	i := ns - 1
-	j := nt - 1
+	n := ns
+	if nt < n {
+		n = nt
+	}
+	if bytealg.NativeMismatchFold && n >= minMismatchFold {
+		// Trim the longest common suffix that is ASCII and equal ignoring
+		// case (this does not change the indexes of s).
+		k := bytealg.LastMismatchFold(s[ns-n:], t[nt-n:]) + 1
+		s = s[:ns-n+k]
+		t = t[:nt-n+k]
+	}
+	i := len(s) - 1
+	j := len(t) - 1
 	for ; i >= 0 && j >= 0; i, j = i-1, j-1 {
 		sr := s[i]
 		tr := t[j]
@@ -334,25 +439,45 @@ func TrimSuffix(s, suffix []byte) []byte {
 	return s
 }
 
-// bruteForceIndexUnicode performs a brute-force search for substr in s.
-func bruteForceIndexUnicode(s, substr []byte) int {
+// needleFolds stores the case-folds of two adjacent runes of a needle (the
+// anchor runes) that are searched for to find candidate matches. Looking
+// these up is the bulk of the setup cost of Index, so they are stored
+// separately to allow Finder to compute them only once.
+type needleFolds struct {
+	substr         []byte
+	off            int     // byte offset of the anchor runes in substr
+	nr             int     // number of runes in substr before the anchor runes
+	sz             int     // encoded size of the anchor runes
+	u0, l0, u1, l1 rune    // upper and lower case forms of the anchor runes
+	folds0, folds1 [2]rune // folds of the anchor runes excluding upper/lower
+}
+
+// makeNeedleFolds returns the needleFolds for substr, which must contain at
+// least two runes, that are anchored on the runes at byte offset off.
+//
+// NB: bruteForceIndex requires an offset of zero.
+func makeNeedleFolds(substr []byte, off int) needleFolds {
 	var u0, u1 rune
 	var sz0, sz1 int
-	if substr[0] < utf8.RuneSelf {
-		u0, sz0 = rune(substr[0]), 1
+	if substr[off] < utf8.RuneSelf {
+		u0, sz0 = rune(substr[off]), 1
 	} else {
-		u0, sz0 = utf8.DecodeRune(substr)
+		u0, sz0 = utf8.DecodeRune(substr[off:])
 	}
-	if substr[sz0] < utf8.RuneSelf {
-		u1, sz1 = rune(substr[sz0]), 1
+	if substr[off+sz0] < utf8.RuneSelf {
+		u1, sz1 = rune(substr[off+sz0]), 1
 	} else {
-		u1, sz1 = utf8.DecodeRune(substr[sz0:])
+		u1, sz1 = utf8.DecodeRune(substr[off+sz0:])
 	}
+
+	// hasFolds{0,1} should be rare so consider optimizing
+	// the no folds case
 	folds0 := tables.FoldMapExcludingUpperLower(u0)
 	folds1 := tables.FoldMapExcludingUpperLower(u1)
-	hasFolds0 := folds0[0] != 0
-	hasFolds1 := folds1[0] != 0
-	needle := substr[sz0+sz1:]
+
+	// TODO: we can possibly get rid of the ToUpperLower function
+	// and table since it's not always on the critical path and it
+	// adds a a lot to the size of this package
 
 	// Ugly hack
 	var l0, l1 rune
@@ -373,6 +498,42 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 		u1, l1, _ = tables.ToUpperLower(u1)
 	}
 
+	return needleFolds{
+		substr: substr,
+		off:    off,
+		nr:     utf8.RuneCount(substr[:off]),
+		sz:     sz0 + sz1,
+		u0:     u0,
+		l0:     l0,
+		u1:     u1,
+		l1:     l1,
+		folds0: folds0,
+		folds1: folds1,
+	}
+}
+
+// invalid reports if either of the anchor runes of the needle are invalid.
+func (nf *needleFolds) invalid() bool {
+	return nf.u0 == utf8.RuneError || nf.u1 == utf8.RuneError
+}
+
+// bruteForceIndexUnicode performs a brute-force search for substr in s and
+// returns the index and length in bytes of the first match, or -1, 0.
+func bruteForceIndexUnicode(s, substr []byte) (int, int) {
+	nf := makeNeedleFolds(substr, 0)
+	return nf.bruteForceIndex(s)
+}
+
+// bruteForceIndex performs a brute-force search for the needle in s and
+// returns the index and length in bytes of the first match, or -1, 0.
+func (nf *needleFolds) bruteForceIndex(s []byte) (int, int) {
+	substr := nf.substr
+	u0, l0, u1, l1 := nf.u0, nf.l0, nf.u1, nf.l1
+	folds0, folds1 := nf.folds0, nf.folds1
+	hasFolds0 := folds0[0] != 0
+	hasFolds1 := folds1[0] != 0
+	needle := substr[nf.sz:]
+
 	// Limit search space.
 	t := len(s) - len(substr)/3 + 2
 	if t > len(s) {
@@ -386,9 +547,9 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 		// Fast check for the first rune.
 		i := 0
 		if u0 != utf8.RuneError && u1 != utf8.RuneError {
-			i = bytes.Index(s, substr[:sz0+sz1])
+			i = bytes.Index(s, substr[:nf.sz])
 			if i < 0 {
-				return -1
+				return -1, 0
 			}
 		}
 		for i < t {
@@ -422,9 +583,9 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				continue
 			}
 
-			match, noMore := hasPrefixUnicode(s[i+n0+n1:], needle)
+			match, noMore, m := hasPrefixUnicodeLen(s[i+n0+n1:], needle)
 			if match {
-				return i
+				return i, n0 + n1 + m
 			}
 			if noMore {
 				break
@@ -434,7 +595,7 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				i += n1 // Skip 2 runes when possible
 			}
 		}
-		return -1
+		return -1, 0
 	case !hasFolds0 && !hasFolds1:
 		// TODO: check is adding a fast check for l0 and u0 is faster
 		i := 0
@@ -469,9 +630,9 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				continue
 			}
 
-			match, noMore := hasPrefixUnicode(s[i+n0+n1:], needle)
+			match, noMore, m := hasPrefixUnicodeLen(s[i+n0+n1:], needle)
 			if match {
-				return i
+				return i, n0 + n1 + m
 			}
 			if noMore {
 				break
@@ -481,7 +642,7 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				i += n1 // Skip 2 runes when possible
 			}
 		}
-		return -1
+		return -1, 0
 	default:
 		// TODO: see if there is a better cutoff to use
 		i := 0
@@ -520,9 +681,9 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				}
 			}
 
-			match, noMore := hasPrefixUnicode(s[i+n0+n1:], needle)
+			match, noMore, m := hasPrefixUnicodeLen(s[i+n0+n1:], needle)
 			if match {
-				return i
+				return i, n0 + n1 + m
 			}
 			if noMore {
 				break
@@ -532,8 +693,97 @@ func bruteForceIndexUnicode(s, substr []byte) int {
 				i += n1 // Skip 2 runes when possible
 			}
 		}
-		return -1
+		return -1, 0
+	}
+}
+
+// asciiNeedle reports whether s consists only of ASCII characters and if
+// it contains any of [KkSs], which also match the non-ASCII Kelvin K (U+212A)
+// and long S (U+017F). ASCII needles are searched for with indexASCII.
+func asciiNeedle(s []byte) (ascii, ks bool) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 'K', 'S', 'k', 's':
+			ks = true
+		default:
+			if c >= utf8.RuneSelf {
+				return false, false
+			}
+		}
 	}
+	return true, ks
+}
+
+// indexASCII returns the index of the ASCII needle substr in s using only
+// ASCII case-folding. Like bytes.Index, it searches for the rarer byte of
+// the pair at offsets i1 and i2 of substr with IndexByte and switches to
+// the SIMD packed pair search once IndexByte produces too many false
+// positives.
+//
+// If ks is true (substr contains [KkSs]) a match that contains the Kelvin K
+// or long S in s may have been missed. Such a match contains a non-ASCII
+// byte within len(substr) bytes of its start, so there cannot be one before
+// the returned index if s is ASCII up to the end of the match (or all of s
+// if there is no match). The returned bool is false if this does not hold
+// and the result is not valid.
+func indexASCII(s, substr []byte, i1, i2 int, ks bool) (int, bool) {
+	k := i1
+	if byteRank[_lower[substr[i2]]] < byteRank[_lower[substr[i1]]] {
+		k = i2
+	}
+	c := substr[k]
+	n := len(substr)
+	t := len(s) - n + 1
+	i := -1
+	for j, fails := 0, 0; j < t; {
+		o := bytealg.IndexByte(s[j+k:], c)
+		if o < 0 {
+			// There are no matches, including those that contain the
+			// Kelvin K or long S, if c does not occur in s and does not
+			// match either of them.
+			if l := _lower[c]; fails == 0 && l != 'k' && l != 's' {
+				return -1, true
+			}
+			break
+		}
+		j += o
+		if j >= t {
+			break
+		}
+		if equalFoldASCII(s[j:j+n], substr) {
+			i = j
+			break
+		}
+		fails++
+		j++
+		if fails > bytealg.Cutover(j) {
+			if o := bytealg.IndexCase(s[j:], substr, i1, i2); o >= 0 {
+				i = j + o
+			}
+			break
+		}
+	}
+	if ks {
+		end := len(s)
+		if i >= 0 {
+			end = i + n
+		}
+		if bytealg.IndexByteNonASCII(s[:end]) != -1 {
+			return -1, false
+		}
+	}
+	return i, true
+}
+
+// equalFoldASCII reports whether s and the ASCII slice t, which must be
+// the same length, are equal under ASCII case-folding.
+func equalFoldASCII(s, t []byte) bool {
+	for i := 0; i < len(s); i++ {
+		if _lower[s[i]] != _lower[t[i]] {
+			return false
+		}
+	}
+	return true
 }
 
 // nonLetterASCII checks if the first 32 bytes of s consist only of
@@ -563,6 +813,14 @@ func nonLetterASCII(s []byte) bool {
 // This is required because we guarantee that the result of Index would equal
 // if compared with [strings.EqualFold].
 func Index(s, substr []byte) int {
+	i, _ := indexLen(s, substr)
+	return i
+}
+
+// indexLen returns the index of the first instance of substr in s and the
+// length in bytes of the match, which may differ from len(substr). If substr
+// is not present in s the returned index is -1 and the length is undefined.
+func indexLen(s, substr []byte) (int, int) {
 	n := len(substr)
 	var r rune
 	var size int
@@ -575,22 +833,22 @@ func Index(s, substr []byte) int {
 	}
 	switch {
 	case n == 0:
-		return 0
+		return 0, 0
 	case n == 1 && r != utf8.RuneError:
-		return IndexByte(s, byte(r))
+		return indexByte(s, byte(r))
 	case n == size:
-		return IndexRune(s, r)
+		return indexRune(s, r)
 	case n >= len(s):
 		if n > len(s)*3 {
-			return -1
+			return -1, 0
 		}
 		// Match here is possible due to upper/lower case runes
 		// having different encoded sizes.
 		//
 		// Fast check to see if s contains the first character of substr.
-		i := IndexRune(s, r)
+		i, _ := indexRune(s, r)
 		if i < 0 {
-			return -1
+			return -1, 0
 		}
 		// Reduce the search space
 		s = s[i:]
@@ -598,13 +856,24 @@ func Index(s, substr []byte) int {
 		// to check for it to see if the longer needle (substr) could
 		// possibly match the shorter haystack (s).
 		if n > len(s)*2 && !containsKelvin(substr) {
-			return -1
-		}
-		// NB: until disproven this is sufficiently fast (and maybe fastest)
-		if o := bruteForceIndexUnicode(s, substr); o != -1 {
-			return o + i
+			return -1, 0
 		}
-		return -1
+		var o, size int
+		if n >= twoWayMinLen {
+			o, size = indexTwoWay(s, substr)
+		} else {
+			// NB: until disproven this is sufficiently fast (and maybe fastest)
+			o, size = bruteForceIndexUnicode(s, substr)
+		}
+		if o != -1 {
+			return o + i, size
+		}
+		return -1, 0
+	case n >= twoWayMinLen:
+		// The cost of verifying each candidate found by searching for the
+		// first runes of substr is proportional to n, so use Two-Way which
+		// is linear in the worst case.
+		return indexTwoWay(s, substr)
 	case n <= maxLen: // WARN: 32 is for arm64 (see: bytealg.MaxLen)
 		// WARN:
 		//  * this does not take non-folding runes into account
@@ -623,7 +892,18 @@ func Index(s, substr []byte) int {
 		//  * We should skip this check if s is small
 		//
 		if bytealg.NativeIndex && n <= 32 && nonLetterASCII(substr) {
-			return bytealg.Index(s, substr)
+			return bytealg.Index(s, substr), n
+		}
+		// Use ASCII only searching if substr is ASCII. This only folds
+		// ASCII letters so the result is not valid if substr contains a
+		// letter that matches the Kelvin K or long S and s is not ASCII.
+		if bytealg.NativeIndexCase {
+			if ascii, ks := asciiNeedle(substr); ascii {
+				i1, i2 := rareBytes(substr)
+				if i, ok := indexASCII(s, substr, i1, i2, ks); ok {
+					return i, n
+				}
+			}
 		}
 		// TODO: tune this
 		if len(s) <= maxBruteForce {
@@ -632,57 +912,48 @@ func Index(s, substr []byte) int {
 		// fallthrough
 	}
 
-	var u0, u1 rune
-	var sz0, sz1 int
-	if substr[0] < utf8.RuneSelf {
-		u0, sz0 = rune(substr[0]), 1
-	} else {
-		u0, sz0 = utf8.DecodeRune(substr)
-	}
-	if substr[sz0] < utf8.RuneSelf {
-		u1, sz1 = rune(substr[sz0]), 1
-	} else {
-		u1, sz1 = utf8.DecodeRune(substr[sz0:])
-	}
+	// Search for the rarest pair of runes in substr instead of the first
+	// two, which are often common (e.g. the "th" of "the").
+	nf := makeNeedleFolds(substr, rareOffset(substr))
 
-	// Use Rabin-Karp if either of the first two runes are invalid
-	// this is slower but simplifies the logic below.
-	if u0 == utf8.RuneError || u1 == utf8.RuneError {
+	// Use Rabin-Karp if either of the anchor runes are invalid (which only
+	// happens if either of the first two runes of substr are invalid) this
+	// is slower but simplifies the logic below.
+	if nf.invalid() {
 		return indexRabinKarpUnicode(s, substr)
 	}
 
-	// hasFolds{0,1} should be rare so consider optimizing
-	// the no folds case
-	folds0 := tables.FoldMapExcludingUpperLower(u0)
-	folds1 := tables.FoldMapExcludingUpperLower(u1)
-	needle := substr[sz0+sz1:]
-
-	// TODO: we can possibly get rid of the ToUpperLower function
-	// and table since it's not always on the critical path and it
-	// adds a a lot to the size of this package
-
-	// Ugly hack
-	var l0, l1 rune
-	switch u0 {
-	case 'İ':
-		l0 = 'İ'
-	case 'ı':
-		l0 = 'ı'
-	default:
-		u0, l0, _ = tables.ToUpperLower(u0)
+	i, size, done := nf.index(s)
+	if done {
+		return i, size
 	}
-	switch u1 {
-	case 'İ':
-		l1 = 'İ'
-	case 'ı':
-		l1 = 'ı'
-	default:
-		u1, l1, _ = tables.ToUpperLower(u1)
+	j, size := indexTwoWay(s[i:], substr)
+	if j < 0 {
+		return -1, 0
 	}
+	return i + j, size
+}
+
+// index returns the index and length in bytes of the first instance of the
+// needle in s, or -1, 0 if the needle is not present in s. If searching by
+// the anchor runes of the needle produces too many false positives index
+// stops and returns false and the index in s from which the caller should
+// continue using Two-Way.
+//
+// Candidate matches are found by searching for the anchor runes and are then
+// verified by matching the runes of the needle that follow the anchor runes
+// forwards and the runes that precede them backwards. Since the anchor runes
+// of the candidates are found in order, so are the matches.
+func (nf *needleFolds) index(s []byte) (int, int, bool) {
+	substr := nf.substr
+	u0, l0, u1, l1 := nf.u0, nf.l0, nf.u1, nf.l1
+	folds0, folds1 := nf.folds0, nf.folds1
+	prefix := substr[:nf.off]
+	needle := substr[nf.off+nf.sz:]
 
 	fails := 0
 	// TODO: see if we can stop sooner.
-	t := len(s) - len(substr)/3 + 1
+	t := len(s) - len(substr[nf.off:])/3 + 2
 	if t > len(s) {
 		t = len(s)
 	}
@@ -705,14 +976,14 @@ func Index(s, substr []byte) int {
 				o, sz = indexRune(s[i+n0:], l0)
 			}
 			if o < 0 {
-				return -1
+				return -1, 0, true
 			}
 			i += o + n0
 			n0 = sz // The rune we matched on might not be the same size as c0
 		}
 
 		if i+n0 >= t {
-			return -1
+			return -1, 0, true
 		}
 
 		var r1 rune
@@ -724,12 +995,17 @@ func Index(s, substr []byte) int {
 		}
 
 		if r1 == u1 || r1 == l1 || (folds1[0] != 0 && (r1 == folds1[0] || r1 == folds1[1])) {
-			match, exhausted := hasPrefixUnicode(s[i+n0+n1:], needle)
+			match, exhausted, m := hasPrefixUnicodeLen(s[i+n0+n1:], needle)
 			if match {
-				return i
-			}
-			if exhausted {
-				return -1
+				end := i + n0 + n1 + m
+				if len(prefix) == 0 {
+					return i, end - i, true
+				}
+				if match, j := hasSuffixUnicode(s[:i], prefix); match {
+					return j, end - j, true
+				}
+			} else if exhausted {
+				return -1, 0, true
 			}
 		}
 		fails++
@@ -752,19 +1028,30 @@ func Index(s, substr []byte) int {
 			// more expensive is particularly relevant us since
 			// our "Equal" is drastically more expensive than
 			// the stdlibs.
-			j := indexRabinKarpUnicode(s[i:], substr)
-			if j < 0 {
-				return -1
+			//
+			// Matches whose anchor runes start at or after i
+			// start at most nf.nr runes before i.
+			for k := 0; k < nf.nr && i > 0; k++ {
+				_, n := utf8.DecodeLastRune(s[:i])
+				i -= n
 			}
-			return i + j
+			return i, 0, false
 		}
 	}
-	return -1
+	return -1, 0, true
 }
 
 // LastIndex returns the index of the last instance of substr in s, or -1 if
 // substr is not present in s.
 func LastIndex(s, substr []byte) int {
+	i, _ := lastIndexLen(s, substr)
+	return i
+}
+
+// lastIndexLen returns the index of the last instance of substr in s and the
+// length in bytes of the match, which may differ from len(substr). If substr
+// is not present in s the returned index is -1 and the length is undefined.
+func lastIndexLen(s, substr []byte) (int, int) {
 	n := len(substr)
 	var r rune
 	var size int
@@ -777,19 +1064,21 @@ func LastIndex(s, substr []byte) int {
 	}
 	switch {
 	case n == 0:
-		return len(s)
+		return len(s), 0
 	case n == 1 && r != utf8.RuneError:
-		return LastIndexByte(s, substr[0])
+		return lastIndexByte(s, substr[0])
 	case n == size:
 		return lastIndexRune(s, r)
 	case n >= len(s):
 		if n > len(s)*3 {
-			return -1
+			return -1, 0
 		}
 		if n > len(s)*2 && !containsKelvin(substr) {
-			return -1
+			return -1, 0
 		}
-		// fallthrough
+	}
+	if n >= twoWayMinLen {
+		return lastIndexTwoWay(s, substr)
 	}
 	return indexRabinKarpRevUnicode(s, substr)
 }
@@ -871,47 +1160,38 @@ func indexByte(s []byte, c byte) (int, int) {
 // LastIndexByte returns the index of the last instance of c in s, or -1
 // if c is not present in s.
 func LastIndexByte(s []byte, c byte) int {
-	if len(s) == 0 {
-		return -1
-	}
-	if !isAlpha(c) {
-		return bytes.LastIndexByte(s, c)
-	}
+	i, _ := lastIndexByte(s, c)
+	return i
+}
+
+// lastIndexByte returns the index of the last instance of c in s, or -1 if c
+// is not present in s, and the size (in bytes) of the character matched.
+func lastIndexByte(s []byte, c byte) (int, int) {
+	n := bytealg.LastIndexByte(s, c)
 
 	// Special case for Unicode characters that map to ASCII.
 	var r rune
+	var sz int
 	switch c {
 	case 'K', 'k':
-		r = 'K'
+		r = 'K' // Kelvin K
+		sz = 3
 	case 'S', 's':
-		r = 'ſ'
+		r = 'ſ' // Latin small letter long S
+		sz = 2
 	default:
-		c |= ' ' // convert to lower case
-		for i := len(s) - 1; i >= 0; i-- {
-			if s[i]|' ' == c {
-				return i
-			}
-		}
-		return -1
+		return n, 1
 	}
 
-	// Handle ASCII characters with Unicode mappings
-	c |= ' ' // convert to lower case
-	for i := len(s); i > 0; {
-		if s[i-1] < utf8.RuneSelf {
-			i--
-			if s[i]|' ' == c {
-				return i
-			}
-		} else {
-			sr, size := utf8.DecodeLastRune(s[:i])
-			i -= size
-			if sr == r {
-				return i
-			}
-		}
+	// Search for Unicode characters that map to ASCII byte 'c' after the
+	// last instance of c.
+	if bytealg.IndexByteNonASCII(s[n+1:]) == -1 {
+		return n, 1
 	}
-	return -1
+	if o := bytes.LastIndex(s[n+1:], []byte(string(r))); o != -1 {
+		return n + 1 + o, sz
+	}
+	return n, 1
 }
 
 // IndexRune returns the index of the first instance of the Unicode code point
@@ -942,7 +1222,7 @@ func indexRune(s []byte, r rune) (int, int) {
 		for i := 0; i < len(s); {
 			r1, n := utf8.DecodeRune(s[i:])
 			if r1 == utf8.RuneError {
-				return i, utf8.RuneLen(r1)
+				return i, n
 			}
 			i += n
 		}
@@ -1161,36 +1441,37 @@ func indexRune2(s []byte, lower, upper rune) (int, int) {
 }
 
 // lastIndexRune returns the last index of the first instance of the Unicode
-// code point r, or -1 if rune is not present in s.
+// code point r, or -1 if rune is not present in s, and the size of the rune
+// that matched.
 // If r is utf8.RuneError, it returns the last instance of any
 // invalid UTF-8 byte sequence.
-func lastIndexRune(s []byte, r rune) int {
+func lastIndexRune(s []byte, r rune) (int, int) {
 	switch {
 	case r == utf8.RuneError:
 		for i := len(s); i > 0; {
 			sr, size := utf8.DecodeLastRune(s[:i])
 			i -= size
 			if sr == utf8.RuneError {
-				return i
+				return i, size
 			}
 		}
-		return -1
+		return -1, 0
 	case !utf8.ValidRune(r):
-		return -1
+		return -1, 0
 	default:
 		if folds := tables.FoldMap(r); folds != nil {
 			for i := len(s); i > 0; {
 				var sr rune
+				size := 1
 				if sr = rune(s[i-1]); sr < utf8.RuneSelf {
 					i--
 				} else {
-					var size int
 					sr, size = utf8.DecodeLastRune(s[:i])
 					i -= size
 				}
 				for j := 0; j < len(folds) && folds[j] != 0; j++ {
 					if sr == rune(folds[j]) {
-						return i
+						return i, size
 					}
 				}
 			}
@@ -1198,19 +1479,19 @@ func lastIndexRune(s []byte, r rune) int {
 			u, l, _ := tables.ToUpperLower(r)
 			for i := len(s); i > 0; {
 				var sr rune
+				size := 1
 				if sr = rune(s[i-1]); sr < utf8.RuneSelf {
 					i--
 				} else {
-					var size int
 					sr, size = utf8.DecodeLastRune(s[:i])
 					i -= size
 				}
 				if sr == u || sr == l {
-					return i
+					return i, size
 				}
 			}
 		}
-		return -1
+		return -1, 0
 	}
 }
 
@@ -1274,10 +1555,18 @@ func hashStrRevUnicode(sep []byte) (uint32, uint32, int) {
 }
 
 // indexRabinKarpRevUnicode uses the Rabin-Karp search algorithm to return the
-// index of the last occurrence of substr in s, or -1 if not present.
-func indexRabinKarpRevUnicode(s, substr []byte) int {
-	// Reverse Rabin-Karp search
+// index and length in bytes of the last occurrence of substr in s, or -1, 0 if
+// not present.
+func indexRabinKarpRevUnicode(s, substr []byte) (int, int) {
 	hashss, pow, n := hashStrRevUnicode(substr)
+	return indexRabinKarpRevUnicodeHash(s, substr, hashss, pow, n)
+}
+
+// indexRabinKarpRevUnicodeHash is indexRabinKarpRevUnicode with the hash,
+// multiplicative factor, and rune count of substr already computed by
+// hashStrRevUnicode.
+func indexRabinKarpRevUnicodeHash(s, substr []byte, hashss, pow uint32, n int) (int, int) {
+	// Reverse Rabin-Karp search
 	var h uint32
 	i := len(s)
 	for i > 0 {
@@ -1297,10 +1586,10 @@ func indexRabinKarpRevUnicode(s, substr []byte) int {
 		}
 	}
 	if n > 0 {
-		return -1
+		return -1, 0
 	}
 	if h == hashss && HasSuffix(s, substr) {
-		return i // WARN
+		return i, len(s) - i // WARN
 	}
 	j := len(s)
 	for i > 0 {
@@ -1326,17 +1615,25 @@ func indexRabinKarpRevUnicode(s, substr []byte) int {
 		i -= n0
 		j -= n1
 		if h == hashss && HasSuffix(s[i:j], substr) {
-			return i
+			return i, j - i
 		}
 	}
-	return -1
+	return -1, 0
 }
 
 // indexRabinKarpUnicode uses the Rabin-Karp search algorithm to return the
-// index of the first occurrence of substr in s, or -1 if not present.
-func indexRabinKarpUnicode(s, substr []byte) int {
-	// Rabin-Karp search
+// index and length in bytes of the first occurrence of substr in s, or -1, 0
+// if not present.
+func indexRabinKarpUnicode(s, substr []byte) (int, int) {
 	hashss, pow, n := hashStrUnicode(substr)
+	return indexRabinKarpUnicodeHash(s, substr, hashss, pow, n)
+}
+
+// indexRabinKarpUnicodeHash is indexRabinKarpUnicode with the hash,
+// multiplicative factor, and rune count of substr already computed by
+// hashStrUnicode.
+func indexRabinKarpUnicodeHash(s, substr []byte, hashss, pow uint32, n int) (int, int) {
+	// Rabin-Karp search
 	var h uint32
 	j := 0
 	for j < len(s) {
@@ -1356,7 +1653,7 @@ func indexRabinKarpUnicode(s, substr []byte) int {
 		}
 	}
 	if h == hashss && HasPrefix(s, substr) {
-		return 0
+		return 0, j
 	}
 	i := 0 // start of rolling hash
 	for j < len(s) {
@@ -1380,10 +1677,10 @@ func indexRabinKarpUnicode(s, substr []byte) int {
 		j += n0
 		i += n1
 		if h == hashss && HasPrefix(s[i:j], substr) {
-			return i
+			return i, j - i
 		}
 	}
-	return -1
+	return -1, 0
 }
 
 func countRune(s []byte, r rune) (n int) {
@@ -1418,30 +1715,13 @@ func Count(s, substr []byte) int {
 		return n
 	}
 	n := 0
-	runeCount := -1 // Lazily calculated after the first match
 	for {
-		i := Index(s, substr)
+		i, size := IndexLen(s, substr)
 		if i == -1 {
 			return n
 		}
 		n++
-		if runeCount < 0 {
-			runeCount = utf8.RuneCount(substr)
-		}
-		o := runeCount
-		s = s[i:]
-		// Trim substr prefix from s.
-		j := 0
-		for j < len(s) && o > 0 {
-			if s[j] < utf8.RuneSelf {
-				j++
-			} else {
-				_, sz := utf8.DecodeRune(s[j:])
-				j += sz
-			}
-			o--
-		}
-		s = s[j:]
+		s = s[i+size:]
 	}
 }
 
@@ -1460,15 +1740,12 @@ func ContainsRune(s []byte, r rune) bool {
 	return IndexRune(s, r) >= 0
 }
 
-// asciiSet is a 32-byte value, where each bit represents the presence of a
-// given ASCII character in the set. The 128-bits of the lower 16 bytes,
-// starting with the least-significant bit of the lowest word to the
-// most-significant bit of the highest word, map to the full range of all
-// 128 ASCII characters. The 128-bits of the upper 16 bytes will be zeroed,
-// ensuring that any non-ASCII character will be reported as not in the set.
-// This allocates a total of 32 bytes even though the upper half
-// is unused to avoid bounds checks in asciiSet.contains.
-type asciiSet [8]uint32
+// asciiSet is a 16-byte value indexed by the low nibble of a character,
+// where bit i of each byte represents the presence of the ASCII character
+// with the high nibble i in the set. Since non-ASCII characters have a high
+// nibble of 8 or more they will be reported as not in the set. This is the
+// layout of the nibble table used by bytealg.IndexAnyASCII.
+type asciiSet [16]uint8
 
 // makeASCIISet creates a set of ASCII characters and reports whether all
 // characters in chars are ASCII.
@@ -1479,10 +1756,10 @@ func makeASCIISet(s, chars []byte) (as asciiSet, ok bool) {
 		if c >= utf8.RuneSelf {
 			return as, false
 		}
-		as[c/32] |= 1 << (c % 32)
+		as[c&0xf] |= 1 << (c >> 4)
 		if isAlpha(c) {
 			c ^= ' ' // swap case
-			as[c/32] |= 1 << (c % 32)
+			as[c&0xf] |= 1 << (c >> 4)
 			// Can't use ASCII when non-ASCII chars fold to ASCII chars.
 			switch c {
 			case 'K', 'k', 'S', 's':
@@ -1506,10 +1783,10 @@ ascii:
 		if c >= utf8.RuneSelf {
 			return as, false
 		}
-		as[c/32] |= 1 << (c % 32)
+		as[c&0xf] |= 1 << (c >> 4)
 		if isAlpha(c) {
 			c ^= ' ' // swap case
-			as[c/32] |= 1 << (c % 32)
+			as[c&0xf] |= 1 << (c >> 4)
 		}
 	}
 	return as, true
@@ -1517,7 +1794,7 @@ ascii:
 
 // contains reports whether c is inside the set.
 func (as *asciiSet) contains(c byte) bool {
-	return (as[c/32] & (1 << (c % 32))) != 0
+	return as[c&0xf]&(1<<(c>>4)) != 0
 }
 
 // IndexAny returns the index of the first instance of any Unicode code point
@@ -1538,12 +1815,7 @@ func IndexAny(s, chars []byte) int {
 	if len(s) > 8 {
 		if as, isASCII := makeASCIISet(s, chars); isASCII {
 			// TODO: should we convert Kelvin and Small Long S to ASCII here?
-			for i := 0; i < len(s); i++ {
-				if as.contains(s[i]) {
-					return i
-				}
-			}
-			return -1
+			return bytealg.IndexAnyASCII(s, (*[16]byte)(&as))
 		}
 	}
 	if len(s) > len(chars)*2 {
@@ -1609,12 +1881,7 @@ func LastIndexAny(s, chars []byte) int {
 	}
 	if len(s) > 8 {
 		if as, isASCII := makeASCIISet(s, chars); isASCII {
-			for i := len(s) - 1; i >= 0; i-- {
-				if as.contains(s[i]) {
-					return i
-				}
-			}
-			return -1
+			return bytealg.LastIndexAnyASCII(s, (*[16]byte)(&as))
 		}
 	}
 	if len(chars) == 1 {
@@ -1640,6 +1907,53 @@ func LastIndexAny(s, chars []byte) int {
 	return -1
 }
 
+// IndexLen returns the index of the first instance of substr in s and the
+// length in bytes of the match, or -1, 0 if substr is not present in s.
+// The length of the match may differ from len(substr), for example:
+//
+//	IndexLen([]byte("\u212Aelvin"), []byte("k")) // returns 0, 3
+func IndexLen(s, substr []byte) (int, int) {
+	i, n := indexLen(s, substr)
+	if i < 0 {
+		return -1, 0
+	}
+	return i, n
+}
+
+// LastIndexLen returns the index of the last instance of substr in s and the
+// length in bytes of the match, or -1, 0 if substr is not present in s.
+// The length of the match may differ from len(substr).
+func LastIndexLen(s, substr []byte) (int, int) {
+	i, n := lastIndexLen(s, substr)
+	if i < 0 {
+		return -1, 0
+	}
+	return i, n
+}
+
+// HasPrefixLen tests whether s begins with prefix ignoring case.
+// It also returns the length in bytes of the prefix in s, which may differ
+// from len(prefix).
+func HasPrefixLen(s, prefix []byte) (bool, int) {
+	if len(prefix) == 0 {
+		return true, 0
+	}
+	if ss := TrimPrefix(s, prefix); len(ss) != len(s) {
+		return true, len(s) - len(ss)
+	}
+	return false, 0
+}
+
+// HasSuffixLen tests whether s ends with suffix ignoring case.
+// It also returns the length in bytes of the suffix in s, which may differ
+// from len(suffix).
+func HasSuffixLen(s, suffix []byte) (bool, int) {
+	if match, i := hasSuffixUnicode(s, suffix); match {
+		return true, len(s) - i
+	}
+	return false, 0
+}
+
 // Cut slices s around the first instance of sep,
 // returning the text before and after sep.
 // The found result reports whether sep appears in s.
@@ -1647,21 +1961,8 @@ func LastIndexAny(s, chars []byte) int {
 //
 // Cut returns slices of the original slice s, not copies.
 func Cut(s, sep []byte) (before, after []byte, found bool) {
-	// TODO: both Cut and Count would benefit from Index returning the
-	// number of bytes consumed from sep - consider adding this.
-	if i := Index(s, sep); i >= 0 {
-		after = s[i:]
-		// WARN: make sure this works with invalid runes
-		// trim sep from s
-		for n := utf8.RuneCount(sep); n > 0 && len(after) > 0; n-- {
-			if after[0] < utf8.RuneSelf {
-				after = after[1:]
-			} else {
-				_, n := utf8.DecodeRune(after)
-				after = after[n:]
-			}
-		}
-		return s[:i], after, true
+	if i, n := IndexLen(s, sep); i >= 0 {
+		return s[:i], s[i+n:], true
 	}
 	return s, nil, false
 }
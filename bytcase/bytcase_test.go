@@ -1,6 +1,7 @@
 package bytcase
 
 import (
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -31,6 +32,10 @@ func TestIndexInvalid(t *testing.T) {
 	test.IndexInvalid(t, test.ByteIndexFunc(Index))
 }
 
+func TestIndexPeriodic(t *testing.T) {
+	test.IndexPeriodic(t, test.ByteIndexFunc(Index))
+}
+
 func TestIndexRuneIndexParity(t *testing.T) {
 	test.IndexRuneIndexParity(t, test.ByteIndexFunc(Index),
 		test.ByteIndexRuneFunc(IndexRune))
@@ -63,7 +68,7 @@ func TestIndexKelvin(t *testing.T) {
 // trigger it.
 func TestRabinKarp(t *testing.T) {
 	test.Index(t, test.WrapRabinKarp(
-		test.ByteIndexFunc(indexRabinKarpUnicode),
+		test.IndexLenCheckFunc(t, "indexRabinKarpUnicode", test.ByteIndexLenFunc(indexRabinKarpUnicode)),
 	))
 }
 
@@ -71,12 +76,12 @@ func TestRabinKarp(t *testing.T) {
 // trigger it.
 func TestRabinKarpUnicode(t *testing.T) {
 	test.IndexUnicode(t, test.WrapRabinKarp(
-		test.ByteIndexFunc(indexRabinKarpUnicode),
+		test.IndexLenCheckFunc(t, "indexRabinKarpUnicode", test.ByteIndexLenFunc(indexRabinKarpUnicode)),
 	))
 }
 
 func TestBruteForceIndexUnicode(t *testing.T) {
-	test.IndexUnicode(t, func(s, substr string) int {
+	test.IndexUnicode(t, test.IndexLenCheckFunc(t, "bruteForceIndexUnicode", func(s, substr string) (int, int) {
 		n := len(substr)
 		var size int
 		if n > 0 {
@@ -88,10 +93,10 @@ func TestBruteForceIndexUnicode(t *testing.T) {
 		}
 		if len(s) == 0 || len(substr) == 0 || n == size {
 			// Can't use brute-force here
-			return Index([]byte(s), []byte(substr))
+			return IndexLen([]byte(s), []byte(substr))
 		}
 		return bruteForceIndexUnicode([]byte(s), []byte(substr))
-	})
+	}))
 }
 
 func TestIndexAllocs(t *testing.T) {
@@ -133,6 +138,10 @@ func TestLastIndexInvalid(t *testing.T) {
 	test.LastIndexInvalid(t, test.ByteIndexFunc(LastIndex))
 }
 
+func TestLastIndexPeriodic(t *testing.T) {
+	test.LastIndexPeriodic(t, test.ByteIndexFunc(LastIndex))
+}
+
 func TestIndexRune(t *testing.T) {
 	test.IndexRune(t, test.ByteIndexRuneFunc(IndexRune))
 }
@@ -166,7 +175,13 @@ func TestContainsRune(t *testing.T) {
 }
 
 func TestLastIndexRune(t *testing.T) {
-	test.LastIndexRune(t, test.ByteIndexRuneFunc(lastIndexRune))
+	test.LastIndexRune(t, func(s string, r rune) int {
+		i, n := lastIndexRune([]byte(s), r)
+		if i >= 0 && !strings.EqualFold(s[i:i+n], string(r)) {
+			t.Errorf("lastIndexRune(%q, %q) = %d, %d: invalid match length", s, r, i, n)
+		}
+		return i
+	})
 }
 
 func TestIndexByte(t *testing.T) {
@@ -193,6 +208,19 @@ func TestHasPrefix(t *testing.T) {
 	test.HasPrefix(t, test.BytePrefixFunc(hasPrefixUnicode))
 }
 
+func TestHasPrefixUnicodeLen(t *testing.T) {
+	fn := func(s, prefix string) (bool, bool) {
+		match, exhausted, n := hasPrefixUnicodeLen([]byte(s), []byte(prefix))
+		if (match && !strings.EqualFold(s[:n], prefix)) || (!match && n != 0) {
+			t.Errorf("hasPrefixUnicodeLen(%q, %q) = %t, %t, %d: invalid match length",
+				s, prefix, match, exhausted, n)
+		}
+		return match, exhausted
+	}
+	test.HasPrefix(t, fn)
+	test.HasPrefixFuzz(t, fn)
+}
+
 func TestTrimPrefix(t *testing.T) {
 	test.TrimPrefix(t, test.ByteTrimFunc(TrimPrefix))
 }
@@ -219,6 +247,34 @@ func TestTestLastIndexAny(t *testing.T) {
 	test.LastIndexAny(t, test.ByteIndexFunc(LastIndexAny))
 }
 
+func TestIndexLen(t *testing.T) {
+	test.IndexLen(t, test.ByteIndexLenFunc(IndexLen))
+	fn := test.IndexLenCheckFunc(t, "IndexLen", test.ByteIndexLenFunc(IndexLen))
+	test.Index(t, fn)
+	test.IndexUnicode(t, fn)
+	test.IndexKelvin(t, fn)
+	test.IndexInvalid(t, fn)
+	test.IndexPeriodic(t, fn)
+	test.IndexFuzz(t, fn)
+}
+
+func TestLastIndexLen(t *testing.T) {
+	test.LastIndexLen(t, test.ByteIndexLenFunc(LastIndexLen))
+	fn := test.IndexLenCheckFunc(t, "LastIndexLen", test.ByteIndexLenFunc(LastIndexLen))
+	test.LastIndex(t, fn)
+	test.LastIndexInvalid(t, fn)
+	test.LastIndexPeriodic(t, fn)
+	test.LastIndexFuzz(t, fn)
+}
+
+func TestHasPrefixLen(t *testing.T) {
+	test.HasPrefixLen(t, test.BytePrefixLenFunc(HasPrefixLen))
+}
+
+func TestHasSuffixLen(t *testing.T) {
+	test.HasSuffixLen(t, test.BytePrefixLenFunc(HasSuffixLen))
+}
+
 func TestCut(t *testing.T) {
 	test.Cut(t, func(s, sep string) (before, after string, found bool) {
 		b, a, ok := Cut([]byte(s), []byte(sep))
@@ -0,0 +1,87 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestCharSetContains(t *testing.T) {
+	test.CharSetContains(t, func(chars string) func(rune) bool {
+		return NewCharSet([]byte(chars)).Contains
+	})
+}
+
+func TestCharSetIndexAny(t *testing.T) {
+	test.IndexAny(t, func(s, chars string) int {
+		return NewCharSet([]byte(chars)).IndexAny([]byte(s))
+	})
+}
+
+func TestCharSetLastIndexAny(t *testing.T) {
+	test.LastIndexAny(t, func(s, chars string) int {
+		return NewCharSet([]byte(chars)).LastIndexAny([]byte(s))
+	})
+}
+
+func TestCharSetCount(t *testing.T) {
+	test.CharSetCount(t, func(s, chars string) int {
+		return NewCharSet([]byte(chars)).Count([]byte(s))
+	})
+}
+
+func TestCharSetTrim(t *testing.T) {
+	test.Trim(t, test.ByteTrimFunc(func(s, cutset []byte) []byte {
+		return NewCharSet(cutset).Trim(s)
+	}))
+}
+
+func TestCharSetZeroValue(t *testing.T) {
+	var c CharSet
+	s := []byte("abc")
+	if c.Contains('a') || c.IndexAny(s) != -1 || c.LastIndexAny(s) != -1 ||
+		c.Count(s) != 0 || string(c.Trim(s)) != "abc" {
+		t.Error("the zero value CharSet must be empty")
+	}
+}
+
+func TestCharSetAllocs(t *testing.T) {
+	c := NewCharSet([]byte("aK☺"))
+	s := []byte(strings.Repeat("xyz☻", 8) + "☺")
+	allocs := testing.AllocsPerRun(100, func() {
+		if c.IndexAny(s) < 0 || c.LastIndexAny(s) < 0 || c.Count(s) != 1 {
+			t.Fatal("CharSet failed to find '☺'")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations got: %.2f", allocs)
+	}
+}
+
+func BenchmarkCharSet(b *testing.B) {
+	bench := func(b *testing.B, s, chars []byte) {
+		b.Run("IndexAny", func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				IndexAny(s, chars)
+			}
+		})
+		b.Run("CharSet", func(b *testing.B) {
+			c := NewCharSet(chars)
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				c.IndexAny(s)
+			}
+		})
+	}
+	b.Run("FewChars", func(b *testing.B) {
+		bench(b, []byte(strings.Repeat("xyz☻", 64)+"☺"), []byte("aK☺"))
+	})
+	b.Run("ManyChars", func(b *testing.B) {
+		bench(b, []byte(strings.Repeat("xyz☻", 16)+"☺"), []byte("αβγδεζηθικλμνξοπρστυφχψω☺"))
+	})
+}
@@ -9,20 +9,32 @@ allocates memory.
 Simple Unicode case-folding is used for all comparisons. This matches the
 behavior of [bytes.EqualFold].
 
+Full Unicode case-folding, where a character may fold to more than one
+character (for example "ß" folds to "ss" and "ﬁ" folds to "fi"), is opt-in
+and provided by [CompareFull], [EqualFoldFull], [IndexFull], [HasPrefixFull],
+[HasSuffixFull], and [CountFull]. Since a match may have a different length
+than the string being searched for, these functions report the length of the
+match in bytes of the searched string.
+
+Turkic (Turkish and Azerbaijani) case-folding, where 'I' folds to 'ı' and 'İ'
+folds to 'i', is provided by the methods of [Turkic], which mirror the
+functions of this package (for example: [TurkicCase.EqualFold]).
+
 Package bytcase also provides two functions for identifying non-ASCII characters
 that are not available in the bytes package: [IndexNonASCII] and
 [ContainsNonASCII].
-On amd64 and arm64 these functions are implemented in assembly and their
-performance is mostly governed by memory bandwidth.
+On amd64, arm64 and 386 these functions are implemented in assembly and
+their performance is mostly governed by memory bandwidth. Building with the
+"purego" build tag disables all assembly and uses the pure Go implementations.
 
 [bytes]: https://pkg.go.dev/bytes
 [bytcase]: https://pkg.go.dev/github.com/charlievieth/strcase/bytcase
 */
 package bytcase
 
-// BUG(cvieth): There is no mechanism for full case folding, that is, for
-// characters that involve multiple runes in the input or output
-// (see: https://pkg.go.dev/unicode#pkg-note-BUG).
+// BUG(cvieth): Full case folding, that is, for characters that involve
+// multiple runes in the input or output, is only supported by the functions
+// with a "Full" suffix (see: https://pkg.go.dev/unicode#pkg-note-BUG).
 //
 // This is a limitation of Go's [unicode] package.
 //
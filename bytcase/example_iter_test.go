@@ -0,0 +1,49 @@
+//go:build go1.23
+// +build go1.23
+
+package bytcase_test
+
+import (
+	"fmt"
+
+	"github.com/charlievieth/strcase/bytcase"
+)
+
+func ExampleAllIndexes() {
+	s := []byte("oink OINK \u212Aoink")
+	for i, n := range bytcase.AllIndexes(s, []byte("oink")) {
+		fmt.Printf("%d %d %s\n", i, n, s[i:i+n])
+	}
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	for i, n := range bytcase.AllIndexes(s, []byte("k")) {
+		fmt.Println(i, n)
+	}
+	// Output:
+	// 0 4 oink
+	// 5 4 OINK
+	// 13 4 oink
+	// 3 1
+	// 8 1
+	// 10 3
+	// 16 1
+}
+
+func ExampleSplitSeq() {
+	for part := range bytcase.SplitSeq([]byte("a AND b and c"), []byte(" and ")) {
+		fmt.Printf("%q\n", part)
+	}
+	// Output:
+	// "a"
+	// "b"
+	// "c"
+}
+
+func ExampleSplitAfterSeq() {
+	for part := range bytcase.SplitAfterSeq([]byte("aXbxc"), []byte("x")) {
+		fmt.Printf("%q\n", part)
+	}
+	// Output:
+	// "aX"
+	// "bx"
+	// "c"
+}
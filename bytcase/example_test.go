@@ -1,7 +1,9 @@
 package bytcase_test
 
 import (
+	"bytes"
 	"fmt"
+	"hash/maphash"
 	"sort"
 	"unicode/utf8"
 
@@ -189,6 +191,16 @@ func ExampleHasSuffix() {
 	// true
 }
 
+func ExampleHash() {
+	seed := maphash.MakeSeed()
+	// Kelvin K (U+212A) folds to 'k'
+	fmt.Println(bytcase.Hash(seed, []byte("Kelvin")) == bytcase.Hash(seed, []byte("\u212AELVIN")))
+	fmt.Println(bytcase.Hash(seed, []byte("Kelvin")) == bytcase.Hash(seed, []byte("Celsius")))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleIndex() {
 	fmt.Println(bytcase.Index([]byte("chicken"), []byte("KEN")))
 	fmt.Println(bytcase.Index([]byte("chicken"), []byte("DMR")))
@@ -358,3 +370,208 @@ func ExampleContainsNonASCII() {
 	// true
 	// false
 }
+
+func ExampleFinder() {
+	f := bytcase.NewFinder([]byte("GOPHER"))
+	lines := []string{
+		"The gopher sleeps",
+		"Two Gophers: gopher and GOPHER",
+		"No badgers",
+	}
+	for _, s := range lines {
+		fmt.Println(f.Index([]byte(s)), f.LastIndex([]byte(s)), f.Count([]byte(s)))
+	}
+	// Output:
+	// 4 4 1
+	// 4 24 3
+	// -1 -1 0
+}
+
+func ExampleEqualFoldFull() {
+	fmt.Println(bytcase.EqualFold([]byte("straße"), []byte("STRASSE")))
+	fmt.Println(bytcase.EqualFoldFull([]byte("straße"), []byte("STRASSE")))
+	fmt.Println(bytcase.EqualFoldFull([]byte("oﬃce"), []byte("OFFICE")))
+	// Output:
+	// false
+	// true
+	// true
+}
+
+func ExampleIndexFull() {
+	// The length of the match may differ from the length of substr.
+	s := []byte("Die Straße")
+	i, n := bytcase.IndexFull(s, []byte("STRASSE"))
+	fmt.Println(i, n, string(s[i:i+n]))
+	fmt.Println(bytcase.IndexFull([]byte("Die Strasse"), []byte("straße")))
+	fmt.Println(bytcase.IndexFull([]byte("ß"), []byte("s")))
+	// Output:
+	// 4 7 Straße
+	// 4 7
+	// -1 0
+}
+
+func ExampleTurkicCase() {
+	fmt.Println(bytcase.EqualFold([]byte("İSTANBUL"), []byte("istanbul")))
+	fmt.Println(bytcase.Turkic.EqualFold([]byte("İSTANBUL"), []byte("istanbul")))
+	fmt.Println(bytcase.Turkic.EqualFold([]byte("ISPARTA"), []byte("ısparta")))
+	fmt.Println(bytcase.Turkic.EqualFold([]byte("ISPARTA"), []byte("isparta")))
+	fmt.Println(bytcase.Turkic.Index([]byte("Diyarbakir DIYARBAKIR"), []byte("DIYARBAKIR")))
+	// Output:
+	// false
+	// true
+	// true
+	// false
+	// 11
+}
+
+func ExampleReplace() {
+	fmt.Printf("%s\n", bytcase.Replace([]byte("oink OINK oink"), []byte("K"), []byte("ky"), 2))
+	fmt.Printf("%s\n", bytcase.Replace([]byte("oink OINK oink"), []byte("OINK"), []byte("moo"), -1))
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	fmt.Printf("%s\n", bytcase.Replace([]byte("\u212Aelvin"), []byte("k"), []byte("K"), -1))
+	// Output:
+	// oinky OINky oink
+	// moo moo moo
+	// Kelvin
+}
+
+func ExampleReplaceAll() {
+	fmt.Printf("%s\n", bytcase.ReplaceAll([]byte("oink OINK oink"), []byte("OINK"), []byte("moo")))
+	// Output:
+	// moo moo moo
+}
+
+func ExampleReplacePreserveCase() {
+	s := []byte("color Color COLOR")
+	fmt.Printf("%s\n", bytcase.ReplacePreserveCase(s, []byte("color"), []byte("colour"), -1))
+	fmt.Printf("%s\n", bytcase.ReplacePreserveCase(s, []byte("color"), []byte("colour"), 1))
+	// Output:
+	// colour Colour COLOUR
+	// colour Color COLOR
+}
+
+func ExampleNewReplacer() {
+	r := bytcase.NewReplacer("<", "&lt;", ">", "&gt;", "GOPHER", "Gopher")
+	fmt.Printf("%s\n", r.Replace([]byte("This is <b>HTML</b> for gophers!")))
+	// Output:
+	// This is &lt;b&gt;HTML&lt;/b&gt; for Gophers!
+}
+
+func ExampleSplit() {
+	fmt.Printf("%q\n", bytcase.Split([]byte("a,b,c"), []byte(",")))
+	fmt.Printf("%q\n", bytcase.Split([]byte("a man a plan a canal panama"), []byte("A ")))
+	fmt.Printf("%q\n", bytcase.Split([]byte(" xyz "), []byte("")))
+	fmt.Printf("%q\n", bytcase.Split([]byte(""), []byte("Bernardo O'Higgins")))
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	fmt.Printf("%q\n", bytcase.Split([]byte("1k2K3"), []byte("K")))
+	// Output:
+	// ["a" "b" "c"]
+	// ["" "man " "plan " "canal panama"]
+	// [" " "x" "y" "z" " "]
+	// [""]
+	// ["1" "2" "3"]
+}
+
+func ExampleSplitN() {
+	fmt.Printf("%q\n", bytcase.SplitN([]byte("aXbXc"), []byte("x"), 2))
+	z := bytcase.SplitN([]byte("aXbXc"), []byte("x"), 0)
+	fmt.Printf("%q (nil = %v)\n", z, z == nil)
+	// Output:
+	// ["a" "bXc"]
+	// [] (nil = true)
+}
+
+func ExampleSplitAfter() {
+	fmt.Printf("%q\n", bytcase.SplitAfter([]byte("aXbxc"), []byte("x")))
+	// Output:
+	// ["aX" "bx" "c"]
+}
+
+func ExampleSplitAfterN() {
+	fmt.Printf("%q\n", bytcase.SplitAfterN([]byte("aXbxc"), []byte("x"), 2))
+	// Output:
+	// ["aX" "bxc"]
+}
+
+func ExampleIndexLen() {
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	s := []byte("Kelvin")
+	i, n := bytcase.IndexLen(s, []byte("KEL"))
+	fmt.Printf("%d %d %s\n", i, n, s[i+n:])
+	fmt.Println(bytcase.IndexLen(s, []byte("x")))
+	// Output:
+	// 0 5 vin
+	// -1 0
+}
+
+func ExampleHasPrefixLen() {
+	s := []byte("Kelvin")
+	ok, n := bytcase.HasPrefixLen(s, []byte("kel"))
+	fmt.Printf("%t %d %s\n", ok, n, s[n:])
+	// Output:
+	// true 5 vin
+}
+
+func ExampleTrim() {
+	fmt.Printf("%s", bytcase.Trim([]byte("xX¡¡¡Hello, Gophers!!!Xx"), []byte("!¡X")))
+	// Output: Hello, Gophers
+}
+
+func ExampleTrimLeft() {
+	// 'ſ' (U+017F) folds to 's'.
+	fmt.Printf("%s", bytcase.TrimLeft([]byte("sSſHello, Gophers"), []byte("s")))
+	// Output: Hello, Gophers
+}
+
+func ExampleTrimRight() {
+	fmt.Printf("%s", bytcase.TrimRight([]byte("Hello, Gophers!!!xX"), []byte("!x")))
+	// Output: Hello, Gophers
+}
+
+func ExampleCharSet() {
+	// The set contains 'k', 'K', Kelvin K (U+212A), 'x' and 'X'.
+	c := bytcase.NewCharSet([]byte("kx"))
+	fmt.Println(c.Contains('K'))
+	fmt.Println(c.IndexAny([]byte("chicken")))
+	fmt.Println(c.LastIndexAny([]byte("KEX LUTHOR")))
+	fmt.Println(c.Count([]byte("Kicks")))
+	fmt.Printf("%s\n", c.Trim([]byte("xXoxKoK")))
+	// Output:
+	// true
+	// 4
+	// 2
+	// 2
+	// oxKo
+}
+
+func ExampleMultiMatcher() {
+	m := bytcase.NewMultiMatcher("gopher", "rust", "ZIG")
+	s := []byte("Gophers, Rustaceans and Ziguanas")
+	for _, match := range m.FindAll(s, -1) {
+		fmt.Printf("%d %s\n", match.Pattern, s[match.Start:match.End])
+	}
+	// Output:
+	// 0 Gopher
+	// 1 Rust
+	// 2 Zig
+}
+
+func ExampleIndexAnyString() {
+	fmt.Println(bytcase.IndexAnyString([]byte("Gophers and Rustaceans"), []string{"rust", "GOPHER"}))
+	fmt.Println(bytcase.IndexAnyString([]byte("chicken"), []string{"dmr", "rsc"}))
+	// Output:
+	// 0 1
+	// -1 -1
+}
+
+func ExampleAppendSortKey() {
+	// Sort keys compare the same as Compare and can be used in systems
+	// that only compare raw bytes.
+	a := bytcase.AppendSortKey(nil, []byte("Straße"))
+	b := bytcase.AppendSortKey(nil, []byte("STRASSE"))
+	fmt.Printf("%q %q\n", a, b)
+	fmt.Println(bytes.Compare(a, b), bytcase.Compare([]byte("Straße"), []byte("STRASSE")))
+	// Output:
+	// "straße" "strasse"
+	// 1 1
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/bytealg"
+)
+
+// A Finder efficiently finds case-insensitive instances of a fixed needle
+// in byte slices. Matching is identical to that of [Index].
+//
+// The setup work that [Index] performs on every call (decoding the first
+// runes of the needle and looking up their case-folds) is performed once
+// by [NewFinder], which makes Finder faster when the same needle is
+// searched for repeatedly.
+//
+// A Finder is safe for concurrent use by multiple goroutines and its
+// methods never allocate memory.
+type Finder struct {
+	substr    []byte
+	r0        rune // first rune of substr
+	sz0       int  // size of r0
+	runeCount int  // number of runes in substr
+	hash      uint32
+	pow       uint32
+	revHash   uint32
+	revPow    uint32
+	kelvin    bool        // substr contains Kelvin K
+	nonLetter bool        // substr consists only of non-letter ASCII characters
+	ascii     bool        // substr consists only of ASCII characters
+	ks        bool        // substr contains [KkSs]
+	i1, i2    int         // offsets of the rarest bytes of an ASCII substr
+	folds     needleFolds // anchored on the first two runes of substr
+	rare      needleFolds // anchored on the rarest pair of runes in substr
+	tw        twoWay      // Two-Way searcher for long needles
+	revTw     twoWay      // Two-Way searcher for LastIndex of long needles
+}
+
+// NewFinder returns a new [Finder] that searches for substr. The Finder
+// stores a copy of substr so the caller is free to modify it afterwards.
+func NewFinder(substr []byte) *Finder {
+	f := new(Finder)
+	f.init(append([]byte(nil), substr...))
+	return f
+}
+
+// init initializes f to search for substr. Unlike NewFinder, substr is
+// not copied.
+func (f *Finder) init(substr []byte) {
+	*f = Finder{substr: substr}
+	if len(substr) == 0 {
+		return
+	}
+	if substr[0] < utf8.RuneSelf {
+		f.r0, f.sz0 = rune(substr[0]), 1
+	} else {
+		f.r0, f.sz0 = utf8.DecodeRune(substr)
+	}
+	f.hash, f.pow, f.runeCount = hashStrUnicode(substr)
+	f.revHash, f.revPow, _ = hashStrRevUnicode(substr)
+	f.kelvin = containsKelvin(substr)
+	f.nonLetter = len(substr) <= 32 && nonLetterASCII(substr)
+	if len(substr) <= maxLen {
+		f.ascii, f.ks = asciiNeedle(substr)
+		if f.ascii && len(substr) > 1 {
+			f.i1, f.i2 = rareBytes(substr)
+		}
+	}
+	if len(substr) > f.sz0 {
+		f.folds = makeNeedleFolds(substr, 0)
+		f.rare = makeNeedleFolds(substr, rareOffset(substr))
+	}
+	if len(substr) >= twoWayMinLen {
+		f.tw.init(substr, false, nil)
+		f.revTw.init(substr, true, nil)
+	}
+}
+
+// Index returns the index of the first instance of the needle in s, or -1
+// if the needle is not present in s.
+func (f *Finder) Index(s []byte) int {
+	i, _ := f.indexLen(s)
+	return i
+}
+
+// indexLen returns the index of the first instance of the needle in s and
+// the length in bytes of the match. If the needle is not present in s the
+// returned index is -1 and the length is undefined.
+func (f *Finder) indexLen(s []byte) (int, int) {
+	n := len(f.substr)
+	switch {
+	case n == 0:
+		return 0, 0
+	case n == 1 && f.r0 != utf8.RuneError:
+		return indexByte(s, byte(f.r0))
+	case n == f.sz0:
+		return indexRune(s, f.r0)
+	case n >= len(s):
+		if n > len(s)*3 {
+			return -1, 0
+		}
+		// Fast check to see if s contains the first character of substr.
+		i, _ := indexRune(s, f.r0)
+		if i < 0 {
+			return -1, 0
+		}
+		s = s[i:]
+		if n > len(s)*2 && !f.kelvin {
+			return -1, 0
+		}
+		var o, size int
+		if n >= twoWayMinLen {
+			o, size = f.tw.index(s)
+		} else {
+			o, size = f.folds.bruteForceIndex(s)
+		}
+		if o != -1 {
+			return o + i, size
+		}
+		return -1, 0
+	case n >= twoWayMinLen:
+		return f.tw.index(s)
+	case n <= maxLen:
+		if bytealg.NativeIndex && f.nonLetter {
+			return bytealg.Index(s, f.substr), n
+		}
+		if bytealg.NativeIndexCase && f.ascii {
+			if i, ok := indexASCII(s, f.substr, f.i1, f.i2, f.ks); ok {
+				return i, n
+			}
+		}
+		if len(s) <= maxBruteForce {
+			return f.folds.bruteForceIndex(s)
+		}
+	}
+
+	if f.rare.invalid() {
+		return indexRabinKarpUnicodeHash(s, f.substr, f.hash, f.pow, f.runeCount)
+	}
+	i, size, done := f.rare.index(s)
+	if done {
+		return i, size
+	}
+	j, size := indexTwoWay(s[i:], f.substr)
+	if j < 0 {
+		return -1, 0
+	}
+	return i + j, size
+}
+
+// LastIndex returns the index of the last instance of the needle in s, or
+// -1 if the needle is not present in s.
+func (f *Finder) LastIndex(s []byte) int {
+	n := len(f.substr)
+	switch {
+	case n == 0:
+		return len(s)
+	case n == 1:
+		return LastIndexByte(s, f.substr[0])
+	case n == f.sz0:
+		i, _ := lastIndexRune(s, f.r0)
+		return i
+	case n >= len(s):
+		if n > len(s)*3 {
+			return -1
+		}
+		if n > len(s)*2 && !f.kelvin {
+			return -1
+		}
+	}
+	var i int
+	if n >= twoWayMinLen {
+		i, _ = f.revTw.lastIndex(s)
+	} else {
+		i, _ = indexRabinKarpRevUnicodeHash(s, f.substr, f.revHash, f.revPow, f.runeCount)
+	}
+	return i
+}
+
+// Contains reports whether the needle is within s.
+func (f *Finder) Contains(s []byte) bool {
+	return f.Index(s) >= 0
+}
+
+// Count counts the number of non-overlapping instances of the needle in s.
+// If the needle is empty, Count returns 1 + the number of Unicode
+// code points in s.
+func (f *Finder) Count(s []byte) int {
+	if len(f.substr) <= 1 {
+		return Count(s, f.substr)
+	}
+	n := 0
+	for {
+		i, size := f.indexLen(s)
+		if i == -1 {
+			return n
+		}
+		n++
+		s = s[i+size:]
+	}
+}
+
+// Cut slices s around the first instance of the needle, returning the text
+// before and after the needle. The found result reports whether the needle
+// appears in s. If the needle does not appear in s, Cut returns s, nil, false.
+//
+// Cut returns slices of the original slice s, not copies.
+func (f *Finder) Cut(s []byte) (before, after []byte, found bool) {
+	if i, n := f.indexLen(s); i >= 0 {
+		return s[:i], s[i+n:], true
+	}
+	return s, nil, false
+}
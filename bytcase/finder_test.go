@@ -0,0 +1,115 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func finderIndex(s, substr []byte) int     { return NewFinder(substr).Index(s) }
+func finderLastIndex(s, substr []byte) int { return NewFinder(substr).LastIndex(s) }
+func finderCount(s, substr []byte) int     { return NewFinder(substr).Count(s) }
+
+func TestFinderIndex(t *testing.T) {
+	test.Index(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexUnicode(t *testing.T) {
+	test.IndexUnicode(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexInvalid(t *testing.T) {
+	test.IndexInvalid(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexNumeric(t *testing.T) {
+	test.IndexNumeric(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexKelvin(t *testing.T) {
+	test.IndexKelvin(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexPeriodic(t *testing.T) {
+	test.IndexPeriodic(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderIndexRare(t *testing.T) {
+	test.IndexRare(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderLastIndex(t *testing.T) {
+	test.LastIndex(t, test.ByteIndexFunc(finderLastIndex))
+}
+
+func TestFinderLastIndexInvalid(t *testing.T) {
+	test.LastIndexInvalid(t, test.ByteIndexFunc(finderLastIndex))
+}
+
+func TestFinderLastIndexPeriodic(t *testing.T) {
+	test.LastIndexPeriodic(t, test.ByteIndexFunc(finderLastIndex))
+}
+
+func TestFinderContains(t *testing.T) {
+	test.Contains(t, test.ByteContainsFunc(func(s, substr []byte) bool {
+		return NewFinder(substr).Contains(s)
+	}))
+}
+
+func TestFinderCount(t *testing.T) {
+	test.Count(t, test.ByteIndexFunc(finderCount))
+}
+
+func TestFinderCut(t *testing.T) {
+	test.Cut(t, func(s, sep string) (before, after string, found bool) {
+		b, a, ok := NewFinder([]byte(sep)).Cut([]byte(s))
+		return string(b), string(a), ok
+	})
+}
+
+func TestFinderIndexFuzz(t *testing.T) {
+	test.IndexFuzz(t, test.ByteIndexFunc(finderIndex))
+}
+
+func TestFinderLastIndexFuzz(t *testing.T) {
+	test.LastIndexFuzz(t, test.ByteIndexFunc(finderLastIndex))
+}
+
+func TestFinderCopiesNeedle(t *testing.T) {
+	needle := []byte("abc")
+	f := NewFinder(needle)
+	needle[0] = 'x'
+	if i := f.Index([]byte("xxABC")); i != 2 {
+		t.Errorf("Index = %d; want: %d", i, 2)
+	}
+}
+
+func TestFinderAllocs(t *testing.T) {
+	haystack := []byte("test世界İ")
+	f0 := NewFinder([]byte("世界İ"))
+	f1 := NewFinder([]byte("t世"))
+	f2 := NewFinder([]byte("test世界İ"))
+	allocs := testing.AllocsPerRun(1000, func() {
+		if i := f0.Index(haystack); i != 4 {
+			t.Fatalf("'s' at %d; want 4", i)
+		}
+		if i := f1.Index(haystack); i != 3 {
+			t.Fatalf("'世' at %d; want 3", i)
+		}
+		if i := f2.LastIndex(haystack); i != 0 {
+			t.Fatalf("'İ' at %d; want 0", i)
+		}
+		if n := f1.Count(haystack); n != 1 {
+			t.Fatalf("Count = %d; want 1", n)
+		}
+		if _, _, ok := f0.Cut(haystack); !ok {
+			t.Fatal("Cut: failed to find needle")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestCompareFull(t *testing.T) {
+	test.CompareFull(t, test.ByteIndexFunc(CompareFull))
+}
+
+func TestEqualFoldFull(t *testing.T) {
+	test.EqualFoldFull(t, test.ByteContainsFunc(EqualFoldFull))
+}
+
+func TestIndexFull(t *testing.T) {
+	test.IndexFull(t, test.ByteIndexLenFunc(IndexFull))
+}
+
+func TestHasPrefixFull(t *testing.T) {
+	test.HasPrefixFull(t, test.BytePrefixLenFunc(HasPrefixFull))
+}
+
+func TestHasSuffixFull(t *testing.T) {
+	test.HasSuffixFull(t, test.BytePrefixLenFunc(HasSuffixFull))
+}
+
+func TestCountFull(t *testing.T) {
+	test.CountFull(t, test.ByteIndexFunc(CountFull))
+}
+
+func TestFullFoldAllocs(t *testing.T) {
+	s0, s1 := []byte("straße"), []byte("STRASSE")
+	s2, s3 := []byte("the oﬃce"), []byte("OFFICE")
+	s4, s5 := []byte("ßß"), []byte("ss")
+	allocs := testing.AllocsPerRun(100, func() {
+		if !EqualFoldFull(s0, s1) {
+			t.Fatal("EqualFoldFull failed")
+		}
+		if i, n := IndexFull(s2, s3); i != 4 || n != len("oﬃce") {
+			t.Fatalf("IndexFull = %d, %d", i, n)
+		}
+		if n := CountFull(s4, s5); n != 2 {
+			t.Fatalf("CountFull = %d", n)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
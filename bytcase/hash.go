@@ -0,0 +1,84 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"hash/maphash"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// Hash returns the hash of b with the given seed ignoring case.
+// If EqualFold(b, t) is true then Hash(seed, b) == Hash(seed, t).
+//
+// Hash is equivalent to, but faster than:
+//
+//	var h maphash.Hash
+//	h.SetSeed(seed)
+//	WriteFold(&h, b)
+//	return h.Sum64()
+//
+// Hash does not allocate and is intended for use as the key of a map or to
+// shard case-insensitive data without having to first convert it with
+// [bytes.ToLower], which does not agree with simple case-folding for
+// runes such as Kelvin K (U+212A) or 'ſ' (U+017F).
+func Hash(seed maphash.Seed, b []byte) uint64 {
+	i := indexUnfolded(b)
+	if i == -1 {
+		return maphash.Bytes(seed, b)
+	}
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.Write(b[:i])
+	writeFold(&h, b[i:])
+	return h.Sum64()
+}
+
+// WriteFold adds the simple case-fold of b to h. Invalid UTF-8 sequences
+// are written as U+FFFD (just as EqualFold treats them as equal to U+FFFD)
+// so b should not be split inside of a UTF-8 sequence across calls to
+// WriteFold.
+func WriteFold(h *maphash.Hash, b []byte) {
+	i := indexUnfolded(b)
+	if i == -1 {
+		h.Write(b)
+		return
+	}
+	h.Write(b[:i])
+	writeFold(h, b[i:])
+}
+
+// indexUnfolded returns the index of the first byte in s that may change
+// when folded, which is either an upper case ASCII letter or the start of a
+// multi-byte rune, or -1 if s is already folded.
+func indexUnfolded(s []byte) int {
+	for i, c := range s {
+		if c >= utf8.RuneSelf || _lower[c] != c {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeFold(h *maphash.Hash, s []byte) {
+	var buf [128]byte
+	n := 0
+	for i := 0; i < len(s); {
+		if n > len(buf)-utf8.UTFMax {
+			h.Write(buf[:n])
+			n = 0
+		}
+		if c := s[i]; c < utf8.RuneSelf {
+			buf[n] = _lower[c]
+			n++
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		n += utf8.EncodeRune(buf[n:], tables.CaseFold(r))
+		i += size
+	}
+	h.Write(buf[:n])
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build go1.23
+// +build go1.23
+
+package bytcase
+
+import (
+	"iter"
+	"unicode/utf8"
+)
+
+// AllIndexes returns an iterator over the non-overlapping instances of
+// substr in s, ignoring case. The iterator yields the index of each instance
+// and the number of bytes of s that it spans, which may differ from
+// len(substr). For example, Kelvin K (U+212A) is encoded with three bytes
+// but matches the single byte 'k'.
+//
+// If substr is empty, it matches with a length of zero at the beginning of
+// s and after each UTF-8 sequence.
+func AllIndexes(s, substr []byte) iter.Seq2[int, int] {
+	if len(substr) == 0 {
+		return func(yield func(int, int) bool) {
+			for i := 0; i < len(s); {
+				if !yield(i, 0) {
+					return
+				}
+				_, size := utf8.DecodeRune(s[i:])
+				i += size
+			}
+			yield(len(s), 0)
+		}
+	}
+	var f Finder
+	f.init(substr)
+	return func(yield func(int, int) bool) {
+		for i := 0; ; {
+			o, n := f.indexLen(s[i:])
+			if o < 0 {
+				return
+			}
+			i += o
+			if !yield(i, n) {
+				return
+			}
+			i += n
+		}
+	}
+}
+
+// splitSeq implements SplitSeq and SplitAfterSeq. If sepSave is true the
+// matched separator, as it appears in s, is included in each subslice.
+func splitSeq(s, sep []byte, sepSave bool) iter.Seq[[]byte] {
+	if len(sep) == 0 {
+		// Case does not matter when exploding s into UTF-8 sequences.
+		return func(yield func([]byte) bool) {
+			for s := s; len(s) > 0; {
+				_, size := utf8.DecodeRune(s)
+				if !yield(s[:size:size]) {
+					return
+				}
+				s = s[size:]
+			}
+		}
+	}
+	var f Finder
+	f.init(sep)
+	return func(yield func([]byte) bool) {
+		s := s
+		for {
+			i, n := f.indexLen(s)
+			if i < 0 {
+				break
+			}
+			rest := s[i+n:]
+			frag := s[:i:i]
+			if sepSave {
+				frag = s[: i+n : i+n]
+			}
+			if !yield(frag) {
+				return
+			}
+			s = rest
+		}
+		yield(s)
+	}
+}
+
+// SplitSeq returns an iterator over all subslices of s separated by sep,
+// ignoring case. The iterator yields the same subslices that would be
+// returned by [Split](s, sep), but without constructing a new slice
+// containing the subslices.
+func SplitSeq(s, sep []byte) iter.Seq[[]byte] {
+	return splitSeq(s, sep, false)
+}
+
+// SplitAfterSeq returns an iterator over subslices of s split after each
+// instance of sep, ignoring case. The iterator yields the same subslices
+// that would be returned by [SplitAfter](s, sep), but without constructing
+// a new slice containing the subslices.
+func SplitAfterSeq(s, sep []byte) iter.Seq[[]byte] {
+	return splitSeq(s, sep, true)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build go1.23
+// +build go1.23
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestAllIndexes(t *testing.T) {
+	test.AllIndexes(t, test.ByteAllIndexesFunc(AllIndexes))
+}
+
+func TestSplitSeq(t *testing.T) {
+	test.Split(t, test.ByteSeqSplitFunc(SplitSeq, SplitN))
+}
+
+func TestSplitAfterSeq(t *testing.T) {
+	test.SplitAfter(t, test.ByteSeqSplitFunc(SplitAfterSeq, SplitAfterN))
+}
+
+// The subslices yielded by SplitSeq must have their capacity limited to
+// their length so that appending to one does not overwrite the next.
+func TestSplitSeqCapacity(t *testing.T) {
+	s := []byte("aXbxc")
+	for _, sep := range []string{"x", ""} {
+		for b := range SplitSeq(s, []byte(sep)) {
+			if len(b) > 0 && &b[len(b)-1] != &s[len(s)-1] && cap(b) != len(b) {
+				t.Errorf("SplitSeq(%q, %q): cap(%q) = %d; want: %d", s, sep, b, cap(b), len(b))
+			}
+		}
+		for b := range SplitAfterSeq(s, []byte(sep)) {
+			if len(b) > 0 && &b[len(b)-1] != &s[len(s)-1] && cap(b) != len(b) {
+				t.Errorf("SplitAfterSeq(%q, %q): cap(%q) = %d; want: %d", s, sep, b, cap(b), len(b))
+			}
+		}
+	}
+}
+
+func BenchmarkSplitSeq(b *testing.B) {
+	s := []byte("a,B,c,D,e,F,g,H,i,J,k,L,m,N,o,P,q,R,s,T,u,V,w,X,y,Z")
+	sep := []byte(",")
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for range SplitSeq(s, sep) {
+		}
+	}
+}
@@ -0,0 +1,461 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/bytealg"
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// A MultiMatch is a match of one of the needles of a [MultiMatcher].
+type MultiMatch struct {
+	Start   int // index of the first byte of the match in s
+	End     int // index after the last byte of the match, s[Start:End] is the matched text
+	Pattern int // index of the matched needle
+}
+
+// MultiMatcher searches for multiple needles at once ignoring case using
+// the Aho-Corasick algorithm. The cost of a search is proportional to the
+// length of the text being searched and not the number of needles.
+//
+// Needles are matched using simple Unicode case-folding, the same as
+// [EqualFold] and [Index]. When multiple needles match at the same position
+// the needle that appears first in the list of needles is preferred
+// (leftmost-first semantics), like [Replacer].
+//
+// A MultiMatcher is safe for concurrent use by multiple goroutines.
+type MultiMatcher struct {
+	nodes  []acNode
+	root   [utf8.RuneSelf]int32 // transitions from the root for ASCII
+	maxLen int                  // length of the longest needle in runes
+}
+
+type acNode struct {
+	edges []acEdge // sorted by rune
+	fail  int32    // longest proper suffix that is also in the trie
+	dict  int32    // nearest node on the fail chain with a match, or -1
+	out   int32    // index of the first needle that ends at this node, or -1
+	depth int32    // length of the node's prefix in runes
+}
+
+type acEdge struct {
+	r    rune
+	next int32
+}
+
+// NewMultiMatcher returns a [MultiMatcher] that matches any of needles.
+// An empty needle matches at the beginning of the text and after each UTF-8
+// sequence.
+func NewMultiMatcher(needles ...string) *MultiMatcher {
+	m := &MultiMatcher{
+		nodes:  []acNode{{dict: -1, out: -1}},
+		maxLen: 1,
+	}
+	for id, needle := range needles {
+		n := int32(0)
+		depth := 0
+		for _, r := range needle {
+			depth++
+			r = foldRune(r)
+			next := m.edge(n, r)
+			if next < 0 {
+				next = int32(len(m.nodes))
+				m.nodes = append(m.nodes, acNode{
+					dict:  -1,
+					out:   -1,
+					depth: int32(depth),
+				})
+				m.insertEdge(n, r, next)
+			}
+			n = next
+		}
+		if m.nodes[n].out < 0 {
+			m.nodes[n].out = int32(id)
+		}
+		if depth > m.maxLen {
+			m.maxLen = depth
+		}
+	}
+
+	// Compute the fail and dict links in breadth-first order.
+	queue := make([]int32, 0, len(m.nodes))
+	for _, e := range m.nodes[0].edges {
+		if m.nodes[0].out >= 0 {
+			m.nodes[e.next].dict = 0 // empty needle
+		}
+		queue = append(queue, e.next)
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range m.nodes[n].edges {
+			f := m.nodes[n].fail
+			for {
+				if next := m.edge(f, e.r); next >= 0 {
+					f = next
+					break
+				}
+				if f == 0 {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			c := &m.nodes[e.next]
+			c.fail = f
+			if m.nodes[f].out >= 0 {
+				c.dict = f
+			} else {
+				c.dict = m.nodes[f].dict
+			}
+			queue = append(queue, e.next)
+		}
+	}
+	for c := range m.root {
+		m.root[c] = m.edge(0, foldRune(rune(c)))
+		if m.root[c] < 0 {
+			m.root[c] = 0
+		}
+	}
+	return m
+}
+
+// foldRune returns the canonical simple case-fold of r.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		return rune(_lower[byte(r)])
+	}
+	return tables.CaseFold(r)
+}
+
+// edge returns the node that n transitions to on rune r or -1.
+func (m *MultiMatcher) edge(n int32, r rune) int32 {
+	edges := m.nodes[n].edges
+	i, j := 0, len(edges)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		if edges[h].r < r {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	if i < len(edges) && edges[i].r == r {
+		return edges[i].next
+	}
+	return -1
+}
+
+func (m *MultiMatcher) insertEdge(n int32, r rune, next int32) {
+	edges := m.nodes[n].edges
+	i := len(edges)
+	for i > 0 && edges[i-1].r > r {
+		i--
+	}
+	edges = append(edges, acEdge{})
+	copy(edges[i+1:], edges[i:])
+	edges[i] = acEdge{r: r, next: next}
+	m.nodes[n].edges = edges
+}
+
+// step returns the node reached from node n after reading rune r,
+// which must be folded.
+func (m *MultiMatcher) step(n int32, r rune) int32 {
+	for {
+		if n == 0 {
+			if r < utf8.RuneSelf {
+				return m.root[r]
+			}
+			if next := m.edge(0, r); next >= 0 {
+				return next
+			}
+			return 0
+		}
+		if next := m.edge(n, r); next >= 0 {
+			return next
+		}
+		n = m.nodes[n].fail
+	}
+}
+
+// find returns the leftmost-first match in s. If skipEmpty is true an
+// empty match at the start of s is ignored.
+func (m *MultiMatcher) find(s []byte, skipEmpty bool) (MultiMatch, bool) {
+	match := MultiMatch{Start: -1, End: -1, Pattern: -1}
+	if !skipEmpty && m.nodes[0].out >= 0 {
+		match = MultiMatch{Start: 0, End: 0, Pattern: int(m.nodes[0].out)}
+	}
+
+	// offs is a circular buffer of the start index of the last maxLen runes.
+	var buf [32]int
+	var offs []int
+	if m.maxLen <= len(buf) {
+		offs = buf[:m.maxLen]
+	} else {
+		offs = make([]int, m.maxLen)
+	}
+
+	n := int32(0)
+	k := 0 // rune count
+	for i := 0; i < len(s); k++ {
+		offs[k%len(offs)] = i
+		var r rune
+		if c := s[i]; c < utf8.RuneSelf {
+			r = rune(_lower[c])
+			i++
+		} else {
+			var size int
+			r, size = utf8.DecodeRune(s[i:])
+			r = tables.CaseFold(r)
+			i += size
+		}
+		n = m.step(n, r)
+
+		// Consider all of the needles that end at i.
+		for o := n; o >= 0; o = m.nodes[o].dict {
+			nd := &m.nodes[o]
+			if nd.out < 0 {
+				continue
+			}
+			start := i
+			if nd.depth > 0 {
+				start = offs[(k-int(nd.depth)+1)%len(offs)]
+			}
+			if match.Start < 0 || start < match.Start ||
+				(start == match.Start && int(nd.out) < match.Pattern) {
+				match = MultiMatch{Start: start, End: i, Pattern: int(nd.out)}
+			}
+		}
+
+		// Stop once no match in progress can start at or before the
+		// current match.
+		if match.Start >= 0 {
+			pending := i
+			if d := int(m.nodes[n].depth); d > 0 {
+				pending = offs[(k-d+1)%len(offs)]
+			}
+			if pending > match.Start {
+				break
+			}
+		}
+	}
+	return match, match.Start >= 0
+}
+
+// Find returns the leftmost-first match of any of the needles in s and
+// reports if a match was found.
+func (m *MultiMatcher) Find(s []byte) (MultiMatch, bool) {
+	return m.find(s, false)
+}
+
+// FindAll returns successive non-overlapping matches of the needles in s.
+// Matches are found in the same order, and using the same rules, as
+// [Replacer] uses for replacements. If n >= 0, FindAll returns at most n
+// matches, otherwise it returns all of them.
+func (m *MultiMatcher) FindAll(s []byte, n int) []MultiMatch {
+	var matches []MultiMatch
+	prevEmpty := false
+	for i := 0; i <= len(s) && (n < 0 || len(matches) < n); {
+		match, ok := m.find(s[i:], prevEmpty)
+		if !ok {
+			break
+		}
+		match.Start += i
+		match.End += i
+		matches = append(matches, match)
+		i = match.End
+		// Two empty matches at the same position are not allowed, but
+		// a non-empty match may follow an empty match.
+		prevEmpty = match.Start == match.End
+	}
+	return matches
+}
+
+// Contains reports whether any of the needles are within s.
+func (m *MultiMatcher) Contains(s []byte) bool {
+	if m.nodes[0].out >= 0 {
+		return true
+	}
+	n := int32(0)
+	for i := 0; i < len(s); {
+		var r rune
+		if c := s[i]; c < utf8.RuneSelf {
+			r = rune(_lower[c])
+			i++
+		} else {
+			var size int
+			r, size = utf8.DecodeRune(s[i:])
+			r = tables.CaseFold(r)
+			i += size
+		}
+		n = m.step(n, r)
+		if m.nodes[n].out >= 0 || m.nodes[n].dict >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// maxTeddyNeedles is the maximum number of needles that IndexAnyString
+// searches for with a single [bytealg.Teddy] filter. Larger sets of needles
+// are searched for in chunks of maxTeddyNeedles.
+const maxTeddyNeedles = 64
+
+// IndexAnyString returns the index of the first instance of any of needles
+// in s, ignoring case, and the index of the needle that matched. If more
+// than one needle matches at the same index the needle that appears first
+// in needles is reported. It returns -1, -1 if none of the needles are
+// present in s.
+//
+// Needles are searched for with a SIMD filter that is much faster than
+// calling [Index] for each needle. The filter is built on every call and
+// holds at most 64 needles, so larger sets of needles are searched for in
+// chunks of 64 and the cost of a search grows with the number of needles.
+// If the same set of needles is searched for repeatedly, or there are many
+// needles, a [MultiMatcher] created with [NewMultiMatcher] should be used
+// instead.
+func IndexAnyString(s []byte, needles []string) (int, int) {
+	switch {
+	case len(needles) == 0:
+		return -1, -1
+	case len(needles) == 1:
+		if i := Index(s, []byte(needles[0])); i >= 0 {
+			return i, 0
+		}
+		return -1, -1
+	}
+	if len(s) == 0 {
+		for j, needle := range needles {
+			if len(needle) == 0 {
+				return 0, j
+			}
+		}
+		return -1, -1
+	}
+
+	// Ties are won by the needle that appears first, so the chunks after
+	// the first match only need to be searched for matches before it.
+	index, needle := -1, -1
+	end := len(s)
+	for k := 0; k < len(needles) && end > 0; k += maxTeddyNeedles {
+		chunk := needles[k:]
+		if len(chunk) > maxTeddyNeedles {
+			chunk = chunk[:maxTeddyNeedles]
+		}
+		if i, j := indexAnyTeddy(s, chunk, end); i != -1 {
+			index, needle = i, k+j
+			end = i
+		}
+	}
+	return index, needle
+}
+
+// indexAnyTeddy returns the index of the first instance of any of needles
+// in s that starts before end and the index of the needle that matched,
+// or -1, -1. There must be at most maxTeddyNeedles needles.
+func indexAnyTeddy(s []byte, needles []string, end int) (int, int) {
+	var t bytealg.Teddy
+	for j, needle := range needles {
+		addTeddy(&t, j%bytealg.TeddyBuckets, needle)
+	}
+	for i := 0; i < end; i++ {
+		o := t.Index(s[i:])
+		if o == -1 {
+			break
+		}
+		i += o
+		if i >= end {
+			break
+		}
+		if inRune(s, i) {
+			continue // matches only start at character boundaries
+		}
+		buckets := t.Buckets(s, i)
+		for j, needle := range needles {
+			if buckets&(1<<(j%bytealg.TeddyBuckets)) != 0 && hasPrefixString(s[i:], needle) {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+// hasPrefixString is like HasPrefix but prefix is a string. It does not
+// allocate if prefix is ASCII.
+func hasPrefixString(s []byte, prefix string) bool {
+	for i := 0; i < len(prefix); i++ {
+		if i == len(s) {
+			return false
+		}
+		c, p := s[i], prefix[i]
+		if (c|p)&utf8.RuneSelf != 0 {
+			return HasPrefix(s, []byte(prefix))
+		}
+		if _lower[c] != _lower[p] {
+			return false
+		}
+	}
+	return true
+}
+
+// inRune reports whether s[i] is a continuation byte of a valid UTF-8
+// sequence that starts before i.
+func inRune(s []byte, i int) bool {
+	if utf8.RuneStart(s[i]) {
+		return false
+	}
+	for j := i - 1; j >= 0 && j >= i-(utf8.UTFMax-1); j-- {
+		if utf8.RuneStart(s[j]) {
+			_, size := utf8.DecodeRune(s[j:])
+			return j+size > i
+		}
+	}
+	return false
+}
+
+// addTeddy adds the leading bytes of needle, and of all of its case-folds,
+// to bucket b of t. The filter stops at the first non-ASCII character of
+// needle since the encoded length of its case-folds may differ (e.g. 'k'
+// and Kelvin K (U+212A)).
+func addTeddy(t *bytealg.Teddy, b int, needle string) {
+	pos := 0
+	for _, r := range needle {
+		if pos == bytealg.TeddyLen || r == utf8.RuneError {
+			break // invalid UTF-8 may match U+FFFD
+		}
+		ascii := addTeddyRune(t, b, pos, r)
+		// Like indexRune, FoldMap takes precedence over ToUpperLower.
+		if folds := tables.FoldMap(r); folds != nil {
+			for _, f := range folds {
+				if f != 0 && !addTeddyRune(t, b, pos, rune(f)) {
+					ascii = false
+				}
+			}
+		} else if u, l, ok := tables.ToUpperLower(r); ok {
+			ascii = addTeddyRune(t, b, pos, u) && ascii
+			ascii = addTeddyRune(t, b, pos, l) && ascii
+		}
+		pos++
+		if !ascii {
+			break
+		}
+	}
+	for ; pos < bytealg.TeddyLen; pos++ {
+		t.AddAny(b, pos)
+	}
+}
+
+// addTeddyRune adds the first byte of the UTF-8 encoding of r at position
+// pos of bucket b of t and reports if r is ASCII.
+func addTeddyRune(t *bytealg.Teddy, b, pos int, r rune) bool {
+	if r < utf8.RuneSelf {
+		t.Add(b, pos, byte(r))
+		return true
+	}
+	var buf [utf8.UTFMax]byte
+	utf8.EncodeRune(buf[:], r)
+	t.Add(b, pos, buf[0])
+	return false
+}
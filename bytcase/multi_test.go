@@ -0,0 +1,166 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func multiMatcherFuncs(needles ...string) test.MultiMatcherFuncs {
+	m := NewMultiMatcher(needles...)
+	convert := func(match MultiMatch) [3]int {
+		return [3]int{match.Start, match.End, match.Pattern}
+	}
+	return test.MultiMatcherFuncs{
+		Contains: func(s string) bool {
+			return m.Contains([]byte(s))
+		},
+		Find: func(s string) ([3]int, bool) {
+			match, ok := m.Find([]byte(s))
+			return convert(match), ok
+		},
+		FindAll: func(s string, n int) [][3]int {
+			var a [][3]int
+			for _, match := range m.FindAll([]byte(s), n) {
+				a = append(a, convert(match))
+			}
+			return a
+		},
+	}
+}
+
+func TestMultiMatcher(t *testing.T) {
+	test.MultiMatcher(t, multiMatcherFuncs)
+}
+
+// FindAll must find the same matches that Replacer replaces.
+func TestMultiMatcherReplacer(t *testing.T) {
+	test.Replacer(t, func(oldnew ...string) func(string) string {
+		needles := make([]string, 0, len(oldnew)/2)
+		for i := 0; i < len(oldnew); i += 2 {
+			needles = append(needles, oldnew[i])
+		}
+		m := NewMultiMatcher(needles...)
+		return func(s string) string {
+			var b strings.Builder
+			last := 0
+			for _, match := range m.FindAll([]byte(s), -1) {
+				b.WriteString(s[last:match.Start])
+				b.WriteString(oldnew[2*match.Pattern+1])
+				last = match.End
+			}
+			b.WriteString(s[last:])
+			return b.String()
+		}
+	})
+}
+
+func TestMultiMatcherAllocs(t *testing.T) {
+	m := NewMultiMatcher("foo", "bar", "baz", "Kelvin")
+	s := []byte(strings.Repeat("abc xyz ☺ ", 8) + "KELVIN")
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := m.Find(s); !ok {
+			t.Fatal("failed to find match")
+		}
+		if !m.Contains(s) {
+			t.Fatal("failed to find match")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations got: %.2f", allocs)
+	}
+}
+
+func BenchmarkMultiMatcher(b *testing.B) {
+	needles := make([]string, 2000)
+	for i := range needles {
+		needles[i] = fmt.Sprintf("banned-term-%04d", i)
+	}
+	s := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100))
+	s = append(s, "BANNED-TERM-1999"...)
+	b.Run("MultiMatcher", func(b *testing.B) {
+		m := NewMultiMatcher(needles...)
+		b.SetBytes(int64(len(s)))
+		for i := 0; i < b.N; i++ {
+			if !m.Contains(s) {
+				b.Fatal("no match")
+			}
+		}
+	})
+	b.Run("Contains", func(b *testing.B) {
+		b.SetBytes(int64(len(s)))
+		for i := 0; i < b.N; i++ {
+			found := false
+			for _, needle := range needles {
+				if Contains(s, []byte(needle)) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				b.Fatal("no match")
+			}
+		}
+	})
+}
+
+func TestIndexAnyString(t *testing.T) {
+	test.IndexAnyString(t, test.ByteIndexAnyStringFunc(IndexAnyString))
+}
+
+func TestIndexAnyStringAllocs(t *testing.T) {
+	needles := []string{"foo", "bar", "baz"}
+	for i := 0; i < 100; i++ {
+		needles = append(needles, fmt.Sprintf("term-%d", i)) // Multiple chunks
+	}
+	needles = append(needles, "Kelvin")
+	s := []byte(strings.Repeat("abc xyz ☺ ", 8) + "KELVIN")
+	allocs := testing.AllocsPerRun(100, func() {
+		if i, _ := IndexAnyString(s, needles); i == -1 {
+			t.Fatal("failed to find match")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations got: %.2f", allocs)
+	}
+}
+
+func BenchmarkIndexAnyString(b *testing.B) {
+	s := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100))
+	s = append(s, "BANNED-TERM-15"...)
+	for _, n := range []int{2, 8, 16, 64} {
+		needles := make([]string, n)
+		for i := range needles {
+			needles[i] = fmt.Sprintf("banned-term-%d", i+100)
+		}
+		needles[n-1] = "banned-term-15"
+		b.Run(fmt.Sprintf("%d/IndexAnyString", n), func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				if _, j := IndexAnyString(s, needles); j != n-1 {
+					b.Fatal("no match")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%d/Index", n), func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				found := false
+				for _, needle := range needles {
+					if Index(s, []byte(needle)) != -1 {
+						found = true
+						break
+					}
+				}
+				if !found {
+					b.Fatal("no match")
+				}
+			}
+		})
+	}
+}
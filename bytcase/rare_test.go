@@ -0,0 +1,57 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestRareOffset(t *testing.T) {
+	tests := []struct {
+		substr string
+		want   int
+	}{
+		{"ab", 0},
+		{"the quick", 4},  // "qu"
+		{"THE QUICK", 4},  // case is ignored
+		{"thé quick", 1},  // "é" is rare
+		{"the zebra", 4},  // "ze" is rarer than " z"
+		{"\xff\xffab", 0}, // pairs after invalid runes are skipped
+		{"\xffa\xff", 0},  // no valid pairs
+		{"ab\uFFFDzq", 0}, // pairs that follow U+FFFD are skipped
+		{"αβγδ", 0},       // ties favor the earliest pair
+		{"eeeee#", 4},     // "e#"
+	}
+	for _, x := range tests {
+		if got := rareOffset([]byte(x.substr)); got != x.want {
+			t.Errorf("rareOffset(%q) = %d; want: %d", x.substr, got, x.want)
+		}
+	}
+}
+
+func TestRareBytes(t *testing.T) {
+	tests := []struct {
+		substr string
+		i1, i2 int
+	}{
+		{"ab", 0, 1},
+		{"the quick", 4, 8},   // "q" and "k"
+		{"THE QUICK", 4, 8},   // case is ignored
+		{"xyz#", 2, 3},        // offsets are sorted
+		{"eeeee#e", 0, 5},     // ties favor the earliest byte
+		{"a\x80bc\x80", 1, 4}, // non-ASCII bytes are rare
+	}
+	for _, x := range tests {
+		i1, i2 := rareBytes([]byte(x.substr))
+		if i1 != x.i1 || i2 != x.i2 {
+			t.Errorf("rareBytes(%q) = %d, %d; want: %d, %d", x.substr, i1, i2, x.i1, x.i2)
+		}
+	}
+}
+
+func TestIndexRare(t *testing.T) {
+	test.IndexRare(t, test.ByteIndexFunc(Index))
+}
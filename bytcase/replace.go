@@ -0,0 +1,369 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// Replace returns a copy of the slice s with the first n non-overlapping
+// instances of old replaced by new, ignoring case.
+// If old is empty, it matches at the beginning of the slice
+// and after each UTF-8 sequence, yielding up to k+1 replacements
+// for a k-rune slice.
+// If n < 0, there is no limit on the number of replacements.
+//
+// The length of a match in s may differ from len(old), for example
+// Kelvin K (U+212A) is encoded with three bytes but matches the single
+// byte 'k'.
+func Replace(s, old, new []byte, n int) []byte {
+	if n == 0 || len(old) == 0 {
+		// The empty slice matches everywhere regardless of case.
+		return bytes.Replace(s, old, new, n)
+	}
+	var f Finder
+	f.init(old)
+	i, size := f.indexLen(s)
+	if i == -1 {
+		return append([]byte(nil), s...)
+	}
+	var t []byte
+	if len(new) > len(old) {
+		t = make([]byte, 0, len(s)+len(new)-len(old))
+	} else {
+		t = make([]byte, 0, len(s))
+	}
+	for {
+		t = append(t, s[:i]...)
+		t = append(t, new...)
+		s = s[i+size:]
+		if n--; n == 0 {
+			break
+		}
+		if i, size = f.indexLen(s); i == -1 {
+			break
+		}
+	}
+	return append(t, s...)
+}
+
+// ReplaceAll returns a copy of the slice s with all non-overlapping
+// instances of old replaced by new, ignoring case.
+// If old is empty, it matches at the beginning of the slice
+// and after each UTF-8 sequence, yielding up to k+1 replacements
+// for a k-rune slice.
+func ReplaceAll(s, old, new []byte) []byte {
+	return Replace(s, old, new, -1)
+}
+
+// ReplacePreserveCase is like [Replace] but the case of each replaced
+// instance of old is applied to new. If the instance is all lower case, all
+// upper case, or title case, new is converted to the same case. Otherwise,
+// the case of each character of the instance is applied to the
+// corresponding character of new and any remaining characters of new are
+// left unchanged. For example:
+//
+//	ReplacePreserveCase([]byte("color Color COLOR"), []byte("color"), []byte("colour"), -1)
+//	// returns []byte("colour Colour COLOUR")
+//
+// If old is empty, new is inserted unchanged.
+func ReplacePreserveCase(s, old, new []byte, n int) []byte {
+	if n == 0 || len(old) == 0 {
+		return bytes.Replace(s, old, new, n)
+	}
+	var f Finder
+	f.init(old)
+	i, size := f.indexLen(s)
+	if i == -1 {
+		return append([]byte(nil), s...)
+	}
+	var t []byte
+	if len(new) > len(old) {
+		t = make([]byte, 0, len(s)+len(new)-len(old))
+	} else {
+		t = make([]byte, 0, len(s))
+	}
+	for {
+		t = append(t, s[:i]...)
+		t = appendCase(t, s[i:i+size], new)
+		s = s[i+size:]
+		if n--; n == 0 {
+			break
+		}
+		if i, size = f.indexLen(s); i == -1 {
+			break
+		}
+	}
+	return append(t, s...)
+}
+
+// ReplaceAllPreserveCase is like [ReplaceAll] but the case of each replaced
+// instance of old is applied to new (see [ReplacePreserveCase]).
+func ReplaceAllPreserveCase(s, old, new []byte) []byte {
+	return ReplacePreserveCase(s, old, new, -1)
+}
+
+// A casePattern describes the case of the characters in a string.
+type casePattern uint8
+
+const (
+	caseNone  casePattern = iota // no characters with case
+	caseLower                    // all lower case
+	caseUpper                    // all upper case
+	caseTitle                    // first character upper case, the rest lower case
+	caseMixed                    // any other combination
+)
+
+// runeCase returns 1 if r is upper case, -1 if r is lower case, and 0 if r
+// does not have case.
+func runeCase(r rune) int {
+	u, l, ok := tables.ToUpperLower(r)
+	switch {
+	case !ok || u == l:
+		return 0
+	case r == u:
+		return 1
+	case r == l:
+		return -1
+	}
+	return 0 // title case (e.g. 'ǅ')
+}
+
+func matchCase(s []byte) casePattern {
+	var upper, lower int
+	firstUpper := false
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		s = s[size:]
+		switch runeCase(r) {
+		case 1:
+			if upper == 0 && lower == 0 {
+				firstUpper = true
+			}
+			upper++
+		case -1:
+			lower++
+		}
+	}
+	switch {
+	case upper == 0 && lower == 0:
+		return caseNone
+	case upper == 0:
+		return caseLower
+	case firstUpper && upper == 1:
+		return caseTitle // includes a single upper case character
+	case lower == 0:
+		return caseUpper
+	}
+	return caseMixed
+}
+
+// toCase returns the upper case of r if c is greater than zero, the lower
+// case of r if c is less than zero, and r otherwise.
+func toCase(r rune, c int) rune {
+	if c == 0 {
+		return r
+	}
+	u, l, ok := tables.ToUpperLower(r)
+	if !ok {
+		return r
+	}
+	if c > 0 {
+		return u
+	}
+	return l
+}
+
+// appendCase appends new to dst with the case pattern of match applied to
+// it and returns the extended slice.
+func appendCase(dst, match, new []byte) []byte {
+	p := matchCase(match)
+	if p == caseNone {
+		return append(dst, new...)
+	}
+	first := true // next character with case is the first
+	for len(new) > 0 {
+		r, size := utf8.DecodeRune(new)
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, new[0]) // preserve invalid UTF-8
+			new = new[1:]
+			continue
+		}
+		new = new[size:]
+		var c int
+		switch p {
+		case caseLower:
+			c = -1
+		case caseUpper:
+			c = 1
+		case caseTitle:
+			c = -1
+			if first && runeCase(r) != 0 {
+				c = 1
+				first = false
+			}
+		case caseMixed:
+			if len(match) > 0 {
+				mr, size := utf8.DecodeRune(match)
+				match = match[size:]
+				c = runeCase(mr)
+			}
+		}
+		dst = utf8.AppendRune(dst, toCase(r, c))
+	}
+	return dst
+}
+
+// Replacer replaces a list of strings with replacements ignoring case.
+// It is safe for concurrent use by multiple goroutines.
+type Replacer struct {
+	oldnew  [][]byte
+	finders []Finder // finders[i] searches for oldnew[2*i]
+	empty   bool     // one of the old strings is empty
+}
+
+// NewReplacer returns a new [Replacer] from a list of old, new string
+// pairs. Replacements are performed in the order they appear in the
+// target slice, without overlapping matches. The old string
+// comparisons are done in argument order and ignore case.
+//
+// Unlike [strings.Replacer], an empty old string only matches at the
+// beginning of the slice and after each UTF-8 sequence.
+//
+// NewReplacer panics if given an odd number of arguments.
+func NewReplacer(oldnew ...string) *Replacer {
+	if len(oldnew)%2 == 1 {
+		panic("bytcase.NewReplacer: odd argument count")
+	}
+	r := &Replacer{
+		oldnew:  make([][]byte, len(oldnew)),
+		finders: make([]Finder, len(oldnew)/2),
+	}
+	for i, s := range oldnew {
+		r.oldnew[i] = []byte(s)
+	}
+	for i := range r.finders {
+		old := r.oldnew[2*i]
+		r.finders[i].init(old)
+		if len(old) == 0 {
+			r.empty = true
+		}
+	}
+	return r
+}
+
+// Replace returns a copy of s with all replacements performed.
+func (r *Replacer) Replace(s []byte) []byte {
+	w := appendWriter(make([]byte, 0, len(s)))
+	r.replace(&w, s)
+	return w
+}
+
+type appendWriter []byte
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	*w = append(*w, p...)
+	return len(p), nil
+}
+
+// Write writes s to w with all replacements performed.
+func (r *Replacer) Write(w io.Writer, s []byte) (n int, err error) {
+	return r.replace(w, s)
+}
+
+func (r *Replacer) replace(w io.Writer, s []byte) (n int, err error) {
+	// next[j] is the index of the next match of finders[j] in s or
+	// len(s)+1 if there are no more matches. It is only valid if it
+	// is greater than or equal to the current position. end[j] is
+	// the index of the end of that match.
+	var buf, endBuf [16]int
+	var next, end []int
+	if len(r.finders) <= len(buf) {
+		next, end = buf[:len(r.finders)], endBuf[:len(r.finders)]
+	} else {
+		next, end = make([]int, len(r.finders)), make([]int, len(r.finders))
+	}
+	for j := range next {
+		next[j] = -1
+	}
+
+	var last, wn int
+	var prevMatchEmpty bool
+	for i := 0; i <= len(s); {
+		// Find the first old string, in argument order, that matches at i
+		// and the index of the next match if none do.
+		match := -1
+		min := len(s) + 1
+		for j := range r.finders {
+			f := &r.finders[j]
+			if len(f.substr) == 0 {
+				// The empty string matches everywhere, but two empty matches
+				// at the same position are ignored.
+				if !prevMatchEmpty {
+					match = j
+					break
+				}
+				continue
+			}
+			if next[j] < i {
+				if o, size := f.indexLen(s[i:]); o >= 0 {
+					next[j] = i + o
+					end[j] = i + o + size
+				} else {
+					next[j] = len(s) + 1
+				}
+			}
+			if next[j] == i {
+				match = j
+				break
+			}
+			if next[j] < min {
+				min = next[j]
+			}
+		}
+		if match >= 0 {
+			f := &r.finders[match]
+			wn, err = w.Write(s[last:i])
+			n += wn
+			if err != nil {
+				return
+			}
+			wn, err = w.Write(r.oldnew[2*match+1])
+			n += wn
+			if err != nil {
+				return
+			}
+			prevMatchEmpty = len(f.substr) == 0
+			if !prevMatchEmpty {
+				i = end[match]
+			}
+			last = i
+			continue
+		}
+		prevMatchEmpty = false
+		if !r.empty {
+			i = min // skip to the next match
+			continue
+		}
+		// The empty string matched at i so advance by one character.
+		if i == len(s) {
+			break
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+		} else {
+			_, size := utf8.DecodeRune(s[i:])
+			i += size
+		}
+	}
+	if last != len(s) {
+		wn, err = w.Write(s[last:])
+		n += wn
+	}
+	return
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestReplace(t *testing.T) {
+	test.Replace(t, test.ByteReplaceFunc(Replace))
+}
+
+func TestReplaceAll(t *testing.T) {
+	test.Replace(t, test.ByteReplaceFunc(func(s, old, new []byte, n int) []byte {
+		if n >= 0 {
+			return Replace(s, old, new, n)
+		}
+		return ReplaceAll(s, old, new)
+	}))
+}
+
+// Replace must always return a copy of s.
+func TestReplaceCopy(t *testing.T) {
+	s := []byte("abc")
+	for _, old := range []string{"x", "", "B"} {
+		for _, n := range []int{0, -1} {
+			out := Replace(s, []byte(old), []byte("y"), n)
+			if len(out) > 0 && &out[0] == &s[0] {
+				t.Errorf("Replace(%q, %q, %q, %d) returned s instead of a copy", s, old, "y", n)
+			}
+		}
+	}
+}
+
+func TestReplacePreserveCase(t *testing.T) {
+	test.ReplacePreserveCase(t, test.ByteReplaceFunc(ReplacePreserveCase))
+	test.ReplacePreserveCase(t, test.ByteReplaceFunc(func(s, old, new []byte, n int) []byte {
+		if n >= 0 {
+			return ReplacePreserveCase(s, old, new, n)
+		}
+		return ReplaceAllPreserveCase(s, old, new)
+	}))
+}
+
+func TestReplacer(t *testing.T) {
+	test.Replacer(t, func(oldnew ...string) func(string) string {
+		r := NewReplacer(oldnew...)
+		return func(s string) string {
+			return string(r.Replace([]byte(s)))
+		}
+	})
+}
+
+func TestReplacerWrite(t *testing.T) {
+	test.Replacer(t, func(oldnew ...string) func(string) string {
+		r := NewReplacer(oldnew...)
+		return func(s string) string {
+			var b bytes.Buffer
+			n, err := r.Write(&b, []byte(s))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != b.Len() {
+				t.Errorf("Write(%q) = %d; want: %d", s, n, b.Len())
+			}
+			return b.String()
+		}
+	})
+}
+
+func TestReplacerOddArgs(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Error("NewReplacer with an odd number of arguments should panic")
+		}
+	}()
+	NewReplacer("a")
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestAppendSortKey(t *testing.T) {
+	test.AppendSortKey(t, test.ByteAppendSortKeyFunc(AppendSortKey), compareString)
+}
+
+func TestAppendSortKeyFuzz(t *testing.T) {
+	test.AppendSortKeyFuzz(t, test.ByteAppendSortKeyFunc(AppendSortKey), compareString)
+}
+
+func compareString(s0, s1 string) int {
+	return Compare([]byte(s0), []byte(s1))
+}
+
+func TestAppendSortKeyAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	s := []byte("Hello, Kelvin!")
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendSortKey(buf[:0], s)
+		if string(buf) != "hello, kelvin!" {
+			t.Fatalf("AppendSortKey = %q", buf)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
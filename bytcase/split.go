@@ -0,0 +1,86 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import "bytes"
+
+// genSplit splits s around each instance of sep, ignoring case, including
+// the matched separator in the subslices if sepSave is true.
+// The length of a matched separator may differ from len(sep).
+func genSplit(s, sep []byte, sepSave bool, n int) [][]byte {
+	if n == 0 {
+		return nil
+	}
+	if len(sep) == 0 {
+		// Case does not matter when exploding s into UTF-8 sequences.
+		return bytes.SplitN(s, sep, n)
+	}
+	var f Finder
+	f.init(sep)
+	if n < 0 {
+		n = f.Count(s) + 1
+	}
+	if n > len(s)+1 {
+		n = len(s) + 1
+	}
+	a := make([][]byte, n)
+	n--
+	i := 0
+	for i < n {
+		m, size := f.indexLen(s)
+		if m < 0 {
+			break
+		}
+		e := m + size
+		if sepSave {
+			a[i] = s[:e:e]
+		} else {
+			a[i] = s[:m:m]
+		}
+		s = s[e:]
+		i++
+	}
+	a[i] = s
+	return a[:i+1]
+}
+
+// SplitN slices s into subslices separated by sep, ignoring case, and
+// returns a slice of the subslices between those separators.
+// If sep is empty, SplitN splits after each UTF-8 sequence.
+// The count determines the number of subslices to return:
+//   - n > 0: at most n subslices; the last subslice will be the unsplit remainder;
+//   - n == 0: the result is nil (zero subslices);
+//   - n < 0: all subslices.
+//
+// To split around the first instance of a separator, see [Cut].
+func SplitN(s, sep []byte, n int) [][]byte { return genSplit(s, sep, false, n) }
+
+// SplitAfterN slices s into subslices after each instance of sep, ignoring
+// case, and returns a slice of those subslices. The subslices include the
+// separator as it appears in s.
+// If sep is empty, SplitAfterN splits after each UTF-8 sequence.
+// The count determines the number of subslices to return:
+//   - n > 0: at most n subslices; the last subslice will be the unsplit remainder;
+//   - n == 0: the result is nil (zero subslices);
+//   - n < 0: all subslices.
+func SplitAfterN(s, sep []byte, n int) [][]byte {
+	return genSplit(s, sep, true, n)
+}
+
+// Split slices s into all subslices separated by sep, ignoring case, and
+// returns a slice of the subslices between those separators.
+// If sep is empty, Split splits after each UTF-8 sequence.
+// It is equivalent to SplitN with a count of -1.
+//
+// To split around the first instance of a separator, see [Cut].
+func Split(s, sep []byte) [][]byte { return genSplit(s, sep, false, -1) }
+
+// SplitAfter slices s into all subslices after each instance of sep,
+// ignoring case, and returns a slice of those subslices. The subslices
+// include the separator as it appears in s.
+// If sep is empty, SplitAfter splits after each UTF-8 sequence.
+// It is equivalent to SplitAfterN with a count of -1.
+func SplitAfter(s, sep []byte) [][]byte {
+	return genSplit(s, sep, true, -1)
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestSplit(t *testing.T) {
+	test.Split(t, test.ByteSplitFunc(func(s, sep []byte, n int) [][]byte {
+		if n >= 0 {
+			return SplitN(s, sep, n)
+		}
+		return Split(s, sep)
+	}))
+}
+
+func TestSplitN(t *testing.T) {
+	test.Split(t, test.ByteSplitFunc(SplitN))
+}
+
+func TestSplitAfter(t *testing.T) {
+	test.SplitAfter(t, test.ByteSplitFunc(func(s, sep []byte, n int) [][]byte {
+		if n >= 0 {
+			return SplitAfterN(s, sep, n)
+		}
+		return SplitAfter(s, sep)
+	}))
+}
+
+func TestSplitAfterN(t *testing.T) {
+	test.SplitAfter(t, test.ByteSplitFunc(SplitAfterN))
+}
+
+func BenchmarkSplit(b *testing.B) {
+	s := []byte("a,B,c,D,e,F,g,H,i,J,k,L,m,N,o,P,q,R,s,T,u,V,w,X,y,Z")
+	sep := []byte(",")
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Split(s, sep)
+	}
+}
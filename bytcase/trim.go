@@ -0,0 +1,111 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import "unicode/utf8"
+
+// Trim returns a subslice of s by slicing off all leading and trailing
+// UTF-8-encoded code points contained in cutset, ignoring case. For example,
+// the cutset "s" removes 's', 'S' and 'ſ' (U+017F).
+func Trim(s, cutset []byte) []byte {
+	if len(s) == 0 {
+		// This is what the bytes package does.
+		return nil
+	}
+	if len(cutset) == 0 {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimLeftASCII(trimRightASCII(s, &as), &as)
+	}
+	return trimLeftUnicode(trimRightUnicode(s, cutset), cutset)
+}
+
+// TrimLeft returns a subslice of s by slicing off all leading UTF-8-encoded
+// code points contained in cutset, ignoring case.
+//
+// To remove a prefix, use [TrimPrefix] instead.
+func TrimLeft(s, cutset []byte) []byte {
+	if len(s) == 0 {
+		// This is what the bytes package does.
+		return nil
+	}
+	if len(cutset) == 0 {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimLeftASCII(s, &as)
+	}
+	return trimLeftUnicode(s, cutset)
+}
+
+// TrimRight returns a subslice of s by slicing off all trailing UTF-8-encoded
+// code points contained in cutset, ignoring case.
+//
+// To remove a suffix, use [TrimSuffix] instead.
+func TrimRight(s, cutset []byte) []byte {
+	if len(s) == 0 || len(cutset) == 0 {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimRightASCII(s, &as)
+	}
+	return trimRightUnicode(s, cutset)
+}
+
+func trimLeftASCII(s []byte, as *asciiSet) []byte {
+	for len(s) > 0 {
+		if !as.contains(s[0]) {
+			break
+		}
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		// This is what the bytes package does.
+		return nil
+	}
+	return s
+}
+
+func trimRightASCII(s []byte, as *asciiSet) []byte {
+	for len(s) > 0 {
+		if !as.contains(s[len(s)-1]) {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func trimLeftUnicode(s, cutset []byte) []byte {
+	for len(s) > 0 {
+		r, n := rune(s[0]), 1
+		if r >= utf8.RuneSelf {
+			r, n = utf8.DecodeRune(s)
+		}
+		if IndexRune(cutset, r) < 0 {
+			break
+		}
+		s = s[n:]
+	}
+	if len(s) == 0 {
+		// This is what the bytes package does.
+		return nil
+	}
+	return s
+}
+
+func trimRightUnicode(s, cutset []byte) []byte {
+	for len(s) > 0 {
+		r, size := rune(s[len(s)-1]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeLastRune(s)
+		}
+		if IndexRune(cutset, r) < 0 {
+			break
+		}
+		s = s[:len(s)-size]
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestTrim(t *testing.T) {
+	test.Trim(t, test.ByteTrimFunc(Trim))
+}
+
+func TestTrimLeft(t *testing.T) {
+	test.TrimLeft(t, test.ByteTrimFunc(TrimLeft))
+}
+
+func TestTrimRight(t *testing.T) {
+	test.TrimRight(t, test.ByteTrimFunc(TrimRight))
+}
+
+// Match the bytes package and return nil when all of s is trimmed.
+func TestTrimNil(t *testing.T) {
+	for _, s := range []string{"", "aA", "Kk"} {
+		for _, cutset := range []string{"a", "k", "k☺"} {
+			if got := Trim([]byte(s), []byte(cutset)); len(got) == 0 && got != nil {
+				t.Errorf("Trim(%q, %q) = %q; want: nil", s, cutset, got)
+			}
+			if got := TrimLeft([]byte(s), []byte(cutset)); len(got) == 0 && got != nil {
+				t.Errorf("TrimLeft(%q, %q) = %q; want: nil", s, cutset, got)
+			}
+		}
+	}
+}
+
+func BenchmarkTrim(b *testing.B) {
+	s := bytes.Repeat([]byte("xX"), 8)
+	s = append(s, "hello, world"...)
+	s = append(s, bytes.Repeat([]byte("Xx"), 8)...)
+	b.Run("ASCII", func(b *testing.B) {
+		cutset := []byte("x")
+		for i := 0; i < b.N; i++ {
+			Trim(s, cutset)
+		}
+	})
+	b.Run("Unicode", func(b *testing.B) {
+		cutset := []byte("x☺")
+		for i := 0; i < b.N; i++ {
+			Trim(s, cutset)
+		}
+	})
+}
@@ -0,0 +1,539 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/bytealg"
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// TurkicCase provides case-insensitive byte slice functions that use the
+// Turkic (Turkish and Azerbaijani) case-folding rules. These are the
+// simple case-folding rules used by the rest of this package with the
+// addition of the "T" mappings from CaseFolding.txt:
+//
+//	'I' (U+0049) folds to 'ı' (U+0131, LATIN SMALL LETTER DOTLESS I)
+//	'İ' (U+0130, LATIN CAPITAL LETTER I WITH DOT ABOVE) folds to 'i' (U+0069)
+//
+// This means that "ISPARTA" matches "ısparta" and "İSTANBUL" matches
+// "istanbul", but "I" does not match "i".
+//
+// The methods of TurkicCase mirror the functions of this package with the
+// same name. Use the [Turkic] variable to access them.
+type TurkicCase struct{}
+
+// Turkic provides case-insensitive byte slice functions that use the Turkic
+// case-folding rules. See [TurkicCase] for more information.
+var Turkic TurkicCase
+
+// hasTurkic reports whether s contains any characters that fold differently
+// under the Turkic rules ('I', 'i', 'İ', or 'ı'). If a needle does not
+// contain any of these characters the Turkic and default rules produce the
+// same results.
+func hasTurkic(s []byte) bool {
+	// Case-insensitive search for 'i' and 'I'.
+	if bytealg.IndexByte(s, 'i') >= 0 {
+		return true
+	}
+	// 'İ' and 'ı' are encoded as "\xc4\xb0" and "\xc4\xb1".
+	for {
+		i := bytes.IndexByte(s, 0xC4)
+		if i < 0 || i == len(s)-1 {
+			return false
+		}
+		if s[i+1]&^1 == 0xB0 {
+			return true
+		}
+		s = s[i+1:]
+	}
+}
+
+// turkicFold returns the Turkic case-fold of r.
+func turkicFold(r rune) rune {
+	if uint32(r) < utf8.RuneSelf {
+		if r == 'I' {
+			return 'ı'
+		}
+		return rune(_lower[r])
+	}
+	return tables.TurkicCaseFold(r)
+}
+
+// turkicDecode returns the Turkic case-fold of the first rune in s and its
+// width in bytes. The slice s must not be empty.
+func turkicDecode(s []byte) (rune, int) {
+	if c := s[0]; c < utf8.RuneSelf {
+		if c == 'I' {
+			return 'ı', 1
+		}
+		return rune(_lower[c]), 1
+	}
+	r, size := utf8.DecodeRune(s)
+	return tables.TurkicCaseFold(r), size
+}
+
+// turkicDecodeLast returns the Turkic case-fold of the last rune in s and
+// its width in bytes. The slice s must not be empty.
+func turkicDecodeLast(s []byte) (rune, int) {
+	if c := s[len(s)-1]; c < utf8.RuneSelf {
+		if c == 'I' {
+			return 'ı', 1
+		}
+		return rune(_lower[c]), 1
+	}
+	r, size := utf8.DecodeLastRune(s)
+	return tables.TurkicCaseFold(r), size
+}
+
+// Compare returns an integer comparing two byte slices lexicographically
+// ignoring case using the Turkic case-folding rules.
+// The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+func (TurkicCase) Compare(s, t []byte) int {
+	return compareTurkic(s, t)
+}
+
+func compareTurkic(s, t []byte) int {
+	for len(s) > 0 && len(t) > 0 {
+		sr, n0 := turkicDecode(s)
+		tr, n1 := turkicDecode(t)
+		if sr != tr {
+			return clamp(int(sr) - int(tr))
+		}
+		s = s[n0:]
+		t = t[n1:]
+	}
+	return clamp(len(s) - len(t))
+}
+
+// EqualFold reports whether s and t, interpreted as UTF-8 byte slices,
+// are equal under the Turkic case-folding rules.
+func (TurkicCase) EqualFold(s, t []byte) bool {
+	if !hasTurkic(s) || !hasTurkic(t) {
+		return EqualFold(s, t)
+	}
+	return compareTurkic(s, t) == 0
+}
+
+// hasPrefixTurkic returns if string s begins with prefix and the length in
+// bytes of the prefix in s.
+func hasPrefixTurkic(s, prefix []byte) (bool, int) {
+	n := 0
+	for len(prefix) > 0 {
+		if n == len(s) {
+			return false, 0
+		}
+		sr, n0 := turkicDecode(s[n:])
+		tr, n1 := turkicDecode(prefix)
+		if sr != tr {
+			return false, 0
+		}
+		n += n0
+		prefix = prefix[n1:]
+	}
+	return true, n
+}
+
+// hasSuffixTurkic returns if string s ends with suffix and the starting
+// index of the suffix in s.
+func hasSuffixTurkic(s, suffix []byte) (bool, int) {
+	n := len(s)
+	for len(suffix) > 0 {
+		if n == 0 {
+			return false, 0
+		}
+		sr, n0 := turkicDecodeLast(s[:n])
+		tr, n1 := turkicDecodeLast(suffix)
+		if sr != tr {
+			return false, 0
+		}
+		n -= n0
+		suffix = suffix[:len(suffix)-n1]
+	}
+	return true, n
+}
+
+// HasPrefix tests whether the string s begins with prefix ignoring case
+// using the Turkic case-folding rules.
+func (TurkicCase) HasPrefix(s, prefix []byte) bool {
+	if !hasTurkic(prefix) {
+		return HasPrefix(s, prefix)
+	}
+	ok, _ := hasPrefixTurkic(s, prefix)
+	return ok
+}
+
+// HasSuffix tests whether the string s ends with suffix ignoring case
+// using the Turkic case-folding rules.
+func (TurkicCase) HasSuffix(s, suffix []byte) bool {
+	if !hasTurkic(suffix) {
+		return HasSuffix(s, suffix)
+	}
+	ok, _ := hasSuffixTurkic(s, suffix)
+	return ok
+}
+
+// TrimPrefix returns s without the provided leading prefix []byte.
+// If s doesn't start with prefix, s is returned unchanged.
+func (TurkicCase) TrimPrefix(s, prefix []byte) []byte {
+	if !hasTurkic(prefix) {
+		return TrimPrefix(s, prefix)
+	}
+	if ok, n := hasPrefixTurkic(s, prefix); ok {
+		return s[n:]
+	}
+	return s
+}
+
+// TrimSuffix returns s without the provided trailing suffix []byte.
+// If s doesn't end with suffix, s is returned unchanged.
+func (TurkicCase) TrimSuffix(s, suffix []byte) []byte {
+	if !hasTurkic(suffix) {
+		return TrimSuffix(s, suffix)
+	}
+	if ok, i := hasSuffixTurkic(s, suffix); ok {
+		return s[:i]
+	}
+	return s
+}
+
+// CutPrefix returns s without the provided leading prefix []byte
+// and reports whether it found the prefix.
+// If s doesn't start with prefix, CutPrefix returns s, false.
+// If prefix is the empty string, CutPrefix returns s, true.
+func (TurkicCase) CutPrefix(s, prefix []byte) (after []byte, found bool) {
+	if !hasTurkic(prefix) {
+		return CutPrefix(s, prefix)
+	}
+	if ok, n := hasPrefixTurkic(s, prefix); ok {
+		return s[n:], true
+	}
+	return s, false
+}
+
+// CutSuffix returns s without the provided ending suffix []byte
+// and reports whether it found the suffix.
+// If s doesn't end with suffix, CutSuffix returns s, false.
+// If suffix is the empty string, CutSuffix returns s, true.
+func (TurkicCase) CutSuffix(s, suffix []byte) (before []byte, found bool) {
+	if !hasTurkic(suffix) {
+		return CutSuffix(s, suffix)
+	}
+	if ok, i := hasSuffixTurkic(s, suffix); ok {
+		return s[:i], true
+	}
+	return s, false
+}
+
+// hashStrTurkic returns the hash of sep using the Turkic case-folding rules
+// and the appropriate multiplicative factor for use in Rabin-Karp algorithm,
+// and the number of runes in sep.
+func hashStrTurkic(sep []byte) (uint32, uint32, int) {
+	hash := uint32(0)
+	n := 0
+	for i := 0; i < len(sep); {
+		r, size := turkicDecode(sep[i:])
+		hash = hash*primeRK + uint32(r)
+		i += size
+		n++
+	}
+	var pow, sq uint32 = 1, primeRK
+	for i := n; i > 0; i >>= 1 {
+		if i&1 != 0 {
+			pow *= sq
+		}
+		sq *= sq
+	}
+	return hash, pow, n
+}
+
+// hashStrRevTurkic is like hashStrTurkic but returns the hash of the reverse
+// of sep.
+func hashStrRevTurkic(sep []byte) (uint32, uint32, int) {
+	hash := uint32(0)
+	n := 0
+	for i := len(sep); i > 0; {
+		r, size := turkicDecodeLast(sep[:i])
+		hash = hash*primeRK + uint32(r)
+		i -= size
+		n++
+	}
+	var pow, sq uint32 = 1, primeRK
+	for i := n; i > 0; i >>= 1 {
+		if i&1 != 0 {
+			pow *= sq
+		}
+		sq *= sq
+	}
+	return hash, pow, n
+}
+
+// indexTurkic returns the index of the first instance of substr in s and
+// the length in bytes of the match, or -1, 0 if substr is not present in s.
+// The length of the match may differ from len(substr) since 'I' and 'i'
+// are encoded with one byte and 'ı' and 'İ' are encoded with two bytes.
+func indexTurkic(s, substr []byte) (int, int) {
+	if len(substr) == 0 {
+		return 0, 0
+	}
+	if r, size := utf8.DecodeRune(substr); size == len(substr) {
+		return indexRuneTurkic(s, r)
+	}
+
+	// Rabin-Karp search
+	hashss, pow, n := hashStrTurkic(substr)
+	var h uint32
+	i, j := 0, 0
+	for ; n > 0; n-- {
+		if j == len(s) {
+			return -1, 0
+		}
+		r, size := turkicDecode(s[j:])
+		h = h*primeRK + uint32(r)
+		j += size
+	}
+	for {
+		if h == hashss && compareTurkic(s[i:j], substr) == 0 {
+			return i, j - i
+		}
+		if j == len(s) {
+			return -1, 0
+		}
+		r0, n0 := turkicDecode(s[j:])
+		r1, n1 := turkicDecode(s[i:])
+		h *= primeRK
+		h += uint32(r0)
+		h -= pow * uint32(r1)
+		j += n0
+		i += n1
+	}
+}
+
+// lastIndexTurkic is like indexTurkic but returns the index of the last
+// instance of substr in s.
+func lastIndexTurkic(s, substr []byte) int {
+	if len(substr) == 0 {
+		return len(s)
+	}
+
+	// Reverse Rabin-Karp search
+	hashss, pow, n := hashStrRevTurkic(substr)
+	var h uint32
+	i, j := len(s), len(s)
+	for ; n > 0; n-- {
+		if i == 0 {
+			return -1
+		}
+		r, size := turkicDecodeLast(s[:i])
+		h = h*primeRK + uint32(r)
+		i -= size
+	}
+	for {
+		if h == hashss && compareTurkic(s[i:j], substr) == 0 {
+			return i
+		}
+		if i == 0 {
+			return -1
+		}
+		r0, n0 := turkicDecodeLast(s[:i])
+		r1, n1 := turkicDecodeLast(s[:j])
+		h *= primeRK
+		h += uint32(r0)
+		h -= pow * uint32(r1)
+		i -= n0
+		j -= n1
+	}
+}
+
+// Index returns the index of the first instance of substr in s, or -1 if
+// substr is not present in s.
+func (TurkicCase) Index(s, substr []byte) int {
+	if !hasTurkic(substr) {
+		return Index(s, substr)
+	}
+	i, _ := indexTurkic(s, substr)
+	return i
+}
+
+// LastIndex returns the index of the last instance of substr in s, or -1 if
+// substr is not present in s.
+func (TurkicCase) LastIndex(s, substr []byte) int {
+	if !hasTurkic(substr) {
+		return LastIndex(s, substr)
+	}
+	return lastIndexTurkic(s, substr)
+}
+
+// Contains reports whether substr is within s.
+func (t TurkicCase) Contains(s, substr []byte) bool {
+	return t.Index(s, substr) >= 0
+}
+
+var (
+	smallDotlessI  = []byte("ı")
+	capitalDottedI = []byte("İ")
+)
+
+// turkicPair returns the ASCII and two-byte members of the Turkic
+// equivalence class of r, if r is one of 'I', 'i', 'İ', or 'ı'.
+func turkicPair(r rune) (byte, []byte, bool) {
+	switch r {
+	case 'I', 'ı':
+		return 'I', smallDotlessI, true
+	case 'i', 'İ':
+		return 'i', capitalDottedI, true
+	}
+	return 0, nil, false
+}
+
+// indexRuneTurkic returns the index of the first instance of the Unicode
+// code point r and the size of the match, or -1, 0 if r is not present.
+func indexRuneTurkic(s []byte, r rune) (int, int) {
+	c, u, ok := turkicPair(r)
+	if !ok {
+		i := IndexRune(s, r)
+		if i < 0 {
+			return -1, 0
+		}
+		_, size := utf8.DecodeRune(s[i:])
+		return i, size
+	}
+	n := bytes.IndexByte(s, c)
+	if n >= 0 {
+		s = s[:n]
+	}
+	if i := bytes.Index(s, u); i >= 0 {
+		return i, len(u)
+	}
+	if n < 0 {
+		return -1, 0
+	}
+	return n, 1
+}
+
+// IndexRune returns the index of the first instance of the Unicode code
+// point r, or -1 if rune is not present in s.
+// If r is utf8.RuneError, it returns the first instance of any
+// invalid UTF-8 byte sequence.
+func (TurkicCase) IndexRune(s []byte, r rune) int {
+	i, _ := indexRuneTurkic(s, r)
+	return i
+}
+
+// ContainsRune reports whether the Unicode code point r is within s.
+func (t TurkicCase) ContainsRune(s []byte, r rune) bool {
+	return t.IndexRune(s, r) >= 0
+}
+
+// IndexByte returns the index of the first instance of c in s, or -1 if c
+// is not present in s.
+func (TurkicCase) IndexByte(s []byte, c byte) int {
+	if c != 'I' && c != 'i' {
+		return IndexByte(s, c)
+	}
+	i, _ := indexRuneTurkic(s, rune(c))
+	return i
+}
+
+// LastIndexByte returns the index of the last instance of c in s, or -1 if
+// c is not present in s.
+func (TurkicCase) LastIndexByte(s []byte, c byte) int {
+	if c != 'I' && c != 'i' {
+		return LastIndexByte(s, c)
+	}
+	c, u, _ := turkicPair(rune(c))
+	i := bytes.LastIndexByte(s, c)
+	if j := bytes.LastIndex(s, u); j > i {
+		return j
+	}
+	return i
+}
+
+// containsRuneTurkic reports whether chars contains a rune that is equal
+// to r under the Turkic case-folding rules.
+func containsRuneTurkic(chars []byte, r rune) bool {
+	r = turkicFold(r)
+	for i := 0; i < len(chars); {
+		c, size := turkicDecode(chars[i:])
+		if c == r {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// IndexAny returns the index of the first instance of any Unicode code point
+// from chars in s, or -1 if no Unicode code point from chars is present in s.
+func (TurkicCase) IndexAny(s, chars []byte) int {
+	if !hasTurkic(chars) {
+		return IndexAny(s, chars)
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRune(s[i:])
+		if containsRuneTurkic(chars, r) {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// LastIndexAny returns the index of the last instance of any Unicode code
+// point from chars in s, or -1 if no Unicode code point from chars is
+// present in s.
+func (TurkicCase) LastIndexAny(s, chars []byte) int {
+	if !hasTurkic(chars) {
+		return LastIndexAny(s, chars)
+	}
+	for i := len(s); i > 0; {
+		r, size := utf8.DecodeLastRune(s[:i])
+		i -= size
+		if containsRuneTurkic(chars, r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsAny reports whether any Unicode code points in chars are within s.
+func (t TurkicCase) ContainsAny(s, chars []byte) bool {
+	return t.IndexAny(s, chars) >= 0
+}
+
+// Count counts the number of non-overlapping instances of substr in s.
+// If substr is an empty string, Count returns 1 + the number of Unicode
+// code points in s.
+func (TurkicCase) Count(s, substr []byte) int {
+	if !hasTurkic(substr) {
+		return Count(s, substr)
+	}
+	n := 0
+	for {
+		i, size := indexTurkic(s, substr)
+		if i == -1 {
+			return n
+		}
+		n++
+		s = s[i+size:]
+	}
+}
+
+// Cut slices s around the first instance of sep,
+// returning the text before and after sep.
+// The found result reports whether sep appears in s.
+// If sep does not appear in s, cut returns s, nil, false.
+//
+// Cut returns slices of the original slice s, not copies.
+func (TurkicCase) Cut(s, sep []byte) (before, after []byte, found bool) {
+	if !hasTurkic(sep) {
+		return Cut(s, sep)
+	}
+	if i, n := indexTurkic(s, sep); i >= 0 {
+		return s[:i], s[i+n:], true
+	}
+	return s, nil, false
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+// Most of these tests map their input from the default to the Turkic
+// case-folding rules so that the Turkic functions are held to the same
+// test suites as the default functions (see test.TurkicIndexFunc).
+
+func TestTurkicCompare(t *testing.T) {
+	test.TurkicCompare(t, test.ByteIndexFunc(Turkic.Compare))
+	test.Compare(t, test.TurkicCompareFunc(test.ByteIndexFunc(Turkic.Compare)))
+}
+
+func TestTurkicEqualFold(t *testing.T) {
+	test.TurkicEqualFold(t, test.ByteContainsFunc(Turkic.EqualFold))
+	test.EqualFold(t, test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.EqualFold)))
+}
+
+func TestTurkicIndex(t *testing.T) {
+	test.TurkicIndex(t, test.ByteIndexFunc(Turkic.Index))
+	test.Index(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)))
+}
+
+func TestTurkicIndexUnicode(t *testing.T) {
+	test.IndexUnicode(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)))
+}
+
+func TestTurkicIndexInvalid(t *testing.T) {
+	test.IndexInvalid(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)))
+}
+
+func TestTurkicIndexKelvin(t *testing.T) {
+	test.IndexKelvin(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)))
+}
+
+func TestTurkicIndexRuneIndexParity(t *testing.T) {
+	test.IndexRuneIndexParity(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)),
+		test.TurkicIndexRuneFunc(test.ByteIndexRuneFunc(Turkic.IndexRune)))
+}
+
+func TestTurkicIndexAllAssigned(t *testing.T) {
+	test.IndexAllAssigned(t,
+		test.TestFunc{Name: "Compare", Index: test.TurkicCompareFunc(test.ByteIndexFunc(Turkic.Compare))},
+		test.TestFunc{Name: "Contains", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.Contains))},
+		test.TestFunc{Name: "EqualFold", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.EqualFold))},
+		test.TestFunc{Name: "HasPrefix", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasPrefix))},
+		test.TestFunc{Name: "HasSuffix", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasSuffix))},
+		test.TestFunc{Name: "Index", Index: test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index))},
+		test.TestFunc{Name: "LastIndex", Index: test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.LastIndex))},
+	)
+}
+
+func TestTurkicContains(t *testing.T) {
+	test.Contains(t, test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.Contains)))
+}
+
+func TestTurkicContainsAny(t *testing.T) {
+	test.ContainsAny(t, test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.ContainsAny)))
+}
+
+func TestTurkicLastIndex(t *testing.T) {
+	test.TurkicLastIndex(t, test.ByteIndexFunc(Turkic.LastIndex))
+	test.LastIndex(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.LastIndex)))
+}
+
+func TestTurkicLastIndexInvalid(t *testing.T) {
+	test.LastIndexInvalid(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.LastIndex)))
+}
+
+func TestTurkicIndexRune(t *testing.T) {
+	test.IndexRune(t, test.TurkicIndexRuneFunc(test.ByteIndexRuneFunc(Turkic.IndexRune)))
+}
+
+func TestTurkicContainsRune(t *testing.T) {
+	test.ContainsRune(t, test.TurkicContainsRuneFunc(func(s string, r rune) bool {
+		return Turkic.ContainsRune([]byte(s), r)
+	}))
+}
+
+func TestTurkicIndexByte(t *testing.T) {
+	test.IndexByte(t, test.TurkicIndexByteFunc(test.ByteIndexByte(Turkic.IndexByte)))
+}
+
+func TestTurkicLastIndexByte(t *testing.T) {
+	test.LastIndexByte(t, test.TurkicIndexByteFunc(test.ByteIndexByte(Turkic.LastIndexByte)))
+}
+
+func TestTurkicTrimPrefix(t *testing.T) {
+	test.TrimPrefix(t, test.TurkicTrimFunc(test.ByteTrimFunc(Turkic.TrimPrefix)))
+}
+
+func TestTurkicHasSuffix(t *testing.T) {
+	test.HasSuffix(t, test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasSuffix)))
+}
+
+func TestTurkicTrimSuffix(t *testing.T) {
+	test.TrimSuffix(t, test.TurkicTrimFunc(test.ByteTrimFunc(Turkic.TrimSuffix)))
+}
+
+func TestTurkicCount(t *testing.T) {
+	test.Count(t, test.TurkicCountFunc(test.ByteIndexFunc(Turkic.Count)))
+}
+
+func TestTurkicIndexAny(t *testing.T) {
+	test.IndexAny(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.IndexAny)))
+}
+
+func TestTurkicLastIndexAny(t *testing.T) {
+	test.LastIndexAny(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.LastIndexAny)))
+}
+
+func TestTurkicCut(t *testing.T) {
+	test.Cut(t, test.TurkicCutFunc(func(s, sep string) (before, after string, found bool) {
+		b, a, ok := Turkic.Cut([]byte(s), []byte(sep))
+		return string(b), string(a), ok
+	}))
+}
+
+func TestTurkicCutPrefix(t *testing.T) {
+	test.CutPrefix(t, test.TurkicCutAffixFunc(func(s, affix string) (string, bool) {
+		b, ok := Turkic.CutPrefix([]byte(s), []byte(affix))
+		return string(b), ok
+	}))
+}
+
+func TestTurkicCutSuffix(t *testing.T) {
+	test.CutSuffix(t, test.TurkicCutAffixFunc(func(s, affix string) (string, bool) {
+		b, ok := Turkic.CutSuffix([]byte(s), []byte(affix))
+		return string(b), ok
+	}))
+}
+
+func TestTurkicIndexFuzz(t *testing.T) {
+	test.IndexFuzz(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.Index)))
+}
+
+func TestTurkicLastIndexFuzz(t *testing.T) {
+	test.LastIndexFuzz(t, test.TurkicIndexFunc(test.ByteIndexFunc(Turkic.LastIndex)))
+}
+
+func TestTurkicHasSuffixFuzz(t *testing.T) {
+	test.HasSuffixFuzz(t, test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasSuffix)))
+}
+
+func TestTurkicCompareFuzz(t *testing.T) {
+	test.CompareFuzz(t, test.TurkicCompareFunc(test.ByteIndexFunc(Turkic.Compare)))
+}
+
+func TestTurkicEqualFoldFuzz(t *testing.T) {
+	test.EqualFoldFuzz(t,
+		test.TestFunc{Name: "Contains", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.Contains))},
+		test.TestFunc{Name: "EqualFold", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.EqualFold))},
+		test.TestFunc{Name: "HasPrefix", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasPrefix))},
+		test.TestFunc{Name: "HasSuffix", Contains: test.TurkicContainsFunc(test.ByteContainsFunc(Turkic.HasSuffix))},
+	)
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+// Test the Two-Way implementation directly since not all test cases will
+// trigger it.
+func TestIndexTwoWay(t *testing.T) {
+	indexTwoWay := test.IndexLenCheckFunc(t, "indexTwoWay", test.ByteIndexLenFunc(indexTwoWay))
+	test.Index(t, indexTwoWay)
+	test.IndexUnicode(t, indexTwoWay)
+	test.IndexInvalid(t, indexTwoWay)
+	test.IndexKelvin(t, indexTwoWay)
+	test.IndexPeriodic(t, indexTwoWay)
+	test.IndexFuzz(t, indexTwoWay)
+}
+
+func TestLastIndexTwoWay(t *testing.T) {
+	lastIndexTwoWay := test.IndexLenCheckFunc(t, "lastIndexTwoWay", test.ByteIndexLenFunc(lastIndexTwoWay))
+	test.LastIndex(t, lastIndexTwoWay)
+	test.LastIndexInvalid(t, lastIndexTwoWay)
+	test.LastIndexPeriodic(t, lastIndexTwoWay)
+	test.LastIndexFuzz(t, lastIndexTwoWay)
+}
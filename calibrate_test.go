@@ -54,7 +54,8 @@ var calibrate = flag.Bool("calibrate", false, "compute crossover for linear vs.
 // }
 
 // This test checks if the performance of strings.LastIndexByte has improved
-// and beats the naive implementation (based on Go 1.19) that is used here.
+// and caught up with the SIMD implementation in internal/bytealg. If so, it
+// should be used for bytes that are not ASCII letters.
 func TestCalibrateLastIndexByte(t *testing.T) {
 	if !*calibrate {
 		return
@@ -78,9 +79,9 @@ func TestCalibrateLastIndexByte(t *testing.T) {
 
 	t.Logf("strings=%d stracse=%d\n", nsStrings, nsStrcase)
 
-	// If strings.LastIndexByte is 1.5x faster than the current naive
-	// Go 1.19 implementation then we should take advantage of that.
-	if nsStrings > nsStrcase+nsStrcase/2 {
+	// If strings.LastIndexByte is faster than the bytealg implementation
+	// then we should take advantage of that.
+	if nsStrings < nsStrcase {
 		t.Fatalf("strings.LastIndexByte = %d ns/op LastIndexByte = %d ns/op Delta = %.2fx",
 			nsStrings, nsStrcase, float64(nsStrcase)/float64(nsStrings))
 	}
@@ -0,0 +1,193 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/bytealg"
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// A CharSet is a set of Unicode code points that is closed under simple
+// Unicode case-folding. That is, if a character is in the set so are all
+// of its case-folds (the set built from "k" contains 'k', 'K' and Kelvin K
+// (U+212A)).
+//
+// A CharSet is useful when the same set of characters is searched for
+// repeatedly since [IndexAny], [LastIndexAny] and [Trim] rebuild their set
+// of characters on each call. A CharSet is safe for concurrent use by
+// multiple goroutines. The zero value is an empty set.
+type CharSet struct {
+	ascii asciiSet
+	runes []rune // sorted non-ASCII code points
+}
+
+// NewCharSet returns a [CharSet] that contains the Unicode code points in
+// chars and all of their case-folds. Invalid UTF-8 sequences in chars are
+// treated as U+FFFD and match invalid UTF-8 sequences when searching.
+func NewCharSet(chars string) *CharSet {
+	c := new(CharSet)
+	for _, r := range chars {
+		c.add(r)
+		// Like indexRune, FoldMap takes precedence over ToUpperLower
+		// since the upper and lower case of some characters are not
+		// case-folds of each other (e.g. 'İ' and 'i').
+		if folds := tables.FoldMap(r); folds != nil {
+			for _, f := range folds {
+				if f != 0 {
+					c.add(rune(f))
+				}
+			}
+		} else if u, l, ok := tables.ToUpperLower(r); ok {
+			c.add(u)
+			c.add(l)
+		}
+	}
+	if len(c.runes) > 1 {
+		sort.Slice(c.runes, func(i, j int) bool {
+			return c.runes[i] < c.runes[j]
+		})
+		// Remove duplicates
+		a := c.runes[:1]
+		for _, r := range c.runes[1:] {
+			if r != a[len(a)-1] {
+				a = append(a, r)
+			}
+		}
+		c.runes = a
+	}
+	return c
+}
+
+func (c *CharSet) add(r rune) {
+	if 0 <= r && r < utf8.RuneSelf {
+		c.ascii[r&0xf] |= 1 << (r >> 4)
+	} else {
+		c.runes = append(c.runes, r)
+	}
+}
+
+// Contains reports whether r is in the set.
+func (c *CharSet) Contains(r rune) bool {
+	if 0 <= r && r < utf8.RuneSelf {
+		return c.ascii.contains(byte(r))
+	}
+	// Binary search
+	a := c.runes
+	i, j := 0, len(a)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		if a[h] < r {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i < len(a) && a[i] == r
+}
+
+// IndexAny returns the index of the first instance of any Unicode code point
+// in the set in s, or -1 if no Unicode code point in the set is present in s.
+func (c *CharSet) IndexAny(s string) int {
+	if len(c.runes) == 0 {
+		// Only non-ASCII characters can fold to ASCII characters
+		// so only ASCII characters can match.
+		return bytealg.IndexAnyASCIIString(s, (*[16]byte)(&c.ascii))
+	}
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			if c.ascii.contains(s[i]) {
+				return i
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if c.Contains(r) {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// LastIndexAny returns the index of the last instance of any Unicode code
+// point in the set in s, or -1 if no Unicode code point in the set is
+// present in s.
+func (c *CharSet) LastIndexAny(s string) int {
+	if len(c.runes) == 0 {
+		return bytealg.LastIndexAnyASCIIString(s, (*[16]byte)(&c.ascii))
+	}
+	for i := len(s); i > 0; {
+		if s[i-1] < utf8.RuneSelf {
+			i--
+			if c.ascii.contains(s[i]) {
+				return i
+			}
+			continue
+		}
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+		if c.Contains(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Count returns the number of Unicode code points in s that are in the set.
+func (c *CharSet) Count(s string) int {
+	n := 0
+	if len(c.runes) == 0 {
+		for i := 0; i < len(s); i++ {
+			if c.ascii.contains(s[i]) {
+				n++
+			}
+		}
+		return n
+	}
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			if c.ascii.contains(s[i]) {
+				n++
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if c.Contains(r) {
+			n++
+		}
+		i += size
+	}
+	return n
+}
+
+// Trim returns a slice of the string s with all leading and trailing Unicode
+// code points in the set removed.
+func (c *CharSet) Trim(s string) string {
+	for len(s) > 0 {
+		r, n := rune(s[0]), 1
+		if r >= utf8.RuneSelf {
+			r, n = utf8.DecodeRuneInString(s)
+		}
+		if !c.Contains(r) {
+			break
+		}
+		s = s[n:]
+	}
+	for len(s) > 0 {
+		r, size := rune(s[len(s)-1]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeLastRuneInString(s)
+		}
+		if !c.Contains(r) {
+			break
+		}
+		s = s[:len(s)-size]
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestCharSetContains(t *testing.T) {
+	test.CharSetContains(t, func(chars string) func(rune) bool {
+		return NewCharSet(chars).Contains
+	})
+}
+
+func TestCharSetIndexAny(t *testing.T) {
+	test.IndexAny(t, func(s, chars string) int {
+		return NewCharSet(chars).IndexAny(s)
+	})
+}
+
+func TestCharSetLastIndexAny(t *testing.T) {
+	test.LastIndexAny(t, func(s, chars string) int {
+		return NewCharSet(chars).LastIndexAny(s)
+	})
+}
+
+func TestCharSetCount(t *testing.T) {
+	test.CharSetCount(t, func(s, chars string) int {
+		return NewCharSet(chars).Count(s)
+	})
+}
+
+func TestCharSetTrim(t *testing.T) {
+	test.Trim(t, func(s, cutset string) string {
+		return NewCharSet(cutset).Trim(s)
+	})
+}
+
+func TestCharSetZeroValue(t *testing.T) {
+	var c CharSet
+	if c.Contains('a') || c.IndexAny("abc") != -1 || c.LastIndexAny("abc") != -1 ||
+		c.Count("abc") != 0 || c.Trim("abc") != "abc" {
+		t.Error("the zero value CharSet must be empty")
+	}
+}
+
+func TestCharSetAllocs(t *testing.T) {
+	c := NewCharSet("aK☺")
+	s := strings.Repeat("xyz☻", 8) + "☺"
+	allocs := testing.AllocsPerRun(100, func() {
+		if c.IndexAny(s) < 0 || c.LastIndexAny(s) < 0 || c.Count(s) != 1 {
+			t.Fatal("CharSet failed to find '☺'")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations got: %.2f", allocs)
+	}
+}
+
+func BenchmarkCharSet(b *testing.B) {
+	bench := func(b *testing.B, s, chars string) {
+		b.Run("IndexAny", func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				IndexAny(s, chars)
+			}
+		})
+		b.Run("CharSet", func(b *testing.B) {
+			c := NewCharSet(chars)
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				c.IndexAny(s)
+			}
+		})
+	}
+	b.Run("FewChars", func(b *testing.B) {
+		bench(b, strings.Repeat("xyz☻", 64)+"☺", "aK☺")
+	})
+	b.Run("ManyChars", func(b *testing.B) {
+		bench(b, strings.Repeat("xyz☻", 16)+"☺", "αβγδεζηθικλμνξοπρστυφχψω☺")
+	})
+}
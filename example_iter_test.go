@@ -0,0 +1,49 @@
+//go:build go1.23
+// +build go1.23
+
+package strcase_test
+
+import (
+	"fmt"
+
+	"github.com/charlievieth/strcase"
+)
+
+func ExampleAllIndexes() {
+	s := "oink OINK \u212Aoink"
+	for i, n := range strcase.AllIndexes(s, "oink") {
+		fmt.Println(i, n, s[i:i+n])
+	}
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	for i, n := range strcase.AllIndexes(s, "k") {
+		fmt.Println(i, n)
+	}
+	// Output:
+	// 0 4 oink
+	// 5 4 OINK
+	// 13 4 oink
+	// 3 1
+	// 8 1
+	// 10 3
+	// 16 1
+}
+
+func ExampleSplitSeq() {
+	for part := range strcase.SplitSeq("a AND b and c", " and ") {
+		fmt.Printf("%q\n", part)
+	}
+	// Output:
+	// "a"
+	// "b"
+	// "c"
+}
+
+func ExampleSplitAfterSeq() {
+	for part := range strcase.SplitAfterSeq("aXbxc", "x") {
+		fmt.Printf("%q\n", part)
+	}
+	// Output:
+	// "aX"
+	// "bx"
+	// "c"
+}
@@ -1,7 +1,9 @@
 package strcase_test
 
 import (
+	"bytes"
 	"fmt"
+	"hash/maphash"
 	"sort"
 	"unicode/utf8"
 
@@ -189,6 +191,16 @@ func ExampleHasSuffix() {
 	// true
 }
 
+func ExampleHash() {
+	seed := maphash.MakeSeed()
+	// Kelvin K (U+212A) folds to 'k'
+	fmt.Println(strcase.Hash(seed, "Kelvin") == strcase.Hash(seed, "\u212AELVIN"))
+	fmt.Println(strcase.Hash(seed, "Kelvin") == strcase.Hash(seed, "Celsius"))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleIndex() {
 	fmt.Println(strcase.Index("chicken", "KEN"))
 	fmt.Println(strcase.Index("chicken", "DMR"))
@@ -376,3 +388,227 @@ func ExampleContainsNonASCII() {
 	// true
 	// false
 }
+
+func ExampleFinder() {
+	f := strcase.NewFinder("GOPHER")
+	lines := []string{
+		"The gopher sleeps",
+		"Two Gophers: gopher and GOPHER",
+		"No badgers",
+	}
+	for _, s := range lines {
+		fmt.Println(f.Index(s), f.LastIndex(s), f.Count(s))
+	}
+	// Output:
+	// 4 4 1
+	// 4 24 3
+	// -1 -1 0
+}
+
+func ExampleEqualFoldFull() {
+	fmt.Println(strcase.EqualFold("straße", "STRASSE"))
+	fmt.Println(strcase.EqualFoldFull("straße", "STRASSE"))
+	fmt.Println(strcase.EqualFoldFull("oﬃce", "OFFICE"))
+	// Output:
+	// false
+	// true
+	// true
+}
+
+func ExampleIndexFull() {
+	// The length of the match may differ from the length of substr.
+	s := "Die Straße"
+	i, n := strcase.IndexFull(s, "STRASSE")
+	fmt.Println(i, n, s[i:i+n])
+	fmt.Println(strcase.IndexFull("Die Strasse", "straße"))
+	fmt.Println(strcase.IndexFull("ß", "s"))
+	// Output:
+	// 4 7 Straße
+	// 4 7
+	// -1 0
+}
+
+func ExampleTurkicCase() {
+	fmt.Println(strcase.EqualFold("İSTANBUL", "istanbul"))
+	fmt.Println(strcase.Turkic.EqualFold("İSTANBUL", "istanbul"))
+	fmt.Println(strcase.Turkic.EqualFold("ISPARTA", "ısparta"))
+	fmt.Println(strcase.Turkic.EqualFold("ISPARTA", "isparta"))
+	fmt.Println(strcase.Turkic.Index("Diyarbakir DIYARBAKIR", "DIYARBAKIR"))
+	// Output:
+	// false
+	// true
+	// true
+	// false
+	// 11
+}
+
+func ExampleReplace() {
+	fmt.Println(strcase.Replace("oink OINK oink", "K", "ky", 2))
+	fmt.Println(strcase.Replace("oink OINK oink", "OINK", "moo", -1))
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	fmt.Println(strcase.Replace("\u212Aelvin", "k", "K", -1))
+	// Output:
+	// oinky OINky oink
+	// moo moo moo
+	// Kelvin
+}
+
+func ExampleReplaceAll() {
+	fmt.Println(strcase.ReplaceAll("oink OINK oink", "OINK", "moo"))
+	// Output:
+	// moo moo moo
+}
+
+func ExampleReplacePreserveCase() {
+	s := "color Color COLOR"
+	fmt.Println(strcase.ReplacePreserveCase(s, "color", "colour", -1))
+	fmt.Println(strcase.ReplacePreserveCase(s, "color", "colour", 1))
+	// Output:
+	// colour Colour COLOUR
+	// colour Color COLOR
+}
+
+func ExampleNewReplacer() {
+	r := strcase.NewReplacer("<", "&lt;", ">", "&gt;", "GOPHER", "Gopher")
+	fmt.Println(r.Replace("This is <b>HTML</b> for gophers!"))
+	// Output:
+	// This is &lt;b&gt;HTML&lt;/b&gt; for Gophers!
+}
+
+func ExampleSplit() {
+	fmt.Printf("%q\n", strcase.Split("a,b,c", ","))
+	fmt.Printf("%q\n", strcase.Split("a man a plan a canal panama", "A "))
+	fmt.Printf("%q\n", strcase.Split(" xyz ", ""))
+	fmt.Printf("%q\n", strcase.Split("", "Bernardo O'Higgins"))
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	fmt.Printf("%q\n", strcase.Split("1k2K3", "K"))
+	// Output:
+	// ["a" "b" "c"]
+	// ["" "man " "plan " "canal panama"]
+	// [" " "x" "y" "z" " "]
+	// [""]
+	// ["1" "2" "3"]
+}
+
+func ExampleSplitN() {
+	fmt.Printf("%q\n", strcase.SplitN("aXbXc", "x", 2))
+	z := strcase.SplitN("aXbXc", "x", 0)
+	fmt.Printf("%q (nil = %v)\n", z, z == nil)
+	// Output:
+	// ["a" "bXc"]
+	// [] (nil = true)
+}
+
+func ExampleSplitAfter() {
+	fmt.Printf("%q\n", strcase.SplitAfter("aXbxc", "x"))
+	// Output:
+	// ["aX" "bx" "c"]
+}
+
+func ExampleSplitAfterN() {
+	fmt.Printf("%q\n", strcase.SplitAfterN("aXbxc", "x", 2))
+	// Output:
+	// ["aX" "bxc"]
+}
+
+func ExampleIndexLen() {
+	// Kelvin K (U+212A) is three bytes but matches 'k'.
+	s := "Kelvin"
+	i, n := strcase.IndexLen(s, "KEL")
+	fmt.Println(i, n, s[i+n:])
+	fmt.Println(strcase.IndexLen(s, "x"))
+	// Output:
+	// 0 5 vin
+	// -1 0
+}
+
+func ExampleHasPrefixLen() {
+	s := "Kelvin"
+	ok, n := strcase.HasPrefixLen(s, "kel")
+	fmt.Println(ok, n, s[n:])
+	// Output:
+	// true 5 vin
+}
+
+func ExampleTrim() {
+	fmt.Print(strcase.Trim("xX¡¡¡Hello, Gophers!!!Xx", "!¡X"))
+	// Output: Hello, Gophers
+}
+
+func ExampleTrimLeft() {
+	// 'ſ' (U+017F) folds to 's'.
+	fmt.Print(strcase.TrimLeft("sSſHello, Gophers", "s"))
+	// Output: Hello, Gophers
+}
+
+func ExampleTrimRight() {
+	fmt.Print(strcase.TrimRight("Hello, Gophers!!!xX", "!x"))
+	// Output: Hello, Gophers
+}
+
+func ExampleCharSet() {
+	// The set contains 'k', 'K', Kelvin K (U+212A), 'x' and 'X'.
+	c := strcase.NewCharSet("kx")
+	fmt.Println(c.Contains('K'))
+	fmt.Println(c.IndexAny("chicken"))
+	fmt.Println(c.LastIndexAny("KEX LUTHOR"))
+	fmt.Println(c.Count("Kicks"))
+	fmt.Println(c.Trim("xXoxKoK"))
+	// Output:
+	// true
+	// 4
+	// 2
+	// 2
+	// oxKo
+}
+
+func ExampleMultiMatcher() {
+	m := strcase.NewMultiMatcher("gopher", "rust", "ZIG")
+	s := "Gophers, Rustaceans and Ziguanas"
+	for _, match := range m.FindAll(s, -1) {
+		fmt.Println(match.Pattern, s[match.Start:match.End])
+	}
+	// Output:
+	// 0 Gopher
+	// 1 Rust
+	// 2 Zig
+}
+
+func ExampleIndexAnyString() {
+	fmt.Println(strcase.IndexAnyString("Gophers and Rustaceans", []string{"rust", "GOPHER"}))
+	fmt.Println(strcase.IndexAnyString("chicken", []string{"dmr", "rsc"}))
+	// Output:
+	// 0 1
+	// -1 -1
+}
+
+func ExampleMap() {
+	var m strcase.Map[string]
+	m.Set("Content-Type", "text/plain")
+	m.Set("X-Request-Id", "1234")
+	m.Set("CONTENT-TYPE", "application/json") // Replaces the value
+
+	fmt.Println(m.Get("content-type"))
+	fmt.Println(m.Lookup("x-request-id"))
+	m.Range(func(key, value string) bool {
+		fmt.Printf("%s: %s\n", key, value)
+		return true
+	})
+	// Output:
+	// application/json true
+	// X-Request-Id 1234 true
+	// Content-Type: application/json
+	// X-Request-Id: 1234
+}
+
+func ExampleAppendSortKey() {
+	// Sort keys compare the same as Compare and can be used in systems
+	// that only compare raw bytes.
+	a := strcase.AppendSortKey(nil, "Straße")
+	b := strcase.AppendSortKey(nil, "STRASSE")
+	fmt.Printf("%q %q\n", a, b)
+	fmt.Println(bytes.Compare(a, b), strcase.Compare("Straße", "STRASSE"))
+	// Output:
+	// "straße" "strasse"
+	// 1 1
+}
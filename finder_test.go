@@ -0,0 +1,113 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func finderIndex(s, substr string) int     { return NewFinder(substr).Index(s) }
+func finderLastIndex(s, substr string) int { return NewFinder(substr).LastIndex(s) }
+func finderCount(s, substr string) int     { return NewFinder(substr).Count(s) }
+
+func TestFinderIndex(t *testing.T) {
+	test.Index(t, finderIndex)
+}
+
+func TestFinderIndexUnicode(t *testing.T) {
+	test.IndexUnicode(t, finderIndex)
+}
+
+func TestFinderIndexInvalid(t *testing.T) {
+	test.IndexInvalid(t, finderIndex)
+}
+
+func TestFinderIndexNumeric(t *testing.T) {
+	test.IndexNumeric(t, finderIndex)
+}
+
+func TestFinderIndexKelvin(t *testing.T) {
+	test.IndexKelvin(t, finderIndex)
+}
+
+func TestFinderIndexPeriodic(t *testing.T) {
+	test.IndexPeriodic(t, finderIndex)
+}
+
+func TestFinderIndexRare(t *testing.T) {
+	test.IndexRare(t, finderIndex)
+}
+
+func TestFinderLastIndex(t *testing.T) {
+	test.LastIndex(t, finderLastIndex)
+}
+
+func TestFinderLastIndexInvalid(t *testing.T) {
+	test.LastIndexInvalid(t, finderLastIndex)
+}
+
+func TestFinderLastIndexPeriodic(t *testing.T) {
+	test.LastIndexPeriodic(t, finderLastIndex)
+}
+
+func TestFinderContains(t *testing.T) {
+	test.Contains(t, func(s, substr string) bool {
+		return NewFinder(substr).Contains(s)
+	})
+}
+
+func TestFinderCount(t *testing.T) {
+	test.Count(t, finderCount)
+}
+
+func TestFinderCut(t *testing.T) {
+	test.Cut(t, func(s, sep string) (before, after string, found bool) {
+		return NewFinder(sep).Cut(s)
+	})
+}
+
+func TestFinderIndexFuzz(t *testing.T) {
+	test.IndexFuzz(t, finderIndex)
+}
+
+func TestFinderLastIndexFuzz(t *testing.T) {
+	test.LastIndexFuzz(t, finderLastIndex)
+}
+
+func TestFinderAllocs(t *testing.T) {
+	haystack := "test世界İ"
+	f0 := NewFinder("世界İ")
+	f1 := NewFinder("t世")
+	f2 := NewFinder("test世界İ")
+	allocs := testing.AllocsPerRun(1000, func() {
+		if i := f0.Index(haystack); i != 4 {
+			t.Fatalf("'s' at %d; want 4", i)
+		}
+		if i := f1.Index(haystack); i != 3 {
+			t.Fatalf("'世' at %d; want 3", i)
+		}
+		if i := f2.LastIndex(haystack); i != 0 {
+			t.Fatalf("'İ' at %d; want 0", i)
+		}
+		if n := f1.Count(haystack); n != 1 {
+			t.Fatalf("Count = %d; want 1", n)
+		}
+		if _, _, ok := f0.Cut(haystack); !ok {
+			t.Fatal("Cut: failed to find needle")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
+
+func BenchmarkFinderIndex(b *testing.B) {
+	f := NewFinder("fox jumps")
+	s := "the quick brown fox jumps over the lazy dog"
+	for i := 0; i < b.N; i++ {
+		f.Index(s)
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// A fullFolder returns the full case-folded runes of a string one at a time.
+type fullFolder struct {
+	s    string
+	n    int        // number of bytes of s consumed
+	fold *[4]uint16 // full case-fold of the last rune read from s, if any
+	j    int        // index of the next rune in fold
+}
+
+// next returns the next case-folded rune or false if the string is exhausted.
+func (f *fullFolder) next() (rune, bool) {
+	if f.fold != nil {
+		r := rune(f.fold[f.j])
+		f.j++
+		if f.j == len(f.fold) || f.fold[f.j] == 0 {
+			f.fold = nil
+		}
+		return r, true
+	}
+	if f.n >= len(f.s) {
+		return 0, false
+	}
+	if c := f.s[f.n]; c < utf8.RuneSelf {
+		f.n++
+		return rune(_lower[c]), true
+	}
+	r, size := utf8.DecodeRuneInString(f.s[f.n:])
+	f.n += size
+	if p := tables.FullCaseFold(r); p != nil {
+		f.fold = p
+		f.j = 2
+		return rune(p[1]), true
+	}
+	return tables.CaseFold(r), true
+}
+
+// boundary reports whether all the case-folded runes of the consumed
+// portion of the string have been returned.
+func (f *fullFolder) boundary() bool {
+	return f.fold == nil
+}
+
+// A fullFolderRev is like a fullFolder but reads the string backwards.
+type fullFolderRev struct {
+	s    string     // unconsumed portion of the string
+	fold *[4]uint16 // full case-fold of the last rune read from s, if any
+	j    int        // index of the next rune in fold
+}
+
+// prev returns the previous case-folded rune or false if the string is
+// exhausted.
+func (f *fullFolderRev) prev() (rune, bool) {
+	if f.fold != nil {
+		r := rune(f.fold[f.j])
+		f.j--
+		if f.j == 0 {
+			f.fold = nil
+		}
+		return r, true
+	}
+	n := len(f.s) - 1
+	if n < 0 {
+		return 0, false
+	}
+	if c := f.s[n]; c < utf8.RuneSelf {
+		f.s = f.s[:n]
+		return rune(_lower[c]), true
+	}
+	r, size := utf8.DecodeLastRuneInString(f.s)
+	f.s = f.s[:len(f.s)-size]
+	if p := tables.FullCaseFold(r); p != nil {
+		j := len(p) - 1
+		for p[j] == 0 {
+			j--
+		}
+		f.fold = p
+		f.j = j - 1
+		return rune(p[j]), true
+	}
+	return tables.CaseFold(r), true
+}
+
+// indexFullFold returns the index of the first character in s that has a
+// full case-folding that differs from its simple case-folding, or -1 if
+// there is none.
+func indexFullFold(s string) int {
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			o := IndexNonASCII(s[i:])
+			if o < 0 {
+				return -1
+			}
+			i += o
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if tables.FullCaseFold(r) != nil {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// CompareFull is like [Compare] but uses full Unicode case-folding.
+// With full case-folding a character may fold to more than one character,
+// for example "ß" folds to "ss" and "ﬃ" folds to "ffi".
+func CompareFull(s, t string) int {
+	// ASCII fast path
+	i := 0
+	for ; i < len(s) && i < len(t); i++ {
+		sr := s[i]
+		tr := t[i]
+		if (sr|tr)&utf8.RuneSelf != 0 {
+			goto hasUnicode
+		}
+		if sr == tr || _lower[sr] == _lower[tr] {
+			continue
+		}
+		if _lower[sr] < _lower[tr] {
+			return -1
+		}
+		return 1
+	}
+	return clamp(len(s) - len(t))
+
+hasUnicode:
+	sf := fullFolder{s: s[i:]}
+	tf := fullFolder{s: t[i:]}
+	for {
+		sr, sok := sf.next()
+		tr, tok := tf.next()
+		if !sok || !tok {
+			if sok {
+				return 1
+			}
+			if tok {
+				return -1
+			}
+			return 0
+		}
+		if sr != tr {
+			return clamp(int(sr) - int(tr))
+		}
+	}
+}
+
+// EqualFoldFull reports whether s and t, interpreted as UTF-8 strings,
+// are equal under full Unicode case-folding. Unlike [EqualFold], this
+// means that "straße" and "STRASSE" are considered equal.
+func EqualFoldFull(s, t string) bool {
+	return CompareFull(s, t) == 0
+}
+
+// HasPrefixFull tests whether the string s begins with prefix using full
+// Unicode case-folding. It also returns the length in bytes of the prefix
+// in s, which may differ from len(prefix).
+//
+// A match must end on a character boundary of s, that is, "ß" begins with
+// "ss" but not "s".
+func HasPrefixFull(s, prefix string) (bool, int) {
+	sf := fullFolder{s: s}
+	pf := fullFolder{s: prefix}
+	for {
+		pr, ok := pf.next()
+		if !ok {
+			if sf.boundary() {
+				return true, sf.n
+			}
+			return false, 0
+		}
+		sr, ok := sf.next()
+		if !ok || sr != pr {
+			return false, 0
+		}
+	}
+}
+
+// HasSuffixFull tests whether the string s ends with suffix using full
+// Unicode case-folding. It also returns the length in bytes of the suffix
+// in s, which may differ from len(suffix).
+//
+// A match must start on a character boundary of s, that is, "ß" ends with
+// "ss" but not "s".
+func HasSuffixFull(s, suffix string) (bool, int) {
+	sf := fullFolderRev{s: s}
+	tf := fullFolderRev{s: suffix}
+	for {
+		tr, ok := tf.prev()
+		if !ok {
+			if sf.fold == nil {
+				return true, len(s) - len(sf.s)
+			}
+			return false, 0
+		}
+		sr, ok := sf.prev()
+		if !ok || sr != tr {
+			return false, 0
+		}
+	}
+}
+
+// IndexFull returns the index of the first instance of substr in s and the
+// length in bytes of the match, or -1, 0 if substr is not present in s.
+// Full Unicode case-folding is used, so the length of the match may differ
+// from len(substr), for example:
+//
+//	IndexFull("Straße", "SS") // returns 4, 2
+func IndexFull(s, substr string) (int, int) {
+	if len(substr) == 0 {
+		return 0, 0
+	}
+	// Full case-folding only differs from simple case-folding if either
+	// string contains a character with a full case-fold.
+	full := indexFullFold(s)
+	if full == -1 && indexFullFold(substr) == -1 {
+		i, n := indexLen(s, substr)
+		if i < 0 {
+			return -1, 0
+		}
+		return i, n
+	}
+	f := makeFullIndex(s, substr, full)
+	return f.index(0)
+}
+
+// A fullIndex searches s for substr using full case-folding.
+//
+// A match must begin with a character whose full case-fold begins with the
+// first folded rune of substr (r0). Such a character either simple folds to
+// r0, which is found with indexRune, or has a full case-fold. Only these
+// candidates are checked with HasPrefixFull. The offsets of the next
+// candidates of each kind are retained between calls to index so that s is
+// scanned at most once by each search.
+type fullIndex struct {
+	s, substr string
+	r0        rune // first full case-folded rune of substr
+	rune0     int  // index of the next rune in s that matches r0, or -1
+	full      int  // index of the next character in s with a full case-fold, or -1
+}
+
+// makeFullIndex returns a fullIndex for substr, which must not be empty.
+// The index of the first character in s with a full case-fold, full, must
+// have been computed by indexFullFold.
+func makeFullIndex(s, substr string, full int) fullIndex {
+	ff := fullFolder{s: substr}
+	r0, _ := ff.next()
+	rune0, _ := indexRune(s, r0)
+	return fullIndex{s: s, substr: substr, r0: r0, rune0: rune0, full: full}
+}
+
+// index returns the index of the first instance of substr in s at or after
+// offset i and the length in bytes of the match, or -1, 0 if there is none.
+// The offset i must not decrease between calls.
+func (f *fullIndex) index(i int) (int, int) {
+	s := f.s
+	for i < len(s) {
+		if f.rune0 != -1 && f.rune0 < i {
+			if o, _ := indexRune(s[i:], f.r0); o != -1 {
+				f.rune0 = i + o
+			} else {
+				f.rune0 = -1
+			}
+		}
+		if f.full != -1 && f.full < i {
+			if o := indexFullFold(s[i:]); o != -1 {
+				f.full = i + o
+			} else {
+				f.full = -1
+			}
+		}
+		j := f.rune0
+		if f.full != -1 && (j == -1 || f.full < j) {
+			j = f.full
+		}
+		if j == -1 {
+			break
+		}
+		if ok, n := HasPrefixFull(s[j:], f.substr); ok {
+			return j, n
+		}
+		if s[j] < utf8.RuneSelf {
+			i = j + 1
+		} else {
+			_, size := utf8.DecodeRuneInString(s[j:])
+			i = j + size
+		}
+	}
+	return -1, 0
+}
+
+// CountFull counts the number of non-overlapping instances of substr in s
+// using full Unicode case-folding.
+// If substr is an empty string, CountFull returns 1 + the number of Unicode
+// code points in s.
+func CountFull(s, substr string) int {
+	if len(substr) == 0 {
+		return utf8.RuneCountInString(s) + 1
+	}
+	full := indexFullFold(s)
+	if full == -1 && indexFullFold(substr) == -1 {
+		return Count(s, substr)
+	}
+	f := makeFullIndex(s, substr, full)
+	n := 0
+	for i := 0; ; {
+		j, size := f.index(i)
+		if j == -1 {
+			return n
+		}
+		n++
+		i = j + size
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestCompareFull(t *testing.T) {
+	test.CompareFull(t, CompareFull)
+}
+
+func TestEqualFoldFull(t *testing.T) {
+	test.EqualFoldFull(t, EqualFoldFull)
+}
+
+func TestIndexFull(t *testing.T) {
+	test.IndexFull(t, IndexFull)
+}
+
+func TestHasPrefixFull(t *testing.T) {
+	test.HasPrefixFull(t, HasPrefixFull)
+}
+
+func TestHasSuffixFull(t *testing.T) {
+	test.HasSuffixFull(t, HasSuffixFull)
+}
+
+func TestCountFull(t *testing.T) {
+	test.CountFull(t, CountFull)
+}
+
+func TestFullFoldAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		if !EqualFoldFull("straße", "STRASSE") {
+			t.Fatal("EqualFoldFull failed")
+		}
+		if i, n := IndexFull("the oﬃce", "OFFICE"); i != 4 || n != len("oﬃce") {
+			t.Fatalf("IndexFull = %d, %d", i, n)
+		}
+		if n := CountFull("ßß", "ss"); n != 2 {
+			t.Fatalf("CountFull = %d", n)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
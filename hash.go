@@ -0,0 +1,84 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"hash/maphash"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// Hash returns the hash of s with the given seed ignoring case.
+// If EqualFold(s, t) is true then Hash(seed, s) == Hash(seed, t).
+//
+// Hash is equivalent to, but faster than:
+//
+//	var h maphash.Hash
+//	h.SetSeed(seed)
+//	WriteFold(&h, s)
+//	return h.Sum64()
+//
+// Hash does not allocate and is intended for use as the key of a map or to
+// shard case-insensitive data without having to first convert it with
+// [strings.ToLower], which does not agree with simple case-folding for
+// runes such as Kelvin K (U+212A) or 'ſ' (U+017F).
+func Hash(seed maphash.Seed, s string) uint64 {
+	i := indexUnfolded(s)
+	if i == -1 {
+		return maphash.String(seed, s)
+	}
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.WriteString(s[:i])
+	writeFold(&h, s[i:])
+	return h.Sum64()
+}
+
+// WriteFold adds the simple case-fold of s to h. Invalid UTF-8 sequences
+// are written as U+FFFD (just as EqualFold treats them as equal to U+FFFD)
+// so a string should not be split inside of a UTF-8 sequence across calls
+// to WriteFold.
+func WriteFold(h *maphash.Hash, s string) {
+	i := indexUnfolded(s)
+	if i == -1 {
+		h.WriteString(s)
+		return
+	}
+	h.WriteString(s[:i])
+	writeFold(h, s[i:])
+}
+
+// indexUnfolded returns the index of the first byte in s that may change
+// when folded, which is either an upper case ASCII letter or the start of a
+// multi-byte rune, or -1 if s is already folded.
+func indexUnfolded(s string) int {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= utf8.RuneSelf || _lower[c] != c {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeFold(h *maphash.Hash, s string) {
+	var buf [128]byte
+	n := 0
+	for i := 0; i < len(s); {
+		if n > len(buf)-utf8.UTFMax {
+			h.Write(buf[:n])
+			n = 0
+		}
+		if c := s[i]; c < utf8.RuneSelf {
+			buf[n] = _lower[c]
+			n++
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		n += utf8.EncodeRune(buf[n:], tables.CaseFold(r))
+		i += size
+	}
+	h.Write(buf[:n])
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestHash(t *testing.T) {
+	test.Hash(t, Hash)
+}
+
+func TestHashFuzz(t *testing.T) {
+	test.HashFuzz(t, Hash)
+}
+
+func TestWriteFold(t *testing.T) {
+	test.Hash(t, func(seed maphash.Seed, s string) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		WriteFold(&h, s)
+		return h.Sum64()
+	})
+}
+
+// Test that splitting a string at a rune boundary across multiple calls to
+// WriteFold does not change the hash.
+func TestWriteFoldSplit(t *testing.T) {
+	seed := maphash.MakeSeed()
+	s := strings.Repeat("aBc \u212A\u017F αΒγ ", 20)
+	want := Hash(seed, s)
+	for i := range s {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		WriteFold(&h, s[:i])
+		WriteFold(&h, s[i:])
+		if got := h.Sum64(); got != want {
+			t.Errorf("WriteFold(%q) + WriteFold(%q) = %#x; want: %#x", s[:i], s[i:], got, want)
+		}
+	}
+}
+
+func TestHashAllocs(t *testing.T) {
+	seed := maphash.MakeSeed()
+	s := strings.Repeat("Hello, World! \u212A", 20)
+	lower := strings.Repeat("hello, world! k", 20)
+	allocs := testing.AllocsPerRun(100, func() {
+		if Hash(seed, "hello") != Hash(seed, "HELLO") {
+			t.Fatal("Hash failed")
+		}
+		if Hash(seed, s) != Hash(seed, lower) {
+			t.Fatal("Hash failed")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	seed := maphash.MakeSeed()
+	bench := func(b *testing.B, s string) {
+		b.SetBytes(int64(len(s)))
+		for i := 0; i < b.N; i++ {
+			Hash(seed, s)
+		}
+	}
+	b.Run("Lower", func(b *testing.B) {
+		bench(b, "content-type")
+	})
+	b.Run("Mixed", func(b *testing.B) {
+		bench(b, "Content-Type")
+	})
+	b.Run("LongMixed", func(b *testing.B) {
+		bench(b, strings.Repeat("Content-Type", 32))
+	})
+	b.Run("Unicode", func(b *testing.B) {
+		bench(b, "ΑΒΓΔΕ αβγδε")
+	})
+	b.Run("ToLower", func(b *testing.B) {
+		s := "Content-Type"
+		b.SetBytes(int64(len(s)))
+		for i := 0; i < b.N; i++ {
+			maphash.String(seed, strings.ToLower(s))
+		}
+	})
+}
@@ -394,6 +394,19 @@ func BenchmarkIndexTorture(b *testing.B) {
 	benchIndex(b, benchInputTorture, benchNeedleTorture)
 }
 
+// The needle is longer than the input (in bytes), but can match it since
+// Kelvin K is three times the size of ASCII k. This is quadratic for
+// algorithms that compare the needle at every offset of the input.
+func BenchmarkIndexTortureKelvin(b *testing.B) {
+	s := strings.Repeat("k", 3<<10)
+	substr := strings.Repeat("\u212A", 1<<10) + "x"
+	benchIndex(b, s, substr)
+}
+
+func BenchmarkLastIndexTorture(b *testing.B) {
+	benchLastIndex(b, benchInputTorture, benchNeedleTorture)
+}
+
 func BenchmarkCountTorture(b *testing.B) {
 	benchCount(b, benchInputTorture, benchNeedleTorture)
 }
@@ -404,6 +417,27 @@ func BenchmarkCountTortureOverlapping(b *testing.B) {
 	benchCount(b, A, B)
 }
 
+const benchEnglishText = `It was late in the afternoon when they finally reached the
+edge of the town. The road had been long and the weather had not been kind
+to them, but neither of them said anything about it. There was a small inn
+near the bridge where the river turned north, and the owner, who had known
+their father, gave them a room at the back of the house. That evening they
+sat by the fire and talked about the things that they would do in the
+morning, though both of them knew that most of those plans would change
+once they saw what the storm had done to the fields on the other side of
+the hill. `
+
+var benchInputEnglish = strings.Repeat(benchEnglishText, 1<<20/len(benchEnglishText))
+
+// Needles that start with common letters searched for in English text.
+func benchmarkIndexEnglish(b *testing.B, sep string) {
+	benchIndex(b, benchInputEnglish, sep)
+}
+
+func BenchmarkIndexEnglish1(b *testing.B) { benchmarkIndexEnglish(b, "the quick brown fox") }
+func BenchmarkIndexEnglish2(b *testing.B) { benchmarkIndexEnglish(b, "there was a zebra") }
+func BenchmarkIndexEnglish3(b *testing.B) { benchmarkIndexEnglish(b, "then they jumped") }
+
 // NB: we count "a" instead of "=" here, which differs from the stdlib
 // but is a more accurate benchmark since for non-Alpha ASCII chars we
 // use strings.Count.
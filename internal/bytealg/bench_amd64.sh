@@ -0,0 +1,22 @@
+set -e
+
+if [ "$(go env GOARCH)" != amd64 ]; then
+    echo 'error: this is only for amd64'
+    exit 1
+fi
+
+# Compare the GOAMD64=v3 implementations, which do not check for AVX2 at
+# runtime, against the default implementations that do.
+BENCH="${1:-Benchmark(IndexByte|Count)$}"
+COUNT="${COUNT:-10}"
+
+DIR="$(mktemp -d)"
+trap 'rm -r "$DIR"' EXIT
+
+GOAMD64='v1' go test -run '^$' -bench "$BENCH" -count "$COUNT" >"$DIR/v1.txt"
+GOAMD64='v3' go test -run '^$' -bench "$BENCH" -count "$COUNT" >"$DIR/v3.txt"
+if command -v benchstat >/dev/null; then
+    benchstat "$DIR/v1.txt" "$DIR/v3.txt"
+else
+    cat "$DIR/v1.txt" "$DIR/v3.txt"
+fi
@@ -0,0 +1,51 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+package bytealg
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/cpu"
+)
+
+// Offsets into internal/cpu records for use in assembly.
+const (
+	offsetX86HasPOPCNT = unsafe.Offsetof(cpu.X86.HasPOPCNT)
+	offsetX86HasSSE2   = unsafe.Offsetof(cpu.X86.HasSSE2)
+)
+
+// Make golangci-lint think these constants are accessed since it
+// cannot see accesses in assembly.
+const _ = offsetX86HasPOPCNT
+const _ = offsetX86HasSSE2
+
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = indexByteSWAR
+var _ = indexByteStringSWAR
+var _ = countSWAR
+var _ = countStringSWAR
+var _ = indexByteNonASCIISWAR
+var _ = indexNonASCIISWAR
+
+//go:noescape
+func IndexByte(b []byte, c byte) int
+
+//go:noescape
+func IndexByteString(s string, c byte) int
+
+//go:noescape
+func IndexNonASCII(s string) int
+
+//go:noescape
+func IndexByteNonASCII(b []byte) int
+
+//go:noescape
+func Count(b []byte, c byte) int
+
+//go:noescape
+func CountString(s string, c byte) int
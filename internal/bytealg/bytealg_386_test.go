@@ -0,0 +1,29 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+package bytealg
+
+import (
+	"testing"
+
+	"golang.org/x/sys/cpu"
+)
+
+// Test the SWAR fallbacks of the 386 implementation.
+func TestSWARFallback(t *testing.T) {
+	popcnt, sse2 := cpu.X86.HasPOPCNT, cpu.X86.HasSSE2
+	t.Cleanup(func() { cpu.X86.HasPOPCNT, cpu.X86.HasSSE2 = popcnt, sse2 })
+
+	cpu.X86.HasPOPCNT = false
+	cpu.X86.HasSSE2 = false
+	t.Run("IndexByte", TestIndexByte)
+	t.Run("IndexByteString", TestIndexByteString)
+	t.Run("IndexByteLimits", TestIndexByteLimits)
+	t.Run("Count", TestCount)
+	t.Run("CountHard", TestCountHard)
+	t.Run("IndexNonASCII", TestIndexNonASCII)
+	t.Run("IndexByteNonASCII", TestIndexByteNonASCII)
+}
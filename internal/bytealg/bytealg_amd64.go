@@ -1,6 +1,9 @@
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
 
+//go:build !purego
+// +build !purego
+
 package bytealg
 
 import (
@@ -13,12 +16,25 @@ import (
 const (
 	offsetX86HasAVX2   = unsafe.Offsetof(cpu.X86.HasAVX2)
 	offsetX86HasPOPCNT = unsafe.Offsetof(cpu.X86.HasPOPCNT)
+	offsetX86HasSSSE3  = unsafe.Offsetof(cpu.X86.HasSSSE3)
 )
 
 // Make golangci-lint think these constants are accessed since it
 // cannot see accesses in assembly.
 const _ = offsetX86HasAVX2
 const _ = offsetX86HasPOPCNT
+const _ = offsetX86HasSSSE3
+
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = indexTeddyGeneric
+var _ = indexTeddyGenericString
+var _ = indexByteSWAR
+var _ = indexByteStringSWAR
+var _ = countSWAR
+var _ = countStringSWAR
+var _ = indexByteNonASCIISWAR
+var _ = indexNonASCIISWAR
 
 //go:noescape
 func IndexByte(b []byte, c byte) int
@@ -26,6 +42,12 @@ func IndexByte(b []byte, c byte) int
 //go:noescape
 func IndexByteString(s string, c byte) int
 
+//go:noescape
+func LastIndexByte(b []byte, c byte) int
+
+//go:noescape
+func LastIndexByteString(s string, c byte) int
+
 //go:noescape
 func IndexNonASCII(s string) int
 
@@ -37,3 +59,9 @@ func Count(b []byte, c byte) int
 
 //go:noescape
 func CountString(s string, c byte) int
+
+//go:noescape
+func indexTeddy(b []byte, masks *[TeddyLen][2][16]byte) int
+
+//go:noescape
+func indexTeddyString(s string, masks *[TeddyLen][2][16]byte) int
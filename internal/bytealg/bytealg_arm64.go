@@ -1,14 +1,34 @@
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
 
+//go:build !purego
+// +build !purego
+
 package bytealg
 
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = indexTeddyGeneric
+var _ = indexTeddyGenericString
+var _ = indexByteSWAR
+var _ = indexByteStringSWAR
+var _ = countSWAR
+var _ = countStringSWAR
+var _ = indexByteNonASCIISWAR
+var _ = indexNonASCIISWAR
+
 //go:noescape
 func IndexByte(b []byte, c byte) int
 
 //go:noescape
 func IndexByteString(s string, c byte) int
 
+//go:noescape
+func LastIndexByte(b []byte, c byte) int
+
+//go:noescape
+func LastIndexByteString(s string, c byte) int
+
 //go:noescape
 func IndexNonASCII(s string) int
 
@@ -20,3 +40,9 @@ func Count(b []byte, c byte) int
 
 //go:noescape
 func CountString(s string, c byte) int
+
+//go:noescape
+func indexTeddy(b []byte, masks *[TeddyLen][2][16]byte) int
+
+//go:noescape
+func indexTeddyString(s string, masks *[TeddyLen][2][16]byte) int
@@ -0,0 +1,353 @@
+//go:build 386 && !go1.22 && !purego
+// +build 386,!go1.22,!purego
+
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The following code is the SSE2 portion of count_amd64.s ported to 386.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// All CPUs that support POPCNT also support SSE2 so only POPCNT is checked.
+
+TEXT ·Count(SB), NOSPLIT, $0-20
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasPOPCNT(SB), $1
+	JEQ  2(PC)
+	JMP  ·countSWAR(SB)
+
+	MOVL b_base+0(FP), SI
+	MOVL b_len+4(FP), BX
+	MOVB c+12(FP), AL
+	LEAL ret+16(FP), BP
+
+	LEAL -65(AX), CX // Check if the byte is a ASCII letter
+	CMPB CL, $25
+	JLS  count_case  // Byte sought is a ASCII letter
+	ADDL $-97, AX
+	CMPB AL, $25
+	JHI  count
+
+count_case:
+	MOVB c+12(FP), AL
+	JMP  countbodyCase<>(SB)
+
+count:
+	MOVB c+12(FP), AL
+	JMP  countbody<>(SB)
+
+TEXT ·CountString(SB), NOSPLIT, $0-16
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasPOPCNT(SB), $1
+	JEQ  2(PC)
+	JMP  ·countStringSWAR(SB)
+
+	MOVL s_base+0(FP), SI
+	MOVL s_len+4(FP), BX
+	MOVB c+8(FP), AL
+	LEAL ret+12(FP), BP
+
+	LEAL -65(AX), CX // Check if the byte is a ASCII letter
+	CMPB CL, $25
+	JLS  count_case  // Byte sought is a ASCII letter
+	ADDL $-97, AX
+	CMPB AL, $25
+	JHI  count
+
+count_case:
+	MOVB c+8(FP), AL
+	JMP  countbodyCase<>(SB)
+
+count:
+	MOVB c+8(FP), AL
+	JMP  countbody<>(SB)
+
+// input:
+//   SI: data
+//   BX: data len
+//   AL: byte sought
+//   BP: address to put result
+// This function requires the POPCNT instruction.
+TEXT countbodyCase<>(SB), NOSPLIT, $0
+	// Shuffle X0 around so that each byte contains
+	// the character we're looking for.
+	ORL       $32, AX    // Convert byte to lowercase
+	MOVL      AX, X0
+	PUNPCKLBW X0, X0
+	PUNPCKLBW X0, X0
+	PSHUFL    $0, X0, X0
+
+	// Add space (' ') mask to X2
+	MOVL   $0x20202020, CX
+	MOVL   CX, X2
+	PSHUFL $0, X2, X2
+
+	CMPL BX, $16
+	JLT  small
+
+	MOVL $0, DI // Accumulator
+
+	LEAL -16(SI)(BX*1), AX // AX = address of last 16 bytes
+	JMP  sseloopentry
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (SI), X1
+
+	// Logical OR to convert data to lowercase
+	POR X2, X1
+
+	// Compare bytes in X0 to X1.
+	PCMPEQB X0, X1
+
+	// Take the top bit of each byte in X1 and put the result in DX.
+	PMOVMSKB X1, DX
+
+	// Count number of matching bytes
+	POPCNTL DX, DX
+
+	// Accumulate into DI
+	ADDL DX, DI
+
+	// Advance to next block.
+	ADDL $16, SI
+
+sseloopentry:
+	CMPL SI, AX
+	JBE  sseloop
+
+	// Get the number of bytes to consider in the last 16 bytes
+	ANDL $15, BX
+	JZ   end
+
+	// Create mask to ignore overlap between previous 16 byte block
+	// and the next.
+	MOVL $16, CX
+	SUBL BX, CX
+	MOVL $0xFFFF, BX
+	SARL CL, BX
+	SALL CL, BX
+
+	// Process the last 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched so we need to mask part of it.
+	MOVOU    (AX), X1
+	POR      X2, X1   // Convert data to lowercase
+	PCMPEQB  X0, X1
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL    BX, DX
+	POPCNTL DX, DX
+	ADDL    DX, DI
+
+end:
+	MOVL DI, (BP)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTL BX, BX
+	JEQ   endzero
+
+	// Check if we'll load across a page boundary.
+	LEAL  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	// We must ignore high bytes as they aren't part of our slice.
+	// Create mask.
+	MOVL BX, CX
+	MOVL $1, DI
+	SALL CL, DI
+	SUBL $1, DI
+
+	// Load data
+	MOVOU (SI), X1
+
+	// Convert data to lowercase
+	POR X2, X1
+
+	// Compare target byte with each byte in data.
+	PCMPEQB X0, X1
+
+	// Move result bits to integer register.
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL    DI, DX
+	POPCNTL DX, DX
+
+	// Directly return DX, we don't need to accumulate
+	// since we have <16 bytes.
+	MOVL DX, (BP)
+	RET
+
+endzero:
+	MOVL $0, (BP)
+	RET
+
+endofpage:
+	// We must ignore low bytes as they aren't part of our slice.
+	MOVL $16, CX
+	SUBL BX, CX
+	MOVL $0xFFFF, DI
+	SARL CL, DI
+	SALL CL, DI
+
+	// Load data into the high end of X1.
+	MOVOU -16(SI)(BX*1), X1
+
+	// Convert data to lowercase
+	POR X2, X1
+
+	// Compare target byte with each byte in data.
+	PCMPEQB X0, X1
+
+	// Move result bits to integer register.
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL DI, DX
+
+	// Directly return DX, we don't need to accumulate
+	// since we have <16 bytes.
+	POPCNTL DX, DX
+	MOVL    DX, (BP)
+	RET
+
+// input:
+//   SI: data
+//   BX: data len
+//   AL: byte sought
+//   BP: address to put result
+// This function requires the POPCNT instruction.
+TEXT countbody<>(SB), NOSPLIT, $0
+	// Shuffle X0 around so that each byte contains
+	// the character we're looking for.
+	MOVL      AX, X0
+	PUNPCKLBW X0, X0
+	PUNPCKLBW X0, X0
+	PSHUFL    $0, X0, X0
+
+	CMPL BX, $16
+	JLT  small
+
+	MOVL $0, DI // Accumulator
+
+	LEAL -16(SI)(BX*1), AX // AX = address of last 16 bytes
+	JMP  sseloopentry
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (SI), X1
+
+	// Compare bytes in X0 to X1.
+	PCMPEQB X0, X1
+
+	// Take the top bit of each byte in X1 and put the result in DX.
+	PMOVMSKB X1, DX
+
+	// Count number of matching bytes
+	POPCNTL DX, DX
+
+	// Accumulate into DI
+	ADDL DX, DI
+
+	// Advance to next block.
+	ADDL $16, SI
+
+sseloopentry:
+	CMPL SI, AX
+	JBE  sseloop
+
+	// Get the number of bytes to consider in the last 16 bytes
+	ANDL $15, BX
+	JZ   end
+
+	// Create mask to ignore overlap between previous 16 byte block
+	// and the next.
+	MOVL $16, CX
+	SUBL BX, CX
+	MOVL $0xFFFF, BX
+	SARL CL, BX
+	SALL CL, BX
+
+	// Process the last 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched so we need to mask part of it.
+	MOVOU    (AX), X1
+	PCMPEQB  X0, X1
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL    BX, DX
+	POPCNTL DX, DX
+	ADDL    DX, DI
+
+end:
+	MOVL DI, (BP)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTL BX, BX
+	JEQ   endzero
+
+	// Check if we'll load across a page boundary.
+	LEAL  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	// We must ignore high bytes as they aren't part of our slice.
+	// Create mask.
+	MOVL BX, CX
+	MOVL $1, DI
+	SALL CL, DI
+	SUBL $1, DI
+
+	// Load data
+	MOVOU (SI), X1
+
+	// Compare target byte with each byte in data.
+	PCMPEQB X0, X1
+
+	// Move result bits to integer register.
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL    DI, DX
+	POPCNTL DX, DX
+
+	// Directly return DX, we don't need to accumulate
+	// since we have <16 bytes.
+	MOVL DX, (BP)
+	RET
+
+endzero:
+	MOVL $0, (BP)
+	RET
+
+endofpage:
+	// We must ignore low bytes as they aren't part of our slice.
+	MOVL $16, CX
+	SUBL BX, CX
+	MOVL $0xFFFF, DI
+	SARL CL, DI
+	SALL CL, DI
+
+	// Load data into the high end of X1.
+	MOVOU -16(SI)(BX*1), X1
+
+	// Compare target byte with each byte in data.
+	PCMPEQB X0, X1
+
+	// Move result bits to integer register.
+	PMOVMSKB X1, DX
+
+	// Apply mask
+	ANDL DI, DX
+
+	// Directly return DX, we don't need to accumulate
+	// since we have <16 bytes.
+	POPCNTL DX, DX
+	MOVL    DX, (BP)
+	RET
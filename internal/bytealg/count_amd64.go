@@ -1,8 +1,8 @@
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
 
-//go:build amd64
-// +build amd64
+//go:build amd64 && !purego
+// +build amd64,!purego
 
 package bytealg
 
@@ -1,5 +1,5 @@
-//go:build amd64 && !go1.22
-// +build amd64,!go1.22
+//go:build amd64 && !go1.22 && !purego
+// +build amd64,!go1.22,!purego
 
 // Copyright 2018 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
@@ -231,10 +231,12 @@ avx2:
 	JNE  sse
 
 #endif
+	// Move the byte sought into X0 before using any Y registers to avoid
+	// the penalty of mixing SSE and AVX instructions.
+	MOVD         AX, X0
+
 	// Create a mask in Y6 that converts text to upper case.
 	VPBROADCASTB X2, Y6
-
-	MOVD         AX, X0
 	LEAQ         -64(SI)(BX*1), R11
 	LEAQ         (SI)(BX*1), R13
 	VPBROADCASTB X0, Y1
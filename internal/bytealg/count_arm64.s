@@ -1,5 +1,5 @@
-//go:build arm64 && !go1.22
-// +build arm64,!go1.22
+//go:build arm64 && !go1.22 && !purego
+// +build arm64,!go1.22,!purego
 
 // Copyright 2018 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
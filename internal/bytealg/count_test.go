@@ -1,8 +1,10 @@
 package bytealg
 
 import (
+	"math/rand"
 	"strings"
 	"testing"
+	"time"
 )
 
 var CountTests = []struct {
@@ -17,23 +19,76 @@ var CountTests = []struct {
 	{"ABAB", 'a', 2},
 	{strings.Repeat("AB", 256), 'a', 256},
 	{strings.Repeat("ab", 256), 'A', 256},
+	{strings.Repeat("a", 4096), 'A', 4096},
+	{strings.Repeat("1", 4095), '1', 4095},
 }
 
-func TestCount(t *testing.T) {
+func testCount(t *testing.T, name string, fn func(s string, c byte) int) {
 	for _, tt := range CountTests {
-		if num := CountString(tt.s, tt.sep); num != tt.num {
-			t.Errorf("Count(%q, %q) = %d, want %d", tt.s, tt.sep, num, tt.num)
+		if num := fn(tt.s, tt.sep); num != tt.num {
+			t.Errorf("%s(%q, %q) = %d, want %d", name, tt.s, tt.sep, num, tt.num)
 		}
 	}
 }
 
-func TestCountHard(t *testing.T) {
+func TestCount(t *testing.T) {
+	testCount(t, "Count", CountString)
+}
+
+func TestCountSWAR(t *testing.T) {
+	testCount(t, "countSWAR", func(s string, c byte) int {
+		return countSWAR([]byte(s), c)
+	})
+	testCount(t, "countStringSWAR", countStringSWAR)
+}
+
+func TestCountRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	count := func(s string, c byte) int {
+		n := 0
+		for i := 0; i < len(s); i++ {
+			if s[i] == c || isAlphaPortable(c) && s[i]|' ' == c|' ' {
+				n++
+			}
+		}
+		return n
+	}
+	// Use a small alphabet so that there are many matches.
+	const alphabet = "aAbB1\x00\x80\xe1"
+	b := make([]byte, 300)
+	for i := range b {
+		b[i] = alphabet[rr.Intn(len(alphabet))]
+	}
+	s := string(b)
+	fails := 0
+	for n := 0; n <= len(s); n++ {
+		// Test all offsets to exercise the alignment of the final block
+		for j := 0; j+n <= len(s) && j < 40; j++ {
+			c := alphabet[rr.Intn(len(alphabet))]
+			want := count(s[j:j+n], c)
+			if got := CountString(s[j:j+n], c); got != want {
+				fails++
+				if fails <= 20 {
+					t.Errorf("CountString(s[%d:%d], %q) = %d; want: %d", j, j+n, c, got, want)
+				}
+			}
+			if got := Count(b[j:j+n], c); got != want {
+				fails++
+				if fails <= 20 {
+					t.Errorf("Count(b[%d:%d], %q) = %d; want: %d", j, j+n, c, got, want)
+				}
+			}
+		}
+	}
+}
+
+func testCountHard(t *testing.T, fn func(s string, c byte) int) {
 	s := strings.Repeat("AB", 32*1024)
 	lower := strings.Repeat("ab", 32*1024)
 	n := 0
 	for i := 0; i < len(s); i += 7 {
 		want := strings.Count(lower[:i], "a")
-		got := CountString(s[:i], 'a')
+		got := fn(s[:i], 'a')
 		if got != want {
 			if n < 30 {
 				t.Errorf("%d: want: %d got: %d", i, want, got)
@@ -45,3 +100,28 @@ func TestCountHard(t *testing.T) {
 		t.Errorf("Failed %d/%d tests", n, len(s))
 	}
 }
+
+func TestCountHard(t *testing.T) {
+	testCountHard(t, CountString)
+}
+
+func TestCountHardSWAR(t *testing.T) {
+	testCountHard(t, countStringSWAR)
+}
+
+func BenchmarkCount(b *testing.B) {
+	benchBytes(b, indexSizes, bmCount(Count))
+}
+
+func BenchmarkCountSWAR(b *testing.B) {
+	benchBytes(b, indexSizes, bmCount(countSWAR))
+}
+
+func bmCount(count func([]byte, byte) int) func(b *testing.B, n int) {
+	return func(b *testing.B, n int) {
+		buf := bmbuf[0:n]
+		for i := 0; i < b.N; i++ {
+			_ = count(buf, 'X')
+		}
+	}
+}
@@ -0,0 +1,170 @@
+//go:build amd64.v3 && go1.22 && !purego
+// +build amd64.v3,go1.22,!purego
+
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+// Count for GOAMD64=v3 and above where AVX2 and POPCNT are always
+// available so there is no runtime dispatch and no SSE loop. Like
+// indexbyte_v3_amd64.s the case-insensitive and case-sensitive counts
+// share the same code.
+//
+// Older toolchains use count_amd64.s, which includes asm_amd64.h so its
+// AVX2 and POPCNT checks are also compiled out when GOAMD64=v3.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// SETUP broadcasts the byte sought in AX to Y0 and the case mask to Y1
+// and converts the byte sought to lower case if it is an ASCII letter.
+// AX is an ASCII letter if (AX-'A')&^0x20 < 26.
+#define SETUP \
+	LEAL         -65(AX), CX \
+	ANDL         $~32, CX    \
+	CMPL         CX, $26     \
+	SBBL         DX, DX      \
+	ANDL         $32, DX     \
+	ORL          DX, AX      \
+	VMOVD        AX, X0      \
+	VPBROADCASTB X0, Y0      \
+	VMOVD        DX, X1      \
+	VPBROADCASTB X1, Y1
+
+// COUNT is the body of Count and CountString.
+//
+// input:
+//   SI: data
+//   BX: data len
+//   AX: byte sought
+//   R8: address to put result
+#define COUNT \
+	SETUP                                                    \
+	CMPQ      BX, $32                                        \
+	JB        small                                          \
+	XORQ      R12, R12 /* Accumulator */                     \
+	MOVQ      SI, DI                                         \
+	LEAQ      (SI)(BX*1), R13 /* end of data */              \
+	LEAQ      -64(R13), R11 /* address of last 64 bytes */   \
+	CMPQ      BX, $64                                        \
+	JB        tail                                           \
+	PCALIGN   $32                                            \
+loop:                                                        \
+	VMOVDQU   (DI), Y2                                       \
+	VMOVDQU   32(DI), Y3                                     \
+	VPOR      Y1, Y2, Y2                                     \
+	VPOR      Y1, Y3, Y3                                     \
+	VPCMPEQB  Y0, Y2, Y2                                     \
+	VPCMPEQB  Y0, Y3, Y3                                     \
+	VPMOVMSKB Y2, DX                                         \
+	VPMOVMSKB Y3, CX                                         \
+	SHLQ      $32, CX                                        \
+	ORQ       CX, DX                                         \
+	POPCNTQ   DX, DX                                         \
+	ADDQ      DX, R12                                        \
+	ADDQ      $64, DI                                        \
+	CMPQ      DI, R11                                        \
+	JBE       loop                                           \
+tail:                                                        \
+	/* Count the remaining 0-63 bytes. */                   \
+	MOVQ      R13, CX                                        \
+	SUBQ      DI, CX                                         \
+	JZ        done                                           \
+	CMPQ      CX, $32                                        \
+	JB        last                                           \
+	VMOVDQU   (DI), Y2                                       \
+	VPOR      Y1, Y2, Y2                                     \
+	VPCMPEQB  Y0, Y2, Y2                                     \
+	VPMOVMSKB Y2, DX                                         \
+	POPCNTL   DX, DX                                         \
+	ADDQ      DX, R12                                        \
+	SUBQ      $32, CX                                        \
+	JZ        done                                           \
+last:                                                        \
+	/* Count the last 32 bytes ignoring the 32-CX bytes */  \
+	/* that overlap with the bytes already counted. */      \
+	VMOVDQU   -32(R13), Y2                                   \
+	VPOR      Y1, Y2, Y2                                     \
+	VPCMPEQB  Y0, Y2, Y2                                     \
+	VPMOVMSKB Y2, DX                                         \
+	NEGQ      CX                                             \
+	ADDQ      $32, CX                                        \
+	SHRL      CX, DX                                         \
+	POPCNTL   DX, DX                                         \
+	ADDQ      DX, R12                                        \
+done:                                                        \
+	VZEROUPPER                                               \
+	MOVQ      R12, (R8)                                      \
+	RET                                                      \
+small:                                                       \
+	CMPQ      BX, $16                                        \
+	JB        tiny                                           \
+	/* Count the first and last 16 bytes ignoring the */    \
+	/* 32-BX bytes of the last 16 that overlap. */          \
+	VMOVDQU   (SI), X2                                       \
+	VMOVDQU   -16(SI)(BX*1), X3                              \
+	VPOR      X1, X2, X2                                     \
+	VPOR      X1, X3, X3                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPCMPEQB  X0, X3, X3                                     \
+	VPMOVMSKB X2, DX                                         \
+	VPMOVMSKB X3, AX                                         \
+	VZEROUPPER                                               \
+	MOVL      $32, CX                                        \
+	SUBL      BX, CX                                         \
+	SHRL      CX, AX                                         \
+	POPCNTL   DX, DX                                         \
+	POPCNTL   AX, AX                                         \
+	ADDQ      AX, DX                                         \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+tiny:                                                        \
+	TESTQ     BX, BX                                         \
+	JEQ       tinyzero                                       \
+	/* Check if we'll load across a page boundary. */       \
+	LEAQ      16(SI), CX                                     \
+	TESTW     $0xff0, CX                                     \
+	JEQ       endofpage                                      \
+	VMOVDQU   (SI), X2                                       \
+	VPOR      X1, X2, X2                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPMOVMSKB X2, DX                                         \
+	VZEROUPPER                                               \
+	/* Ignore the high 16-BX bytes past the end of data. */ \
+	MOVL      $32, CX                                        \
+	SUBL      BX, CX                                         \
+	SHLL      CX, DX                                         \
+	POPCNTL   DX, DX                                         \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+endofpage:                                                   \
+	/* Load data into the high end of X2 and ignore the */  \
+	/* low 16-BX bytes before the start of data. */         \
+	VMOVDQU   -16(SI)(BX*1), X2                              \
+	VPOR      X1, X2, X2                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPMOVMSKB X2, DX                                         \
+	VZEROUPPER                                               \
+	MOVL      $16, CX                                        \
+	SUBL      BX, CX                                         \
+	SHRL      CX, DX                                         \
+	POPCNTL   DX, DX                                         \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+tinyzero:                                                    \
+	VZEROUPPER                                               \
+	MOVQ      $0, (R8)                                       \
+	RET
+
+TEXT ·Count(SB), NOSPLIT, $0-40
+	MOVQ    b_base+0(FP), SI
+	MOVQ    b_len+8(FP), BX
+	MOVBLZX c+24(FP), AX
+	LEAQ    ret+32(FP), R8
+	COUNT
+
+TEXT ·CountString(SB), NOSPLIT, $0-32
+	MOVQ    s_base+0(FP), SI
+	MOVQ    s_len+8(FP), BX
+	MOVBLZX c+16(FP), AX
+	LEAQ    ret+24(FP), R8
+	COUNT
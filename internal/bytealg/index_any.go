@@ -0,0 +1,61 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+// The IndexAnyASCII and LastIndexAnyASCII functions return the index of the
+// first (last) byte of s that is in set, or -1 if there is none.
+//
+// The set is a table indexed by the low nibble of a byte where bit i of
+// set[c&0xf] is set if the byte with the high nibble i is in the set, so
+// only ASCII bytes may be in the set. The assembly implementations look up
+// the low nibble in set and the high nibble in a constant table mapping i
+// to 1<<i with a single shuffle instruction for 16 or 32 bytes at a time.
+
+// anySetContains reports whether c is in set.
+func anySetContains(set *[16]byte, c byte) bool {
+	return set[c&0xf]&(1<<(c>>4)) != 0
+}
+
+// indexAnyASCIIGeneric is the generic implementation of IndexAnyASCII.
+func indexAnyASCIIGeneric(b []byte, set *[16]byte) int {
+	for i := 0; i < len(b); i++ {
+		if anySetContains(set, b[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexAnyASCIIGenericString is the generic implementation of
+// IndexAnyASCIIString.
+func indexAnyASCIIGenericString(s string, set *[16]byte) int {
+	for i := 0; i < len(s); i++ {
+		if anySetContains(set, s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastIndexAnyASCIIGeneric is the generic implementation of
+// LastIndexAnyASCII.
+func lastIndexAnyASCIIGeneric(b []byte, set *[16]byte) int {
+	for i := len(b) - 1; i >= 0; i-- {
+		if anySetContains(set, b[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastIndexAnyASCIIGenericString is the generic implementation of
+// LastIndexAnyASCIIString.
+func lastIndexAnyASCIIGenericString(s string, set *[16]byte) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if anySetContains(set, s[i]) {
+			return i
+		}
+	}
+	return -1
+}
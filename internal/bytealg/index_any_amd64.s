@@ -0,0 +1,293 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "go_asm.h"
+#include "asm_amd64.h"
+#include "textflag.h"
+
+// nibbleBits maps the high nibble i of an ASCII byte to 1<<i and the high
+// nibble of all other bytes to 0.
+DATA nibbleBits<>+0x00(SB)/8, $0x8040201008040201
+DATA nibbleBits<>+0x08(SB)/8, $0x0000000000000000
+GLOBL nibbleBits<>(SB), (NOPTR+RODATA), $16
+
+// ANY_SSE sets the bits of DX that correspond to the 16 bytes at offset CX
+// that are in the set. It sets the zero flag if there are no such bytes.
+#define ANY_SSE \
+	MOVOU    (SI)(CX*1), X0 \
+	MOVOU    X0, X1         \
+	PSRLW    $4, X1         \
+	PAND     X15, X0        \
+	PAND     X15, X1        \
+	MOVOU    X8, X2         \
+	PSHUFB   X0, X2         \
+	MOVOU    X9, X3         \
+	PSHUFB   X1, X3         \
+	PAND     X3, X2         \
+	PCMPEQB  X14, X2        \
+	PMOVMSKB X2, DX         \
+	XORL     $0xffff, DX
+
+// ANY_AVX2 is like ANY_SSE but checks 32 bytes.
+#define ANY_AVX2 \
+	VMOVDQU   (SI)(CX*1), Y0 \
+	VPSRLW    $4, Y0, Y1     \
+	VPAND     Y15, Y0, Y0    \
+	VPAND     Y15, Y1, Y1    \
+	VPSHUFB   Y0, Y8, Y2     \
+	VPSHUFB   Y1, Y9, Y3     \
+	VPAND     Y3, Y2, Y2     \
+	VPCMPEQB  Y14, Y2, Y2    \
+	VPMOVMSKB Y2, DX         \
+	NOTL      DX             \
+	TESTL     DX, DX
+
+// SETUP loads the nibble tables into X8 (low) and X9 (high) and sets
+// X15 to 0x0f in each byte and X14 to 0.
+#define SETUP \
+	MOVOU      (R9), X8              \
+	MOVOU      nibbleBits<>(SB), X9  \
+	MOVQ       $0x0f0f0f0f0f0f0f0f, AX \
+	MOVQ       AX, X15               \
+	PUNPCKLQDQ X15, X15              \
+	PXOR       X14, X14
+
+// SETUP_AVX2 copies the registers loaded by SETUP into both lanes of the
+// Y registers.
+#define SETUP_AVX2 \
+	VBROADCASTI128 (R9), Y8             \
+	VBROADCASTI128 nibbleBits<>(SB), Y9 \
+	VPBROADCASTB   X15, Y15             \
+	VPXOR          Y14, Y14, Y14
+
+// SMALL sets the carry flag if the byte at offset CX is in the set.
+#define SMALL \
+	MOVBLZX (SI)(CX*1), AX \
+	MOVL    AX, DX         \
+	ANDL    $0x0f, DX      \
+	MOVBLZX (R9)(DX*1), DX \
+	SHRL    $4, AX         \
+	BTL     AX, DX
+
+// func IndexAnyASCII(b []byte, set *[16]byte) int
+TEXT ·IndexAnyASCII(SB), NOSPLIT, $0-40
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexAnyASCIIGeneric(SB)
+
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), BX
+	MOVQ set+24(FP), R9
+	LEAQ ret+32(FP), R8
+	JMP  indexAnyBody<>(SB)
+
+// func IndexAnyASCIIString(s string, set *[16]byte) int
+TEXT ·IndexAnyASCIIString(SB), NOSPLIT, $0-32
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexAnyASCIIGenericString(SB)
+
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), BX
+	MOVQ set+16(FP), R9
+	LEAQ ret+24(FP), R8
+	JMP  indexAnyBody<>(SB)
+
+// input:
+//   SI: data
+//   BX: data len
+//   R9: address of the set
+//   R8: address to put result
+TEXT indexAnyBody<>(SB), NOSPLIT, $0
+	XORQ CX, CX
+	CMPQ BX, $16
+	JB   small
+
+	SETUP
+
+	CMPQ BX, $32
+	JA   avx2
+
+sse:
+	LEAQ -16(BX), R10 // R10 = offset of the last 16 bytes
+
+sseloop:
+	CMPQ   CX, R10
+	JAE    sselast
+	ANY_SSE
+	JNZ    found
+	ADDQ   $16, CX
+	JMP    sseloop
+
+sselast:
+	// Search the last 16 bytes. This block may overlap with the bytes
+	// we've already searched, but that's ok.
+	MOVQ    R10, CX
+	ANY_SSE
+	JNZ     found
+
+failure:
+	MOVQ $-1, (R8)
+	RET
+
+// The block was loaded from offset CX and the bits of DX are the bytes
+// that are in the set.
+found:
+	BSFL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, (R8)
+	RET
+
+// handle lengths < 16 a byte at a time
+small:
+	CMPQ  CX, BX
+	JAE   failure
+	SMALL
+	JCS   smallfound
+	INCQ  CX
+	JMP   small
+
+smallfound:
+	MOVQ CX, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	SETUP_AVX2
+	LEAQ -32(BX), R10 // R10 = offset of the last 32 bytes
+
+avx2loop:
+	CMPQ     CX, R10
+	JAE      avx2last
+	ANY_AVX2
+	JNZ      avx2found
+	ADDQ     $32, CX
+	JMP      avx2loop
+
+avx2last:
+	// Search the last (possibly overlapping) 32 bytes.
+	MOVQ       R10, CX
+	ANY_AVX2
+	JNZ        avx2found
+	VZEROUPPER
+	JMP        failure
+
+avx2found:
+	VZEROUPPER
+	JMP found
+
+// func LastIndexAnyASCII(b []byte, set *[16]byte) int
+TEXT ·LastIndexAnyASCII(SB), NOSPLIT, $0-40
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·lastIndexAnyASCIIGeneric(SB)
+
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), BX
+	MOVQ set+24(FP), R9
+	LEAQ ret+32(FP), R8
+	JMP  lastIndexAnyBody<>(SB)
+
+// func LastIndexAnyASCIIString(s string, set *[16]byte) int
+TEXT ·LastIndexAnyASCIIString(SB), NOSPLIT, $0-32
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·lastIndexAnyASCIIGenericString(SB)
+
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), BX
+	MOVQ set+16(FP), R9
+	LEAQ ret+24(FP), R8
+	JMP  lastIndexAnyBody<>(SB)
+
+// lastIndexAnyBody is the reverse of indexAnyBody.
+//
+// input:
+//   SI: data
+//   BX: data len
+//   R9: address of the set
+//   R8: address to put result
+TEXT lastIndexAnyBody<>(SB), NOSPLIT, $0
+	CMPQ BX, $16
+	JB   small
+
+	SETUP
+
+	CMPQ BX, $32
+	JA   avx2
+
+sse:
+	LEAQ -16(BX), CX // CX = offset of the last 16 bytes
+
+sseloop:
+	ANY_SSE
+	JNZ   found
+	TESTQ CX, CX
+	JZ    failure
+	SUBQ  $16, CX
+	JCC   sseloop
+
+	// Search the first 16 bytes. This block may overlap with the bytes
+	// we've already searched, but that's ok.
+	XORQ CX, CX
+	JMP  sseloop
+
+// The block was loaded from offset CX and the bits of DX are the bytes
+// that are in the set.
+found:
+	BSRL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, (R8)
+	RET
+
+failure:
+	MOVQ $-1, (R8)
+	RET
+
+// handle lengths < 16 a byte at a time
+small:
+	MOVQ BX, CX
+
+smallloop:
+	DECQ  CX
+	JS    failure
+	SMALL
+	JCC   smallloop
+	MOVQ  CX, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	SETUP_AVX2
+	LEAQ -32(BX), CX // CX = offset of the last 32 bytes
+
+avx2loop:
+	ANY_AVX2
+	JNZ   avx2found
+	TESTQ CX, CX
+	JZ    avx2failure
+	SUBQ  $32, CX
+	JCC   avx2loop
+
+	// Search the first (possibly overlapping) 32 bytes.
+	XORQ CX, CX
+	JMP  avx2loop
+
+avx2found:
+	VZEROUPPER
+	JMP found
+
+avx2failure:
+	VZEROUPPER
+	JMP failure
@@ -0,0 +1,24 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+package bytealg
+
+import (
+	"testing"
+
+	"golang.org/x/sys/cpu"
+)
+
+// Test the SSE and generic fallbacks of the amd64 implementation.
+func TestIndexAnyASCIIFallback(t *testing.T) {
+	avx2, ssse3 := cpu.X86.HasAVX2, cpu.X86.HasSSSE3
+	t.Cleanup(func() { cpu.X86.HasAVX2, cpu.X86.HasSSSE3 = avx2, ssse3 })
+
+	cpu.X86.HasAVX2 = false
+	t.Run("SSE", TestIndexAnyASCIIRandom)
+	cpu.X86.HasSSSE3 = false
+	t.Run("Generic", TestIndexAnyASCIIRandom)
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// ANY sets each byte of V0 (V1) to 0xff if the corresponding byte of the 32
+// bytes loaded into V0 and V1 is in the set and sets R6 to a non-zero value
+// if there are any such bytes.
+#define ANY \
+	VUSHR  $4, V0.B16, V2.B16         \
+	VAND   V15.B16, V0.B16, V0.B16    \
+	VTBL   V0.B16, [V8.B16], V0.B16   \
+	VTBL   V2.B16, [V9.B16], V2.B16   \
+	VAND   V2.B16, V0.B16, V0.B16     \
+	VCMTST V0.B16, V0.B16, V0.B16     \
+	VUSHR  $4, V1.B16, V3.B16         \
+	VAND   V15.B16, V1.B16, V1.B16    \
+	VTBL   V1.B16, [V8.B16], V1.B16   \
+	VTBL   V3.B16, [V9.B16], V3.B16   \
+	VAND   V3.B16, V1.B16, V1.B16     \
+	VCMTST V1.B16, V1.B16, V1.B16     \
+	VORR   V0.B16, V1.B16, V4.B16     \
+	VADDP  V4.D2, V4.D2, V4.D2        \
+	VMOV   V4.D[0], R6
+
+// SYNDROME sets R6 to the syndrome of V0 and V1. Bit 2*i of R6 is set if
+// byte i of the block is in the set.
+#define SYNDROME \
+	VAND  V5.B16, V0.B16, V0.B16 \
+	VAND  V5.B16, V1.B16, V1.B16 \
+	VADDP V1.B16, V0.B16, V6.B16 \
+	VADDP V6.B16, V6.B16, V6.B16 \
+	VMOV  V6.D[0], R6
+
+// SETUP loads the low nibble table into V8, the high nibble table (which
+// maps the high nibble i of ASCII bytes to 1<<i) into V9 and sets V15 to
+// 0x0f in each byte.
+#define SETUP \
+	VLD1 (R2), [V8.B16]                \
+	MOVD $0x8040201008040201, R4       \
+	VEOR V9.B16, V9.B16, V9.B16        \
+	VMOV R4, V9.D[0]                   \
+	MOVD $0x0f, R4                     \
+	VDUP R4, V15.B16                   \
+	MOVD $0x40100401, R4               \
+	VMOV R4, V5.S4
+
+// SMALL sets bit 0 of R5 if the byte at offset R3 is in the set.
+#define SMALL \
+	MOVBU (R0)(R3), R4 \
+	AND   $0x0f, R4, R5 \
+	MOVBU (R2)(R5), R5 \
+	LSR   $4, R4, R4    \
+	LSR   R4, R5, R5
+
+// func IndexAnyASCII(b []byte, set *[16]byte) int
+TEXT ·IndexAnyASCII(SB), NOSPLIT, $0-40
+	MOVD b_base+0(FP), R0
+	MOVD b_len+8(FP), R1
+	MOVD set+24(FP), R2
+	MOVD $ret+32(FP), R8
+	B    indexAnyBody<>(SB)
+
+// func IndexAnyASCIIString(s string, set *[16]byte) int
+TEXT ·IndexAnyASCIIString(SB), NOSPLIT, $0-32
+	MOVD s_base+0(FP), R0
+	MOVD s_len+8(FP), R1
+	MOVD set+16(FP), R2
+	MOVD $ret+24(FP), R8
+	B    indexAnyBody<>(SB)
+
+// input:
+//   R0: data
+//   R1: data len
+//   R2: address of the set
+//   R8: address to put result
+TEXT indexAnyBody<>(SB), NOSPLIT, $0
+	MOVD $0, R3
+	CMP  $32, R1
+	BLO  small
+
+	SETUP
+
+	// R9 = offset of the last 32 bytes
+	SUB $32, R1, R9
+
+loop:
+	CMP  R9, R3
+	BHS  last
+	ADD  R0, R3, R4
+	VLD1 (R4), [V0.B16, V1.B16]
+	ANY
+	CBNZ R6, found
+	ADD  $32, R3, R3
+	B    loop
+
+last:
+	// Search the last 32 bytes. This block may overlap with the bytes
+	// we've already searched, but that's ok.
+	MOVD R9, R3
+	ADD  R0, R3, R4
+	VLD1 (R4), [V0.B16, V1.B16]
+	ANY
+	CBNZ R6, found
+
+fail:
+	MOVD $-1, R3
+	MOVD R3, (R8)
+	RET
+
+// The block was loaded from offset R3.
+found:
+	SYNDROME
+
+	// Count the trailing zeros using bit reversing
+	RBIT R6, R6
+	CLZ  R6, R6
+
+	// R6 is twice the offset into the block
+	ADD  R6>>1, R3, R3
+	MOVD R3, (R8)
+	RET
+
+// handle lengths < 32 a byte at a time
+small:
+	CMP  R1, R3
+	BHS  fail
+	SMALL
+	TBNZ $0, R5, smallfound
+	ADD  $1, R3, R3
+	B    small
+
+smallfound:
+	MOVD R3, (R8)
+	RET
+
+// func LastIndexAnyASCII(b []byte, set *[16]byte) int
+TEXT ·LastIndexAnyASCII(SB), NOSPLIT, $0-40
+	MOVD b_base+0(FP), R0
+	MOVD b_len+8(FP), R1
+	MOVD set+24(FP), R2
+	MOVD $ret+32(FP), R8
+	B    lastIndexAnyBody<>(SB)
+
+// func LastIndexAnyASCIIString(s string, set *[16]byte) int
+TEXT ·LastIndexAnyASCIIString(SB), NOSPLIT, $0-32
+	MOVD s_base+0(FP), R0
+	MOVD s_len+8(FP), R1
+	MOVD set+16(FP), R2
+	MOVD $ret+24(FP), R8
+	B    lastIndexAnyBody<>(SB)
+
+// lastIndexAnyBody is the reverse of indexAnyBody.
+//
+// input:
+//   R0: data
+//   R1: data len
+//   R2: address of the set
+//   R8: address to put result
+TEXT lastIndexAnyBody<>(SB), NOSPLIT, $0
+	CMP $32, R1
+	BLO small
+
+	SETUP
+
+	// R3 = offset of the last 32 bytes
+	SUB $32, R1, R3
+
+loop:
+	ADD  R0, R3, R4
+	VLD1 (R4), [V0.B16, V1.B16]
+	ANY
+	CBNZ R6, found
+	CBZ  R3, fail
+	SUBS $32, R3, R3
+	BHS  loop
+
+	// Search the first 32 bytes. This block may overlap with the bytes
+	// we've already searched, but that's ok.
+	MOVD $0, R3
+	B    loop
+
+// The block was loaded from offset R3.
+found:
+	SYNDROME
+
+	// Compute the index of the highest set bit, which is
+	// twice the offset into the block.
+	CLZ  R6, R6
+	MOVD $63, R7
+	SUB  R6, R7, R6
+	ADD  R6>>1, R3, R3
+	MOVD R3, (R8)
+	RET
+
+fail:
+	MOVD $-1, R3
+	MOVD R3, (R8)
+	RET
+
+// handle lengths < 32 a byte at a time
+small:
+	MOVD R1, R3
+
+smallloop:
+	SUBS $1, R3, R3
+	BLT  fail
+	SMALL
+	TBZ  $0, R5, smallloop
+	MOVD R3, (R8)
+	RET
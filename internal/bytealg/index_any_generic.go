@@ -0,0 +1,23 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build purego || (!amd64 && !arm64)
+// +build purego !amd64,!arm64
+
+package bytealg
+
+func IndexAnyASCII(b []byte, set *[16]byte) int {
+	return indexAnyASCIIGeneric(b, set)
+}
+
+func IndexAnyASCIIString(s string, set *[16]byte) int {
+	return indexAnyASCIIGenericString(s, set)
+}
+
+func LastIndexAnyASCII(b []byte, set *[16]byte) int {
+	return lastIndexAnyASCIIGeneric(b, set)
+}
+
+func LastIndexAnyASCIIString(s string, set *[16]byte) int {
+	return lastIndexAnyASCIIGenericString(s, set)
+}
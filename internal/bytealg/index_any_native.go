@@ -0,0 +1,27 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build (amd64 || arm64) && !purego
+// +build amd64 arm64
+// +build !purego
+
+package bytealg
+
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = indexAnyASCIIGeneric
+var _ = indexAnyASCIIGenericString
+var _ = lastIndexAnyASCIIGeneric
+var _ = lastIndexAnyASCIIGenericString
+
+//go:noescape
+func IndexAnyASCII(b []byte, set *[16]byte) int
+
+//go:noescape
+func IndexAnyASCIIString(s string, set *[16]byte) int
+
+//go:noescape
+func LastIndexAnyASCII(b []byte, set *[16]byte) int
+
+//go:noescape
+func LastIndexAnyASCIIString(s string, set *[16]byte) int
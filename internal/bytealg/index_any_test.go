@@ -0,0 +1,138 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeAnySet returns the set of bytes in chars, which must be ASCII.
+func makeAnySet(chars string) *[16]byte {
+	var set [16]byte
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		set[c&0xf] |= 1 << (c >> 4)
+	}
+	return &set
+}
+
+func TestIndexAnyASCII(t *testing.T) {
+	tests := []struct {
+		s, chars    string
+		first, last int
+	}{
+		{"", "abc", -1, -1},
+		{"a", "", -1, -1},
+		{"a", "a", 0, 0},
+		{"xyz", "abc", -1, -1},
+		{"xaybzc", "abc", 1, 5},
+		{"ABC", "abc", -1, -1},
+		{"\x00\x7f", "\x00", 0, 0},
+		{"\x00\x7f", "\x7f", 1, 1},
+		// Non-ASCII bytes are never in the set.
+		{"\x80\x81\xff\xe1a", "\x00\x01\x0f\x61", 4, 4},
+		{"\x80\x81\xff\xe1", "\x00\x01\x0f\x61", -1, -1},
+		{strings.Repeat("x", 64) + "a" + strings.Repeat("x", 64), "abc", 64, 64},
+		{"a" + strings.Repeat("x", 64) + "b", "abc", 0, 65},
+		{strings.Repeat("x", 31) + "c", "abc", 31, 31},
+		{strings.Repeat("x", 15) + "c", "abc", 15, 15},
+		{strings.Repeat("\xe1", 100), "abc", -1, -1},
+	}
+	for _, tt := range tests {
+		set := makeAnySet(tt.chars)
+		if got := IndexAnyASCIIString(tt.s, set); got != tt.first {
+			t.Errorf("IndexAnyASCIIString(%q, %q) = %d; want: %d", tt.s, tt.chars, got, tt.first)
+		}
+		if got := IndexAnyASCII([]byte(tt.s), set); got != tt.first {
+			t.Errorf("IndexAnyASCII(%q, %q) = %d; want: %d", tt.s, tt.chars, got, tt.first)
+		}
+		if got := LastIndexAnyASCIIString(tt.s, set); got != tt.last {
+			t.Errorf("LastIndexAnyASCIIString(%q, %q) = %d; want: %d", tt.s, tt.chars, got, tt.last)
+		}
+		if got := LastIndexAnyASCII([]byte(tt.s), set); got != tt.last {
+			t.Errorf("LastIndexAnyASCII(%q, %q) = %d; want: %d", tt.s, tt.chars, got, tt.last)
+		}
+	}
+}
+
+func TestIndexAnyASCIIRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(rr.Intn(256))
+		}
+		return string(b)
+	}
+	for i := 0; i < 5000; i++ {
+		var chars []byte
+		for j := rr.Intn(8); j >= 0; j-- {
+			chars = append(chars, byte(rr.Intn(128)))
+		}
+		set := makeAnySet(string(chars))
+		s := randStr(rr.Intn(160))
+		// Test all offsets to exercise the alignment of the final block
+		for j := 0; j < len(s) && j < 40; j++ {
+			first := strings.IndexAny(s[j:], string(chars))
+			last := strings.LastIndexAny(s[j:], string(chars))
+			if got := IndexAnyASCIIString(s[j:], set); got != first {
+				t.Fatalf("IndexAnyASCIIString(%q, %q) = %d; want: %d", s[j:], chars, got, first)
+			}
+			if got := IndexAnyASCII([]byte(s[j:]), set); got != first {
+				t.Fatalf("IndexAnyASCII(%q, %q) = %d; want: %d", s[j:], chars, got, first)
+			}
+			if got := LastIndexAnyASCIIString(s[j:], set); got != last {
+				t.Fatalf("LastIndexAnyASCIIString(%q, %q) = %d; want: %d", s[j:], chars, got, last)
+			}
+			if got := LastIndexAnyASCII([]byte(s[j:]), set); got != last {
+				t.Fatalf("LastIndexAnyASCII(%q, %q) = %d; want: %d", s[j:], chars, got, last)
+			}
+		}
+	}
+}
+
+func TestIndexAnyASCIIGeneric(t *testing.T) {
+	set := makeAnySet("abc")
+	for _, s := range []string{"", "x", "xxb", "xxxxxxxxxxxxxxxxaxxb", "\x80\xe1\xe2", strings.Repeat("x", 40)} {
+		first := strings.IndexAny(s, "abc")
+		last := strings.LastIndexAny(s, "abc")
+		if got := indexAnyASCIIGenericString(s, set); got != first {
+			t.Errorf("indexAnyASCIIGenericString(%q) = %d; want: %d", s, got, first)
+		}
+		if got := indexAnyASCIIGeneric([]byte(s), set); got != first {
+			t.Errorf("indexAnyASCIIGeneric(%q) = %d; want: %d", s, got, first)
+		}
+		if got := lastIndexAnyASCIIGenericString(s, set); got != last {
+			t.Errorf("lastIndexAnyASCIIGenericString(%q) = %d; want: %d", s, got, last)
+		}
+		if got := lastIndexAnyASCIIGeneric([]byte(s), set); got != last {
+			t.Errorf("lastIndexAnyASCIIGeneric(%q) = %d; want: %d", s, got, last)
+		}
+	}
+}
+
+func BenchmarkIndexAnyASCII(b *testing.B) {
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	set := makeAnySet("#%&")
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		if IndexAnyASCIIString(s, set) != -1 {
+			b.Fatal("unexpected match")
+		}
+	}
+}
+
+func BenchmarkLastIndexAnyASCII(b *testing.B) {
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	set := makeAnySet("#%&")
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		if LastIndexAnyASCIIString(s, set) != -1 {
+			b.Fatal("unexpected match")
+		}
+	}
+}
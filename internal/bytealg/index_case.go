@@ -0,0 +1,67 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+// The IndexCase and IndexStringCase functions return the index of the first
+// instance of substr in s, or -1 if substr is not present in s. Bytes are
+// compared ignoring ASCII case, so they are only suitable for ASCII needles.
+//
+// Candidate matches are found by searching for the bytes substr[i1] and
+// substr[i2] at their offsets in s, which should be the bytes of substr that
+// are least likely to occur in s. The assembly implementations compare the
+// pair with 16 or 32 positions of s at a time.
+//
+// The length of substr must be at least 2 and 0 <= i1 < i2 < len(substr).
+
+// toLowerASCII returns c converted to lower case if it is an ASCII letter.
+func toLowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		c += 'a' - 'A'
+	}
+	return c
+}
+
+// indexCaseGeneric is the generic implementation of IndexCase.
+func indexCaseGeneric(s, substr []byte, i1, i2 int) int {
+	n := len(substr)
+	c1 := toLowerASCII(substr[i1])
+	c2 := toLowerASCII(substr[i2])
+	for i := 0; i <= len(s)-n; i++ {
+		if toLowerASCII(s[i+i1]) != c1 || toLowerASCII(s[i+i2]) != c2 {
+			continue
+		}
+		j := 0
+		for ; j < n; j++ {
+			if toLowerASCII(s[i+j]) != toLowerASCII(substr[j]) {
+				break
+			}
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexCaseGenericString is the generic implementation of IndexStringCase.
+func indexCaseGenericString(s, substr string, i1, i2 int) int {
+	n := len(substr)
+	c1 := toLowerASCII(substr[i1])
+	c2 := toLowerASCII(substr[i2])
+	for i := 0; i <= len(s)-n; i++ {
+		if toLowerASCII(s[i+i1]) != c1 || toLowerASCII(s[i+i2]) != c2 {
+			continue
+		}
+		j := 0
+		for ; j < n; j++ {
+			if toLowerASCII(s[i+j]) != toLowerASCII(substr[j]) {
+				break
+			}
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "go_asm.h"
+#include "asm_amd64.h"
+#include "textflag.h"
+
+// CASEMASK sets m to 0x20 if c is an ASCII letter and 0 otherwise then folds
+// c to lower case by OR'ing it with m.
+#define CASEMASK(c, m) \
+	MOVL c, m         \
+	ORL  $0x20, m     \
+	SUBL $0x61, m     \
+	CMPL m, $26       \
+	SBBL m, m         \
+	ANDL $0x20, m     \
+	ORL  m, c
+
+// BROADCAST copies the low byte of r into each byte of x.
+#define BROADCAST(r, x) \
+	MOVQ      r, x    \
+	PUNPCKLBW x, x    \
+	PUNPCKLBW x, x    \
+	PSHUFL    $0, x, x
+
+// PAIR_SSE sets bit i of DX if the folded bytes at offsets R11 and R12 of
+// position DI+i are equal to the folded pair (X1, X3).
+#define PAIR_SSE \
+	MOVOU    (DI)(R11*1), X5 \
+	POR      X2, X5          \
+	PCMPEQB  X1, X5          \
+	MOVOU    (DI)(R12*1), X6 \
+	POR      X4, X6          \
+	PCMPEQB  X3, X6          \
+	PAND     X6, X5          \
+	PMOVMSKB X5, DX
+
+// PAIR_AVX2 is like PAIR_SSE but checks 32 positions.
+#define PAIR_AVX2 \
+	VMOVDQU   (DI)(R11*1), Y5 \
+	VPOR      Y2, Y5, Y5      \
+	VPCMPEQB  Y1, Y5, Y5      \
+	VMOVDQU   (DI)(R12*1), Y6 \
+	VPOR      Y4, Y6, Y6      \
+	VPCMPEQB  Y3, Y6, Y6      \
+	VPAND     Y6, Y5, Y5      \
+	VPMOVMSKB Y5, DX
+
+// PAIR_AVX2_64 checks 64 positions and clears the zero flag if any of them
+// are candidates.
+#define PAIR_AVX2_64 \
+	VMOVDQU  (DI)(R11*1), Y5   \
+	VPOR     Y2, Y5, Y5        \
+	VPCMPEQB Y1, Y5, Y5        \
+	VMOVDQU  (DI)(R12*1), Y6   \
+	VPOR     Y4, Y6, Y6        \
+	VPCMPEQB Y3, Y6, Y6        \
+	VPAND    Y6, Y5, Y5        \
+	VMOVDQU  32(DI)(R11*1), Y7 \
+	VPOR     Y2, Y7, Y7        \
+	VPCMPEQB Y1, Y7, Y7        \
+	VMOVDQU  32(DI)(R12*1), Y8 \
+	VPOR     Y4, Y8, Y8        \
+	VPCMPEQB Y3, Y8, Y8        \
+	VPAND    Y8, Y7, Y7        \
+	VPOR     Y7, Y5, Y5        \
+	VPTEST   Y5, Y5
+
+// func IndexCase(s, substr []byte, i1, i2 int) int
+TEXT ·IndexCase(SB), NOSPLIT, $0-72
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), BX
+	MOVQ substr_base+24(FP), R9
+	MOVQ substr_len+32(FP), R10
+	MOVQ i1+48(FP), R11
+	MOVQ i2+56(FP), R12
+	LEAQ ret+64(FP), R8
+	JMP  indexCaseBody<>(SB)
+
+// func IndexStringCase(s, substr string, i1, i2 int) int
+TEXT ·IndexStringCase(SB), NOSPLIT, $0-56
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), BX
+	MOVQ substr_base+16(FP), R9
+	MOVQ substr_len+24(FP), R10
+	MOVQ i1+32(FP), R11
+	MOVQ i2+40(FP), R12
+	LEAQ ret+48(FP), R8
+	JMP  indexCaseBody<>(SB)
+
+// input:
+//   SI: data
+//   BX: data len
+//   R9: needle
+//   R10: needle len (must be >= 2)
+//   R11: offset of the first byte of the pair (i1)
+//   R12: offset of the second byte of the pair (i2 > i1)
+//   R8: address to put result
+//
+// Letters are compared by setting bit 0x20 (lower case) of both the data and
+// the needle, other bytes are compared exactly. Each position where both
+// bytes of the pair match is a candidate and is verified by comparing the
+// entire needle.
+TEXT indexCaseBody<>(SB), NOSPLIT, $0
+	// R13 = address of the last position that substr may start at
+	SUBQ R10, BX
+	JCS  failure
+	LEAQ (SI)(BX*1), R13
+
+	// X1 = folded first byte of the pair, X2 = its case mask
+	MOVBLZX (R9)(R11*1), AX
+	CASEMASK(AX, CX)
+	BROADCAST(AX, X1)
+	BROADCAST(CX, X2)
+
+	// X3 = folded second byte of the pair, X4 = its case mask
+	MOVBLZX (R9)(R12*1), AX
+	CASEMASK(AX, CX)
+	BROADCAST(AX, X3)
+	BROADCAST(CX, X4)
+
+	MOVQ SI, DI
+	LEAQ 31(DI), AX
+	CMPQ AX, R13
+	JBE  avx2
+
+sse:
+	LEAQ 15(DI), AX
+	CMPQ AX, R13
+	JA   small
+
+sseloop:
+	PAIR_SSE
+	TESTL DX, DX
+	JNZ   ssecandidate
+
+ssenext:
+	ADDQ $16, DI
+	LEAQ 15(DI), AX
+	CMPQ AX, R13
+	JBE  sseloop
+
+	// Search the last 16 positions. This block may overlap with the
+	// positions we've already searched so clear their bits from the mask.
+	CMPQ DI, R13
+	JA   failure
+	MOVQ DI, CX
+	LEAQ -15(R13), DI
+	SUBQ DI, CX
+	PAIR_SSE
+	SHRL CX, DX
+	SHLL CX, DX
+	TESTL DX, DX
+	JNZ   ssecandidate
+	JMP   failure
+
+// There are fewer than 16 positions to search so verify all of them.
+small:
+	MOVQ R13, CX
+	SUBQ DI, CX
+	INCQ CX
+	MOVL $1, DX
+	SHLL CX, DX
+	DECL DX
+
+// The block was loaded from DI and the bits of DX are its candidates.
+ssecandidate:
+	BSFL DX, CX
+	LEAQ (DI)(CX*1), AX
+	XORL CX, CX
+
+sseverify:
+	MOVBLZX (AX)(CX*1), BX
+	XORB    (R9)(CX*1), BX
+	JZ      ssenextbyte
+	CMPB    BX, $0x20
+	JNE     ssenomatch
+	MOVBLZX (AX)(CX*1), BX
+	ORL     $0x20, BX
+	SUBL    $0x61, BX
+	CMPL    BX, $25
+	JA      ssenomatch
+
+ssenextbyte:
+	INCQ CX
+	CMPQ CX, R10
+	JB   sseverify
+
+	SUBQ SI, AX
+	MOVQ AX, (R8)
+	RET
+
+ssenomatch:
+	// Clear the lowest set bit of the mask.
+	LEAL -1(DX), CX
+	ANDL CX, DX
+	JNZ  ssecandidate
+	JMP  ssenext
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	VPBROADCASTB X1, Y1
+	VPBROADCASTB X2, Y2
+	VPBROADCASTB X3, Y3
+	VPBROADCASTB X4, Y4
+
+avx2loop:
+	// Search 64 positions at a time and fall back to searching 32 at a
+	// time to find the candidates in a block or if there are fewer than
+	// 64 positions left.
+	LEAQ 63(DI), AX
+	CMPQ AX, R13
+	JA   avx2block
+	PAIR_AVX2_64
+	JNZ  avx2block
+	ADDQ $64, DI
+	JMP  avx2loop
+
+avx2block:
+	LEAQ 31(DI), AX
+	CMPQ AX, R13
+	JA   avx2last
+	PAIR_AVX2
+	TESTL DX, DX
+	JNZ   avx2candidate
+
+avx2next:
+	ADDQ $32, DI
+	JMP  avx2loop
+
+avx2last:
+
+	// Search the last (possibly overlapping) 32 positions.
+	CMPQ DI, R13
+	JA   avx2failure
+	MOVQ DI, CX
+	LEAQ -31(R13), DI
+	SUBQ DI, CX
+	PAIR_AVX2
+	SHRL CX, DX
+	SHLL CX, DX
+	TESTL DX, DX
+	JNZ   avx2candidate
+
+avx2failure:
+	VZEROUPPER
+	JMP failure
+
+avx2candidate:
+	BSFL DX, CX
+	LEAQ (DI)(CX*1), AX
+	XORL CX, CX
+
+avx2verify:
+	MOVBLZX (AX)(CX*1), BX
+	XORB    (R9)(CX*1), BX
+	JZ      avx2nextbyte
+	CMPB    BX, $0x20
+	JNE     avx2nomatch
+	MOVBLZX (AX)(CX*1), BX
+	ORL     $0x20, BX
+	SUBL    $0x61, BX
+	CMPL    BX, $25
+	JA      avx2nomatch
+
+avx2nextbyte:
+	INCQ CX
+	CMPQ CX, R10
+	JB   avx2verify
+
+	VZEROUPPER
+	SUBQ SI, AX
+	MOVQ AX, (R8)
+	RET
+
+avx2nomatch:
+	LEAL -1(DX), CX
+	ANDL CX, DX
+	JNZ  avx2candidate
+	JMP  avx2next
+
+failure:
+	MOVQ $-1, (R8)
+	RET
@@ -0,0 +1,181 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// CASEMASK sets m to 0x20 if c is an ASCII letter and 0 otherwise then folds
+// c to lower case by OR'ing it with m.
+#define CASEMASK(c, m) \
+	ORR  $0x20, c, m \
+	SUB  $0x61, m, m \
+	CMP  $26, m      \
+	CSET LO, m       \
+	LSL  $5, m, m    \
+	ORR  m, c, c
+
+// PAIR_NEON computes the syndrome for the 16 positions starting at R6 in
+// R10. Bit 2*i of R10 is set if the folded bytes at offsets R4 and R5 of
+// position i are equal to the folded pair (V1, V3).
+#define PAIR_NEON \
+	ADD   R4, R6, R7                \
+	VLD1  (R7), [V16.B16]           \
+	ADD   R5, R6, R7                \
+	VLD1  (R7), [V17.B16]           \
+	VORR  V2.B16, V16.B16, V16.B16  \
+	VCMEQ V1.B16, V16.B16, V16.B16  \
+	VORR  V4.B16, V17.B16, V17.B16  \
+	VCMEQ V3.B16, V17.B16, V17.B16  \
+	VAND  V17.B16, V16.B16, V16.B16 \
+	VAND  V5.B16, V16.B16, V16.B16  \
+	VADDP V16.B16, V16.B16, V16.B16 \
+	VADDP V16.B16, V16.B16, V16.B16 \
+	VMOV  V16.S[0], R10
+
+// func IndexCase(s, substr []byte, i1, i2 int) int
+TEXT ·IndexCase(SB), NOSPLIT, $0-72
+	MOVD s_base+0(FP), R0
+	MOVD s_len+8(FP), R1
+	MOVD substr_base+24(FP), R2
+	MOVD substr_len+32(FP), R3
+	MOVD i1+48(FP), R4
+	MOVD i2+56(FP), R5
+	MOVD $ret+64(FP), R8
+
+	B indexCaseBody<>(SB)
+
+// func IndexStringCase(s, substr string, i1, i2 int) int
+TEXT ·IndexStringCase(SB), NOSPLIT, $0-56
+	MOVD s_base+0(FP), R0
+	MOVD s_len+8(FP), R1
+	MOVD substr_base+16(FP), R2
+	MOVD substr_len+24(FP), R3
+	MOVD i1+32(FP), R4
+	MOVD i2+40(FP), R5
+	MOVD $ret+48(FP), R8
+
+	B indexCaseBody<>(SB)
+
+// input:
+//   R0: data
+//   R1: data len
+//   R2: needle
+//   R3: needle len (must be >= 2)
+//   R4: offset of the first byte of the pair (i1)
+//   R5: offset of the second byte of the pair (i2 > i1)
+//   R8: address to put result
+//
+// Letters are compared by setting bit 0x20 (lower case) of both the data and
+// the needle, other bytes are compared exactly. Each position where both
+// bytes of the pair match is a candidate and is verified by comparing the
+// entire needle.
+TEXT indexCaseBody<>(SB), NOSPLIT, $0
+	// R9 = address of the last position that substr may start at
+	SUBS R3, R1, R9
+	BLT  fail
+	ADD  R0, R9, R9
+
+	// V1 = folded first byte of the pair, V2 = its case mask
+	MOVBU (R2)(R4), R11
+	CASEMASK(R11, R12)
+	VDUP  R11, V1.B16
+	VDUP  R12, V2.B16
+
+	// V3 = folded second byte of the pair, V4 = its case mask
+	MOVBU (R2)(R5), R11
+	CASEMASK(R11, R12)
+	VDUP  R11, V3.B16
+	VDUP  R12, V4.B16
+
+	// Magic constant 0x40100401 allows us to identify
+	// which lane matches the requested byte.
+	// 0x40100401 = ((1<<0) + (4<<8) + (16<<16) + (64<<24))
+	// Different bytes have different bit masks (i.e: 1, 4, 16, 64)
+	MOVD $0x40100401, R11
+	VMOV R11, V5.S4
+
+	MOVD R0, R6
+	ADD  $15, R6, R7
+	CMP  R9, R7
+	BHI  small
+
+loop:
+	PAIR_NEON
+	CBNZ R10, candidate
+
+next:
+	ADD $16, R6, R6
+	ADD $15, R6, R7
+	CMP R9, R7
+	BLS loop
+
+	// Search the last 16 positions. This block may overlap with the
+	// positions we've already searched so clear their bits from the
+	// syndrome.
+	CMP  R9, R6
+	BHI  fail
+	SUB  $15, R9, R7
+	SUB  R7, R6, R11
+	MOVD R7, R6
+	PAIR_NEON
+	LSL  $1, R11, R11
+	LSR  R11, R10, R10
+	LSL  R11, R10, R10
+	CBNZ R10, candidate
+	B    fail
+
+// There are fewer than 16 positions to search so verify all of them.
+small:
+	SUB  R6, R9, R11
+	ADD  $1, R11, R11
+	LSL  $1, R11, R11
+	MOVD $1, R10
+	LSL  R11, R10, R10
+	SUB  $1, R10, R10
+	AND  $0x55555555, R10, R10
+
+// The block was loaded from R6 and the bits of R10 are its candidates.
+candidate:
+	// Count the trailing zeros using bit reversing
+	RBIT R10, R11
+	CLZ  R11, R11
+
+	// R11 is twice the offset into the block
+	ADD  R11>>1, R6, R12
+	MOVD $0, R13
+
+verify:
+	MOVBU (R12)(R13), R14
+	MOVBU (R2)(R13), R15
+	EOR   R14, R15, R15
+	CBZ   R15, nextbyte
+	CMP   $0x20, R15
+	BNE   nomatch
+	ORR   $0x20, R14, R14
+	SUB   $0x61, R14, R14
+	CMP   $25, R14
+	BHI   nomatch
+
+nextbyte:
+	ADD $1, R13, R13
+	CMP R3, R13
+	BLO verify
+
+	// Compute the offset result
+	SUB  R0, R12, R12
+	MOVD R12, (R8)
+	RET
+
+nomatch:
+	// Clear the lowest set bit of the syndrome.
+	SUB  $1, R10, R11
+	AND  R11, R10, R10
+	CBNZ R10, candidate
+	B    next
+
+fail:
+	MOVD $-1, R0
+	MOVD R0, (R8)
+	RET
@@ -0,0 +1,19 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build purego || (!amd64 && !arm64)
+// +build purego !amd64,!arm64
+
+package bytealg
+
+// NativeIndexCase is true if we have a fast native (assembly) implementation
+// of IndexCase and IndexStringCase.
+const NativeIndexCase = false
+
+func IndexCase(s, substr []byte, i1, i2 int) int {
+	return indexCaseGeneric(s, substr, i1, i2)
+}
+
+func IndexStringCase(s, substr string, i1, i2 int) int {
+	return indexCaseGenericString(s, substr, i1, i2)
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build (amd64 || arm64) && !purego
+// +build amd64 arm64
+// +build !purego
+
+package bytealg
+
+// NativeIndexCase is true if we have a fast native (assembly) implementation
+// of IndexCase and IndexStringCase.
+const NativeIndexCase = true
+
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = indexCaseGeneric
+var _ = indexCaseGenericString
+
+//go:noescape
+func IndexCase(s, substr []byte, i1, i2 int) int
+
+//go:noescape
+func IndexStringCase(s, substr string, i1, i2 int) int
@@ -0,0 +1,116 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// indexCaseReference is a naive implementation of IndexStringCase.
+func indexCaseReference(s, substr string) int {
+	lower := func(s string) string {
+		b := []byte(s)
+		for i, c := range b {
+			b[i] = toLowerASCII(c)
+		}
+		return string(b)
+	}
+	return strings.Index(lower(s), lower(substr))
+}
+
+func TestIndexCase(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		i1, i2    int
+		out       int
+	}{
+		{"", "ab", 0, 1, -1},
+		{"a", "ab", 0, 1, -1},
+		{"ab", "ab", 0, 1, 0},
+		{"xAB", "ab", 0, 1, 1},
+		{"xaBc", "AbC", 0, 2, 1},
+		{"xaBd", "AbC", 0, 2, -1},
+		{"abc", "abc", 1, 2, 0},
+		// Only letters are folded.
+		{"@[`{", "`{", 0, 1, 2},
+		{"\xc0\xe0", "\xe0\xe0", 0, 1, -1},
+		{"\x00\x20", "\x20\x00", 0, 1, -1},
+		{strings.Repeat("x", 64) + "HeLLo", "hello", 0, 4, 64},
+		{strings.Repeat("x", 64) + "HeLLo", "hello", 1, 3, 64},
+		{strings.Repeat("x", 64) + "HeLL", "hello", 1, 3, -1},
+		{strings.Repeat("hellx", 16) + "hello", "hello", 0, 1, 80},
+		{strings.Repeat("hellx", 16) + "hello", "hello", 3, 4, 80},
+		{strings.Repeat("a", 31) + "b", strings.Repeat("A", 31) + "B", 0, 31, 0},
+		{strings.Repeat("a", 100) + "b", strings.Repeat("A", 31) + "B", 0, 31, 69},
+	}
+	for _, tt := range tests {
+		if got := IndexStringCase(tt.s, tt.substr, tt.i1, tt.i2); got != tt.out {
+			t.Errorf("IndexStringCase(%q, %q, %d, %d) = %d; want: %d",
+				tt.s, tt.substr, tt.i1, tt.i2, got, tt.out)
+		}
+		if got := IndexCase([]byte(tt.s), []byte(tt.substr), tt.i1, tt.i2); got != tt.out {
+			t.Errorf("IndexCase(%q, %q, %d, %d) = %d; want: %d",
+				tt.s, tt.substr, tt.i1, tt.i2, got, tt.out)
+		}
+	}
+}
+
+func TestIndexCaseRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int, chars string) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = chars[rr.Intn(len(chars))]
+		}
+		return string(b)
+	}
+	const chars = "abcABC@[`{\x00\x80\xff"
+	for i := 0; i < 5000; i++ {
+		substr := randStr(rr.Intn(31)+2, chars)
+		s := randStr(rr.Intn(128), chars)
+		if rr.Intn(2) == 0 {
+			j := rr.Intn(len(s) + 1)
+			s = s[:j] + strings.ToUpper(substr) + s[j:]
+		}
+		i1 := rr.Intn(len(substr) - 1)
+		i2 := i1 + 1 + rr.Intn(len(substr)-i1-1)
+		// Test all offsets to exercise the alignment of the final block
+		for j := 0; j < len(s) && j < 40; j++ {
+			want := indexCaseReference(s[j:], substr)
+			if got := IndexStringCase(s[j:], substr, i1, i2); got != want {
+				t.Fatalf("IndexStringCase(%q, %q, %d, %d) = %d; want: %d",
+					s[j:], substr, i1, i2, got, want)
+			}
+			if got := IndexCase([]byte(s[j:]), []byte(substr), i1, i2); got != want {
+				t.Fatalf("IndexCase(%q, %q, %d, %d) = %d; want: %d",
+					s[j:], substr, i1, i2, got, want)
+			}
+		}
+	}
+}
+
+func TestIndexCaseGeneric(t *testing.T) {
+	for _, s := range []string{"", "h", "xxHELLO", "xxxxxxxxxxxxxxxxhElLo", strings.Repeat("x", 40)} {
+		want := indexCaseReference(s, "hello")
+		if got := indexCaseGenericString(s, "hello", 0, 4); got != want {
+			t.Errorf("indexCaseGenericString(%q) = %d; want: %d", s, got, want)
+		}
+		if got := indexCaseGeneric([]byte(s), []byte("hello"), 0, 4); got != want {
+			t.Errorf("indexCaseGeneric(%q) = %d; want: %d", s, got, want)
+		}
+	}
+}
+
+func BenchmarkIndexCase(b *testing.B) {
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		if IndexStringCase(s, "LAZY CAT", 2, 6) != -1 {
+			b.Fatal("unexpected match")
+		}
+	}
+}
@@ -1,7 +1,7 @@
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
 
-//go:build amd64 || arm64 || s390x || ppc64le || ppc64
+//go:build (amd64 || arm64 || s390x || ppc64le || ppc64) && !purego
 
 package bytealg
 
@@ -0,0 +1,113 @@
+//go:build 386 && !go1.22 && !purego
+// +build 386,!go1.22,!purego
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// The SSE2 portion of index_non_ascii_amd64.s ported to 386. PMOVMSKB
+// collects the top bit of each byte so the data is used as the mask.
+
+TEXT ·IndexByteNonASCII(SB), NOSPLIT, $0-16
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSE2(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexByteNonASCIISWAR(SB)
+
+	MOVL b_base+0(FP), SI
+	MOVL b_len+4(FP), BX
+	LEAL ret+12(FP), BP
+
+	JMP indexByteBodyNonASCII<>(SB)
+
+TEXT ·IndexNonASCII(SB), NOSPLIT, $0-12
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSE2(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexNonASCIISWAR(SB)
+
+	MOVL s_base+0(FP), SI
+	MOVL s_len+4(FP), BX
+	LEAL ret+8(FP), BP
+
+	JMP indexByteBodyNonASCII<>(SB)
+
+// input:
+//   SI: data
+//   BX: data len
+//   BP: address to put result
+TEXT indexByteBodyNonASCII<>(SB), NOSPLIT, $0
+	CMPL BX, $16
+	JLT  small
+
+	MOVL SI, DI
+	LEAL -16(SI)(BX*1), AX // AX = address of last 16 bytes
+	JMP  sseloopentry
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (DI), X1
+
+	// Take the top bit (RuneSelf) of each byte in X1 and put the
+	// result in DX.
+	PMOVMSKB X1, DX
+
+	// Find first set bit, if any.
+	BSFL DX, DX
+	JNZ  ssesuccess
+
+	// Advance to next block.
+	ADDL $16, DI
+
+sseloopentry:
+	CMPL DI, AX
+	JB   sseloop
+
+	// Search the last 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched, but that's ok.
+	MOVL     AX, DI
+	MOVOU    (AX), X1
+	PMOVMSKB X1, DX
+	BSFL     DX, DX
+	JNZ      ssesuccess
+
+failure:
+	MOVL $-1, (BP)
+	RET
+
+// We've found a chunk containing the byte.
+// The chunk was loaded from DI.
+// The index of the matching byte in the chunk is DX.
+// The start of the data is SI.
+ssesuccess:
+	SUBL SI, DI   // Compute offset of chunk within data.
+	ADDL DX, DI   // Add offset of byte within chunk.
+	MOVL DI, (BP)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTL BX, BX
+	JEQ   failure
+
+	// Check if we'll load across a page boundary.
+	LEAL  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	MOVOU    (SI), X1 // Load data
+	PMOVMSKB X1, DX   // Move RuneSelf bits to integer register.
+	BSFL     DX, DX   // Find first set bit.
+	JZ       failure  // No set bit, failure.
+	CMPL     DX, BX
+	JAE      failure  // Match is past end of data.
+	MOVL     DX, (BP)
+	RET
+
+endofpage:
+	MOVOU    -16(SI)(BX*1), X1 // Load data into the high end of X1.
+	PMOVMSKB X1, DX            // Move RuneSelf bits to integer register.
+	MOVL     BX, CX
+	SHLL     CX, DX
+	SHRL     $16, DX           // Shift desired bits down to bottom of register.
+	BSFL     DX, DX            // Find first set bit.
+	JZ       failure           // No set bit, failure.
+	MOVL     DX, (BP)
+	RET
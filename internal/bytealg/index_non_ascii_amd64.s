@@ -1,7 +1,8 @@
-//go:build amd64 && !go1.22
-// +build amd64,!go1.22
+//go:build amd64 && !go1.22 && !purego
+// +build amd64,!go1.22,!purego
 
 #include "go_asm.h"
+#include "asm_amd64.h"
 #include "textflag.h"
 
 // TODO: can we use popcnt for this ???
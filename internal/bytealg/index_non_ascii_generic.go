@@ -1,24 +1,12 @@
-//go:build !amd64 && !arm64
-// +build !amd64,!arm64
+//go:build purego || (!amd64 && !arm64 && !386)
+// +build purego !amd64,!arm64,!386
 
 package bytealg
 
-import "unicode/utf8"
-
 func IndexByteNonASCII(b []byte) int {
-	for i := 0; i < len(b); i++ {
-		if b[i]&utf8.RuneSelf != 0 {
-			return i
-		}
-	}
-	return -1
+	return indexByteNonASCIISWAR(b)
 }
 
 func IndexNonASCII(s string) int {
-	for i := 0; i < len(s); i++ {
-		if s[i]&utf8.RuneSelf != 0 {
-			return i
-		}
-	}
-	return -1
+	return indexNonASCIISWAR(s)
 }
@@ -150,6 +150,16 @@ func TestIndexByteNonASCII(t *testing.T) {
 	})
 }
 
+func TestIndexNonASCIISWAR(t *testing.T) {
+	testIndexNonASCII(t, "indexNonASCIISWAR", indexNonASCIISWAR)
+}
+
+func TestIndexByteNonASCIISWAR(t *testing.T) {
+	testIndexNonASCII(t, "indexByteNonASCIISWAR", func(s string) int {
+		return indexByteNonASCIISWAR([]byte(s))
+	})
+}
+
 func benchIndexNonASCII(b *testing.B, sizes []int, f func(b *testing.B, n int)) {
 	for _, n := range sizes {
 		b.Run(valName(n), func(b *testing.B) {
@@ -166,6 +176,10 @@ func BenchmarkIndexByteNonASCII(b *testing.B) {
 	benchIndexNonASCII(b, indexSizes, bmIndexNonASCII(IndexByteNonASCII))
 }
 
+func BenchmarkIndexByteNonASCIISWAR(b *testing.B) {
+	benchIndexNonASCII(b, indexSizes, bmIndexNonASCII(indexByteNonASCIISWAR))
+}
+
 func bmIndexNonASCII(index func([]byte) int) func(b *testing.B, n int) {
 	return func(b *testing.B, n int) {
 		buf := bmbuf[0:n]
@@ -0,0 +1,272 @@
+//go:build 386 && !go1.22 && !purego
+// +build 386,!go1.22,!purego
+
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+// The following code is the SSE2 portion of indexbyte_amd64.s ported to
+// 386, which is itself a modified version of the standard library's
+// internal/bytealg/indexbyte_amd64.s the Go LICENSE can be found in the
+// go.LICENSE file.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+TEXT ·IndexByte(SB), NOSPLIT, $0-20
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSE2(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexByteSWAR(SB)
+
+	MOVL b_base+0(FP), SI
+	MOVL b_len+4(FP), BX
+	MOVB c+12(FP), AL
+	LEAL ret+16(FP), BP
+
+	LEAL -65(AX), CX // Check if the byte is a ASCII letter
+	CMPB CL, $25
+	JLS  index_case  // Byte sought is a ASCII letter
+	ADDL $-97, AX
+	CMPB AL, $25
+	JHI  index
+
+index_case:
+	MOVB c+12(FP), AL
+	JMP  indexbytebodyCase<>(SB)
+
+index:
+	MOVB c+12(FP), AL
+	JMP  indexbytebody<>(SB)
+
+TEXT ·IndexByteString(SB), NOSPLIT, $0-16
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSE2(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexByteStringSWAR(SB)
+
+	MOVL s_base+0(FP), SI
+	MOVL s_len+4(FP), BX
+	MOVB c+8(FP), AL
+	LEAL ret+12(FP), BP
+
+	LEAL -65(AX), CX // Check if the byte is a ASCII letter
+	CMPB CL, $25
+	JLS  index_case  // Byte sought is a ASCII letter
+	ADDL $-97, AX
+	CMPB AL, $25
+	JHI  index
+
+index_case:
+	MOVB c+8(FP), AL
+	JMP  indexbytebodyCase<>(SB)
+
+index:
+	MOVB c+8(FP), AL
+	JMP  indexbytebody<>(SB)
+
+// indexbytebodyCase is a case insensitive version indexbytebody
+// the byte being sought *must* be an ASCII letter.
+//
+// input:
+//   SI: data
+//   BX: data len
+//   AL: byte sought
+//   BP: address to put result
+TEXT indexbytebodyCase<>(SB), NOSPLIT, $0
+	// Shuffle X0 around so that each byte contains
+	// the character we're looking for.
+	ORL       $32, AX    // Convert byte to lowercase
+	MOVL      AX, X0
+	PUNPCKLBW X0, X0
+	PUNPCKLBW X0, X0
+	PSHUFL    $0, X0, X0
+
+	// Add space (' ') mask to X2
+	MOVL   $0x20202020, CX
+	MOVL   CX, X2
+	PSHUFL $0, X2, X2
+
+	CMPL BX, $16
+	JLT  small
+
+	MOVL SI, DI
+	LEAL -16(SI)(BX*1), AX // AX = address of last 16 bytes
+	JMP  sseloopentry
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (DI), X1
+
+	// Logical OR to convert data to lowercase
+	POR X2, X1
+
+	// Compare bytes in X0 to X1.
+	PCMPEQB X0, X1
+
+	// Take the top bit of each byte in X1 and put the result in DX.
+	PMOVMSKB X1, DX
+
+	// Find first set bit, if any.
+	BSFL DX, DX
+	JNZ  ssesuccess
+
+	// Advance to next block.
+	ADDL $16, DI
+
+sseloopentry:
+	CMPL DI, AX
+	JB   sseloop
+
+	// Search the last 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched, but that's ok.
+	MOVL     AX, DI
+	MOVOU    (AX), X1
+	POR      X2, X1     // Convert data to lowercase
+	PCMPEQB  X0, X1
+	PMOVMSKB X1, DX
+	BSFL     DX, DX
+	JNZ      ssesuccess
+
+failure:
+	MOVL $-1, (BP)
+	RET
+
+// We've found a chunk containing the byte.
+// The chunk was loaded from DI.
+// The index of the matching byte in the chunk is DX.
+// The start of the data is SI.
+ssesuccess:
+	SUBL SI, DI   // Compute offset of chunk within data.
+	ADDL DX, DI   // Add offset of byte within chunk.
+	MOVL DI, (BP)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTL BX, BX
+	JEQ   failure
+
+	// Check if we'll load across a page boundary.
+	LEAL  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	MOVOU    (SI), X1 // Load data
+	POR      X2, X1   // Convert data to lowercase
+	PCMPEQB  X0, X1   // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX   // Move result bits to integer register.
+	BSFL     DX, DX   // Find first set bit.
+	JZ       failure  // No set bit, failure.
+	CMPL     DX, BX
+	JAE      failure  // Match is past end of data.
+	MOVL     DX, (BP)
+	RET
+
+endofpage:
+	MOVOU    -16(SI)(BX*1), X1 // Load data into the high end of X1.
+	POR      X2, X1            // Convert data to lowercase
+	PCMPEQB  X0, X1            // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX            // Move result bits to integer register.
+	MOVL     BX, CX
+	SHLL     CX, DX
+	SHRL     $16, DX           // Shift desired bits down to bottom of register.
+	BSFL     DX, DX            // Find first set bit.
+	JZ       failure           // No set bit, failure.
+	MOVL     DX, (BP)
+	RET
+
+// indexbytebody is the SSE2 portion of indexbytebody in indexbyte_amd64.s
+//
+// input:
+//   SI: data
+//   BX: data len
+//   AL: byte sought
+//   BP: address to put result
+TEXT indexbytebody<>(SB), NOSPLIT, $0
+	// Shuffle X0 around so that each byte contains
+	// the character we're looking for.
+	MOVL      AX, X0
+	PUNPCKLBW X0, X0
+	PUNPCKLBW X0, X0
+	PSHUFL    $0, X0, X0
+
+	CMPL BX, $16
+	JLT  small
+
+	MOVL SI, DI
+	LEAL -16(SI)(BX*1), AX // AX = address of last 16 bytes
+	JMP  sseloopentry
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (DI), X1
+
+	// Compare bytes in X0 to X1.
+	PCMPEQB X0, X1
+
+	// Take the top bit of each byte in X1 and put the result in DX.
+	PMOVMSKB X1, DX
+
+	// Find first set bit, if any.
+	BSFL DX, DX
+	JNZ  ssesuccess
+
+	// Advance to next block.
+	ADDL $16, DI
+
+sseloopentry:
+	CMPL DI, AX
+	JB   sseloop
+
+	// Search the last 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched, but that's ok.
+	MOVL     AX, DI
+	MOVOU    (AX), X1
+	PCMPEQB  X0, X1
+	PMOVMSKB X1, DX
+	BSFL     DX, DX
+	JNZ      ssesuccess
+
+failure:
+	MOVL $-1, (BP)
+	RET
+
+// We've found a chunk containing the byte.
+// The chunk was loaded from DI.
+// The index of the matching byte in the chunk is DX.
+// The start of the data is SI.
+ssesuccess:
+	SUBL SI, DI   // Compute offset of chunk within data.
+	ADDL DX, DI   // Add offset of byte within chunk.
+	MOVL DI, (BP)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTL BX, BX
+	JEQ   failure
+
+	// Check if we'll load across a page boundary.
+	LEAL  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	MOVOU    (SI), X1 // Load data
+	PCMPEQB  X0, X1   // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX   // Move result bits to integer register.
+	BSFL     DX, DX   // Find first set bit.
+	JZ       failure  // No set bit, failure.
+	CMPL     DX, BX
+	JAE      failure  // Match is past end of data.
+	MOVL     DX, (BP)
+	RET
+
+endofpage:
+	MOVOU    -16(SI)(BX*1), X1 // Load data into the high end of X1.
+	PCMPEQB  X0, X1            // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX            // Move result bits to integer register.
+	MOVL     BX, CX
+	SHLL     CX, DX
+	SHRL     $16, DX           // Shift desired bits down to bottom of register.
+	BSFL     DX, DX            // Find first set bit.
+	JZ       failure           // No set bit, failure.
+	MOVL     DX, (BP)
+	RET
@@ -1,5 +1,5 @@
-//go:build amd64 && !go1.22
-// +build amd64,!go1.22
+//go:build amd64 && !go1.22 && !purego
+// +build amd64,!go1.22,!purego
 
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
@@ -9,6 +9,7 @@
 // the go.LICENSE file.
 
 #include "go_asm.h"
+#include "asm_amd64.h"
 #include "textflag.h"
 
 TEXT ·IndexByte(SB), NOSPLIT, $0-40
@@ -177,10 +178,12 @@ avx2:
 	JNE  sse
 
 #endif
+	// Move the byte sought into X0 before using any Y registers to avoid
+	// the penalty of mixing SSE and AVX instructions.
+	MOVD         AX, X0
+
 	// Create a mask in Y4 that converts text to upper case.
 	VPBROADCASTB X2, Y4 // space ' ' already stored in X2
-
-	MOVD         AX, X0
 	LEAQ         -32(SI)(BX*1), R11
 	VPBROADCASTB X0, Y1
 
@@ -1,10 +1,13 @@
 // Copyright 2023 Charlie Vieth. All rights reserved.
 // Use of this source code is governed by the MIT license.
 
-//go:build !s390x && !wasm && !ppc64 && !amd64 && !arm64
-// +build !s390x,!wasm,!ppc64,!amd64,!arm64
+//go:build !s390x && !wasm && !ppc64 && (purego || (!amd64 && !arm64 && !386))
+// +build !s390x
+// +build !wasm
+// +build !ppc64
+// +build purego !amd64,!arm64,!386
 
-// Simple implementations for arch's where the standard library does not
+// SWAR implementations for arch's where the standard library does not
 // appear to use SIMD for IndexByte.
 //
 // NOTE(cev): See the comment in indexbyte_simd.go for how the list of GOARCH
@@ -17,32 +20,16 @@ import (
 	"strings"
 )
 
-func isAlpha(c byte) bool {
-	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
-}
-
 func IndexByte(s []byte, c byte) int {
 	if !isAlpha(c) {
 		return bytes.IndexByte(s, c)
 	}
-	c |= ' '
-	for i, cc := range s {
-		if cc|' ' == c {
-			return i
-		}
-	}
-	return -1
+	return indexByteSWAR(s, c)
 }
 
 func IndexByteString(s string, c byte) int {
 	if !isAlpha(c) {
 		return strings.IndexByte(s, c)
 	}
-	c |= ' '
-	for i := 0; i < len(s); i++ {
-		if s[i]|' ' == c {
-			return i
-		}
-	}
-	return -1
+	return indexByteStringSWAR(s, c)
 }
@@ -22,10 +22,6 @@ import (
 	"strings"
 )
 
-func isAlpha(c byte) bool {
-	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
-}
-
 func IndexByte(s []byte, c byte) int {
 	if len(s) == 0 {
 		return -1
@@ -206,6 +206,20 @@ func TestIndexByteStringASCII(t *testing.T) {
 	})
 }
 
+func TestIndexByteSWAR(t *testing.T) {
+	testIndex(t, "indexByteSWAR", func(s string, c byte) int {
+		return indexByteSWAR([]byte(s), c)
+	})
+	testIndexByteASCII(t, "indexByteSWAR", indexByteSWAR)
+}
+
+func TestIndexByteStringSWAR(t *testing.T) {
+	testIndex(t, "indexByteStringSWAR", indexByteStringSWAR)
+	testIndexByteASCII(t, "indexByteStringSWAR", func(s []byte, c byte) int {
+		return indexByteStringSWAR(string(s), c)
+	})
+}
+
 func max(a, b int) int {
 	if a >= b {
 		return a
@@ -299,6 +313,12 @@ func TestIndexByteStringLimits(t *testing.T) {
 	testIndexByte(t, alphaUpper, "Digit", "IndexByteString", "1", fn)
 }
 
+func TestIndexByteSWARLimits(t *testing.T) {
+	testIndexByte(t, alphaLower, "Lower", "indexByteSWAR", "xX", indexByteSWAR)
+	testIndexByte(t, alphaUpper, "Upper", "indexByteSWAR", "xX", indexByteSWAR)
+	testIndexByte(t, alphaUpper, "Digit", "indexByteSWAR", "1", indexByteSWAR)
+}
+
 var bmbuf []byte
 
 func valName(x int) string {
@@ -333,6 +353,10 @@ func BenchmarkIndexByteStdLib(b *testing.B) {
 	benchBytes(b, indexSizes, bmIndexByte(bytes.IndexByte, false))
 }
 
+func BenchmarkIndexByteSWAR(b *testing.B) {
+	benchBytes(b, indexSizes, bmIndexByte(indexByteSWAR, true))
+}
+
 func bmIndexByte(index func([]byte, byte) int, caseless bool) func(b *testing.B, n int) {
 	return func(b *testing.B, n int) {
 		buf := bmbuf[0:n]
@@ -0,0 +1,149 @@
+//go:build amd64.v3 && go1.22 && !purego
+// +build amd64.v3,go1.22,!purego
+
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+// IndexByte for GOAMD64=v3 and above where AVX2 is always available so
+// there is no runtime dispatch and no SSE loop. The case-insensitive and
+// case-sensitive searches share the same code: the byte sought and each
+// block of data are ORed with a mask that is 0x20 if the byte sought is an
+// ASCII letter and 0 otherwise.
+//
+// Older toolchains use indexbyte_amd64.s, which includes asm_amd64.h so
+// its AVX2 check is also compiled out when GOAMD64=v3. IndexNonASCII has no
+// v3 variant and relies on asm_amd64.h in the same way.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// SETUP broadcasts the byte sought in AX to Y0 and the case mask to Y1
+// and converts the byte sought to lower case if it is an ASCII letter.
+// AX is an ASCII letter if (AX-'A')&^0x20 < 26.
+#define SETUP \
+	LEAL         -65(AX), CX \
+	ANDL         $~32, CX    \
+	CMPL         CX, $26     \
+	SBBL         DX, DX      \
+	ANDL         $32, DX     \
+	ORL          DX, AX      \
+	VMOVD        AX, X0      \
+	VPBROADCASTB X0, Y0      \
+	VMOVD        DX, X1      \
+	VPBROADCASTB X1, Y1
+
+// INDEXBYTE is the body of IndexByte and IndexByteString.
+//
+// input:
+//   SI: data
+//   BX: data len
+//   AX: byte sought
+//   R8: address to put result
+#define INDEXBYTE \
+	SETUP                                                    \
+	CMPQ      BX, $32                                        \
+	JB        small                                          \
+	MOVQ      SI, DI                                         \
+	LEAQ      -32(SI)(BX*1), R11 /* address of last 32 bytes */ \
+	PCALIGN   $32                                            \
+loop:                                                        \
+	VMOVDQU   (DI), Y2                                       \
+	VPOR      Y1, Y2, Y2                                     \
+	VPCMPEQB  Y0, Y2, Y2                                     \
+	VPMOVMSKB Y2, DX                                         \
+	TESTL     DX, DX                                         \
+	JNZ       success                                        \
+	ADDQ      $32, DI                                        \
+	CMPQ      DI, R11                                        \
+	JB        loop                                           \
+	/* Search the last (possibly overlapping) 32 bytes. */   \
+	MOVQ      R11, DI                                        \
+	VMOVDQU   (DI), Y2                                       \
+	VPOR      Y1, Y2, Y2                                     \
+	VPCMPEQB  Y0, Y2, Y2                                     \
+	VPMOVMSKB Y2, DX                                         \
+	TESTL     DX, DX                                         \
+	JNZ       success                                        \
+	VZEROUPPER                                               \
+	MOVQ      $-1, (R8)                                      \
+	RET                                                      \
+success:                                                     \
+	VZEROUPPER                                               \
+	BSFL      DX, DX                                         \
+	SUBQ      SI, DI                                         \
+	ADDQ      DI, DX                                         \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+small:                                                       \
+	CMPQ      BX, $16                                        \
+	JB        tiny                                           \
+	/* Search the first and last (possibly overlapping) */  \
+	/* 16 bytes. */                                          \
+	VMOVDQU   (SI), X2                                       \
+	VMOVDQU   -16(SI)(BX*1), X3                              \
+	VPOR      X1, X2, X2                                     \
+	VPOR      X1, X3, X3                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPCMPEQB  X0, X3, X3                                     \
+	VPMOVMSKB X2, DX                                         \
+	VPMOVMSKB X3, CX                                         \
+	VZEROUPPER                                               \
+	BSFL      DX, DX                                         \
+	JNZ       smallsuccess                                   \
+	BSFL      CX, DX                                         \
+	JZ        failure                                        \
+	LEAQ      -16(BX)(DX*1), DX                              \
+smallsuccess:                                                \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+tiny:                                                        \
+	TESTQ     BX, BX                                         \
+	JEQ       tinyfailure                                    \
+	/* Check if we'll load across a page boundary. */       \
+	LEAQ      16(SI), CX                                     \
+	TESTW     $0xff0, CX                                     \
+	JEQ       endofpage                                      \
+	VMOVDQU   (SI), X2                                       \
+	VPOR      X1, X2, X2                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPMOVMSKB X2, DX                                         \
+	VZEROUPPER                                               \
+	BSFL      DX, DX                                         \
+	JZ        failure                                        \
+	CMPL      DX, BX                                         \
+	JAE       failure /* Match is past end of data. */       \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+endofpage:                                                   \
+	/* Load data into the high end of X2. */                \
+	VMOVDQU   -16(SI)(BX*1), X2                              \
+	VPOR      X1, X2, X2                                     \
+	VPCMPEQB  X0, X2, X2                                     \
+	VPMOVMSKB X2, DX                                         \
+	VZEROUPPER                                               \
+	MOVL      BX, CX                                         \
+	SHLL      CX, DX                                         \
+	SHRL      $16, DX /* Shift desired bits down. */         \
+	BSFL      DX, DX                                         \
+	JZ        failure                                        \
+	MOVQ      DX, (R8)                                       \
+	RET                                                      \
+tinyfailure:                                                 \
+	VZEROUPPER                                               \
+failure:                                                     \
+	MOVQ      $-1, (R8)                                      \
+	RET
+
+TEXT ·IndexByte(SB), NOSPLIT, $0-40
+	MOVQ    b_base+0(FP), SI
+	MOVQ    b_len+8(FP), BX
+	MOVBLZX c+24(FP), AX
+	LEAQ    ret+32(FP), R8
+	INDEXBYTE
+
+TEXT ·IndexByteString(SB), NOSPLIT, $0-32
+	MOVQ    s_base+0(FP), SI
+	MOVQ    s_len+8(FP), BX
+	MOVBLZX c+16(FP), AX
+	LEAQ    ret+24(FP), R8
+	INDEXBYTE
@@ -0,0 +1,187 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "go_asm.h"
+#include "asm_amd64.h"
+#include "textflag.h"
+
+TEXT ·LastIndexByte(SB), NOSPLIT, $0-40
+	MOVQ    b_base+0(FP), SI
+	MOVQ    b_len+8(FP), BX
+	MOVBLZX c+24(FP), AX
+	LEAQ    ret+32(FP), R8
+	JMP     lastindexbytebody<>(SB)
+
+TEXT ·LastIndexByteString(SB), NOSPLIT, $0-32
+	MOVQ    s_base+0(FP), SI
+	MOVQ    s_len+8(FP), BX
+	MOVBLZX c+16(FP), AX
+	LEAQ    ret+24(FP), R8
+	JMP     lastindexbytebody<>(SB)
+
+// lastindexbytebody is the reverse of indexbytebodyCase. The case of the
+// data is ignored if the byte being sought is an ASCII letter.
+//
+// input:
+//   SI: data
+//   BX: data len
+//   AX: byte sought
+//   R8: address to put result
+TEXT lastindexbytebody<>(SB), NOSPLIT, $0
+	// Set CX to ' ' if the byte being sought is a letter and 0 otherwise,
+	// then convert it to lowercase.
+	MOVL AX, CX
+	ORL  $32, CX
+	SUBL $97, CX
+	CMPL CX, $26
+	SBBL CX, CX
+	ANDL $32, CX
+	ORL  CX, AX
+
+	// Shuffle X0 around so that each byte contains
+	// the character we're looking for.
+	MOVD      AX, X0
+	PUNPCKLBW X0, X0
+	PUNPCKLBW X0, X0
+	PSHUFL    $0, X0, X0
+
+	// Add the case mask to X2
+	MOVD      CX, X2
+	PUNPCKLBW X2, X2
+	PUNPCKLBW X2, X2
+	PSHUFL    $0, X2, X2
+
+	CMPQ BX, $16
+	JLT  small
+
+	CMPQ BX, $32
+	JA   avx2
+
+sse:
+	LEAQ -16(SI)(BX*1), DI // DI = address of last 16 bytes
+
+sseloop:
+	// Move the next 16-byte chunk of the data into X1.
+	MOVOU (DI), X1
+
+	// Logical OR to convert data to lowercase
+	POR X2, X1
+
+	// Compare bytes in X0 to X1.
+	PCMPEQB X0, X1
+
+	// Take the top bit of each byte in X1 and put the result in DX.
+	PMOVMSKB X1, DX
+
+	// Find last set bit, if any.
+	BSRL DX, DX
+	JNZ  ssesuccess
+
+	// Advance to the previous chunk.
+	SUBQ $16, DI
+	CMPQ DI, SI
+	JA   sseloop
+
+	// Search the first 16-byte chunk. This chunk may overlap with the
+	// chunks we've already searched, but that's ok.
+	MOVQ     SI, DI
+	MOVOU    (DI), X1
+	POR      X2, X1
+	PCMPEQB  X0, X1
+	PMOVMSKB X1, DX
+	BSRL     DX, DX
+	JNZ      ssesuccess
+
+failure:
+	MOVQ $-1, (R8)
+	RET
+
+// We've found a chunk containing the byte.
+// The chunk was loaded from DI.
+// The index of the matching byte in the chunk is DX.
+// The start of the data is SI.
+ssesuccess:
+	SUBQ SI, DI   // Compute offset of chunk within data.
+	ADDQ DX, DI   // Add offset of byte within chunk.
+	MOVQ DI, (R8)
+	RET
+
+// handle for lengths < 16
+small:
+	TESTQ BX, BX
+	JEQ   failure
+
+	// Check if we'll load across a page boundary.
+	LEAQ  16(SI), AX
+	TESTW $0xff0, AX
+	JEQ   endofpage
+
+	MOVOU    (SI), X1 // Load data
+	POR      X2, X1   // Convert data to lowercase
+	PCMPEQB  X0, X1   // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX   // Move result bits to integer register.
+	MOVL     $1, AX
+	MOVL     BX, CX
+	SHLL     CX, AX
+	DECL     AX
+	ANDL     AX, DX   // Clear the bits past the end of data.
+	BSRL     DX, DX   // Find last set bit.
+	JZ       failure  // No set bit, failure.
+	MOVQ     DX, (R8)
+	RET
+
+endofpage:
+	MOVOU    -16(SI)(BX*1), X1 // Load data into the high end of X1.
+	POR      X2, X1            // Convert data to lowercase
+	PCMPEQB  X0, X1            // Compare target byte with each byte in data.
+	PMOVMSKB X1, DX            // Move result bits to integer register.
+	MOVL     $16, CX
+	SUBL     BX, CX
+	SHRL     CX, DX            // Shift desired bits down to bottom of register.
+	BSRL     DX, DX            // Find last set bit.
+	JZ       failure           // No set bit, failure.
+	MOVQ     DX, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	VPBROADCASTB X0, Y1
+	VPBROADCASTB X2, Y4 // case mask
+	LEAQ         -32(SI)(BX*1), DI
+
+avx2_loop:
+	VMOVDQU   (DI), Y2
+	VPOR      Y4, Y2, Y2 // Convert data to lowercase
+	VPCMPEQB  Y1, Y2, Y3
+	VPMOVMSKB Y3, DX
+	BSRL      DX, DX
+	JNZ       avx2success
+	SUBQ      $32, DI
+	CMPQ      DI, SI
+	JA        avx2_loop
+
+	// Search the first (possibly overlapping) 32 bytes.
+	MOVQ      SI, DI
+	VMOVDQU   (DI), Y2
+	VPOR      Y4, Y2, Y2
+	VPCMPEQB  Y1, Y2, Y3
+	VPMOVMSKB Y3, DX
+	BSRL      DX, DX
+	JNZ       avx2success
+	VZEROUPPER
+	MOVQ      $-1, (R8)
+	RET
+
+avx2success:
+	SUBQ SI, DI
+	ADDQ DI, DX
+	MOVQ DX, (R8)
+	VZEROUPPER
+	RET
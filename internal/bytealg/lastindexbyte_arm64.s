@@ -0,0 +1,135 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+TEXT ·LastIndexByte(SB), NOSPLIT, $0-40
+	MOVD  b_base+0(FP), R0
+	MOVD  b_len+8(FP), R2
+	MOVBU c+24(FP), R1
+	MOVD  $ret+32(FP), R8
+	B     lastindexbytebody<>(SB)
+
+TEXT ·LastIndexByteString(SB), NOSPLIT, $0-32
+	MOVD  s_base+0(FP), R0
+	MOVD  s_len+8(FP), R2
+	MOVBU c+16(FP), R1
+	MOVD  $ret+24(FP), R8
+	B     lastindexbytebody<>(SB)
+
+// lastindexbytebody is the reverse of indexbytebodyCase. The case of the
+// data is ignored if the byte being sought is an ASCII letter.
+//
+// input:
+//   R0: data
+//   R1: byte to search
+//   R2: data len
+//   R8: address to put result
+TEXT lastindexbytebody<>(SB), NOSPLIT, $0
+	// Core algorithm:
+	// For each 32-byte chunk we calculate a 64-bit syndrome value,
+	// with two bits per byte. For each tuple, bit 0 is set if the
+	// relevant byte matched the requested character and bit 1 is
+	// not used. Since the bits in the syndrome reflect exactly the
+	// order in which things occur in the original string, counting
+	// leading zeros allows to identify exactly which byte has matched
+	// last.
+
+	CBZ R2, fail
+
+	// Set R3 to 0x20 if the byte being sought is a letter and 0
+	// otherwise, then convert it to lowercase.
+	ORR  $32, R1, R3
+	SUB  $97, R3, R3
+	CMP  $26, R3
+	CSET LO, R3
+	LSL  $5, R3, R3
+	ORR  R3, R1, R1
+
+	VMOV R1, V0.B16
+	VMOV R3, V7.B16 // Bit mask to convert upper-case chars to lower-case.
+
+	// Magic constant 0x40100401 allows us to identify
+	// which lane matches the requested byte.
+	// 0x40100401 = ((1<<0) + (4<<8) + (16<<16) + (64<<24))
+	// Different bytes have different bit masks (i.e: 1, 4, 16, 64)
+	MOVD $0x40100401, R5
+	VMOV R5, V5.S4
+
+	// R4 = end of the data
+	ADD R0, R2, R4
+
+	CMP $32, R2
+	BLO small
+
+loop:
+	SUB $0x20, R4, R4
+	CMP R0, R4
+	BLO first
+	VLD1  (R4), [V1.B16, V2.B16]
+	VORR  V7.B16, V1.B16, V1.B16 // Convert to lowercase
+	VORR  V7.B16, V2.B16, V2.B16 // Convert to lowercase
+	VCMEQ V0.B16, V1.B16, V3.B16
+	VCMEQ V0.B16, V2.B16, V4.B16
+
+	// Use a fast check for the termination condition
+	VORR  V4.B16, V3.B16, V6.B16
+	VADDP V6.D2, V6.D2, V6.D2
+	VMOV  V6.D[0], R6
+
+	// Loop if we haven't found the character
+	CBZ R6, loop
+	B   end
+
+first:
+	// Search the first 32 bytes. This block may overlap with the
+	// blocks we've already searched, but that's ok.
+	MOVD  R0, R4
+	VLD1  (R4), [V1.B16, V2.B16]
+	VORR  V7.B16, V1.B16, V1.B16
+	VORR  V7.B16, V2.B16, V2.B16
+	VCMEQ V0.B16, V1.B16, V3.B16
+	VCMEQ V0.B16, V2.B16, V4.B16
+
+end:
+	// Termination condition found, let's calculate the syndrome value
+	VAND  V5.B16, V3.B16, V3.B16
+	VAND  V5.B16, V4.B16, V4.B16
+	VADDP V4.B16, V3.B16, V6.B16
+	VADDP V6.B16, V6.B16, V6.B16
+	VMOV  V6.D[0], R6
+	CBZ   R6, fail
+
+	// Compute the index of the highest set bit, which is
+	// twice the offset into the block.
+	CLZ  R6, R6
+	MOVD $63, R7
+	SUB  R6, R7, R6
+	ADD  R6>>1, R4, R4
+
+	// Compute the offset result
+	SUB  R0, R4, R4
+	MOVD R4, (R8)
+	RET
+
+// handle lengths < 32 a byte at a time
+small:
+	MOVBU.W -1(R4), R6
+	ORR     R3, R6, R6 // Convert to lowercase
+	CMP     R1, R6
+	BEQ     found
+	CMP     R0, R4
+	BHI     small
+
+fail:
+	MOVD $-1, R0
+	MOVD R0, (R8)
+	RET
+
+found:
+	SUB  R0, R4, R4
+	MOVD R4, (R8)
+	RET
@@ -0,0 +1,38 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build purego || (!amd64 && !arm64)
+// +build purego !amd64,!arm64
+
+package bytealg
+
+import (
+	"bytes"
+	"strings"
+)
+
+func LastIndexByte(s []byte, c byte) int {
+	if !isAlpha(c) {
+		return bytes.LastIndexByte(s, c)
+	}
+	c |= ' '
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i]|' ' == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func LastIndexByteString(s string, c byte) int {
+	if !isAlpha(c) {
+		return strings.LastIndexByte(s, c)
+	}
+	c |= ' '
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i]|' ' == c {
+			return i
+		}
+	}
+	return -1
+}
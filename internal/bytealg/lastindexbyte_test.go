@@ -0,0 +1,128 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// lastIndexByteReference is a naive implementation of LastIndexByteString.
+func lastIndexByteReference(s string, c byte) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == c || isAlphaPortable(c) && s[i]|' ' == c|' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+func testLastIndex(t *testing.T, name string, fn func(s string, c byte) int) {
+	for _, tt := range indexTests {
+		if len(tt.sep) == 0 {
+			continue
+		}
+		for _, s := range []string{tt.s, strings.ToUpper(tt.s), tt.s + tt.s + tt.s} {
+			for _, c := range []byte{tt.sep[0], tt.sep[0] ^ ' ', 'X', 'x'} {
+				want := lastIndexByteReference(s, c)
+				if got := fn(s, c); got != want {
+					t.Errorf(`%s(%q, %q) = %v; want %v`, name, s, c, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestLastIndexByte(t *testing.T) {
+	testLastIndex(t, "LastIndexByte", func(s string, c byte) int {
+		return LastIndexByte([]byte(s), c)
+	})
+}
+
+func TestLastIndexByteString(t *testing.T) {
+	testLastIndex(t, "LastIndexByteString", LastIndexByteString)
+}
+
+func TestLastIndexByteRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	const chars = "abcABC@[`{\x00\x80\xc1\xe1\xff"
+	buf := make([]byte, 256)
+	for i := 0; i < 5000; i++ {
+		b := buf[:rr.Intn(len(buf))]
+		for j := range b {
+			b[j] = chars[rr.Intn(len(chars))]
+		}
+		c := chars[rr.Intn(len(chars))]
+		// Test all offsets to exercise the alignment of the blocks
+		for j := 0; j < len(b) && j < 40; j++ {
+			s := string(b[j:])
+			want := lastIndexByteReference(s, c)
+			if got := LastIndexByteString(s, c); got != want {
+				t.Fatalf("LastIndexByteString(%q, %q) = %d; want: %d", s, c, got, want)
+			}
+			if got := LastIndexByte(b[j:], c); got != want {
+				t.Fatalf("LastIndexByte(%q, %q) = %d; want: %d", b[j:], c, got, want)
+			}
+		}
+	}
+}
+
+// Test short inputs that start on either side of a page boundary.
+func TestLastIndexByteSmall(t *testing.T) {
+	pagesize := os.Getpagesize()
+	buf := bytes.Repeat([]byte("-"), pagesize*3)
+	// Offset of the first page boundary in buf
+	off := pagesize - int(uintptr(unsafe.Pointer(&buf[0]))%uintptr(pagesize))
+	for start := off + pagesize - 32; start < off+pagesize+16; start++ {
+		for n := 1; n < 16; n++ {
+			b := buf[start : start+n]
+			for i := range b {
+				for _, c := range []byte{'x', 'X', '1'} {
+					b[i] = c
+					if got := LastIndexByte(b, c); got != i {
+						t.Fatalf("LastIndexByte(%q, %q) = %d; want: %d", b, c, got, i)
+					}
+					if got := LastIndexByteString(string(b), c|' '); got != i {
+						t.Fatalf("LastIndexByteString(%q, %q) = %d; want: %d", b, c|' ', got, i)
+					}
+					b[i] = '-'
+				}
+			}
+			if got := LastIndexByte(b, 'x'); got != -1 {
+				t.Fatalf("LastIndexByte(%q, %q) = %d; want: %d", b, 'x', got, -1)
+			}
+		}
+	}
+}
+
+func BenchmarkLastIndexByte(b *testing.B) {
+	benchBytes(b, indexSizes, bmLastIndexByte(LastIndexByte, true))
+}
+
+func BenchmarkLastIndexByteStdLib(b *testing.B) {
+	benchBytes(b, indexSizes, bmLastIndexByte(bytes.LastIndexByte, false))
+}
+
+func bmLastIndexByte(index func([]byte, byte) int, caseless bool) func(b *testing.B, n int) {
+	return func(b *testing.B, n int) {
+		buf := bmbuf[0:n]
+		buf[0] = 'x'
+		ch := byte('x')
+		if caseless {
+			ch = 'X'
+		}
+		for i := 0; i < b.N; i++ {
+			j := index(buf, ch) // Search for uppercase variant
+			if j != 0 {
+				b.Fatal("bad index", j)
+			}
+		}
+		buf[0] = '\x00'
+	}
+}
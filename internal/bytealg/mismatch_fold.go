@@ -0,0 +1,65 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+// The MismatchFold and LastMismatchFold functions compare a with the first
+// len(a) bytes of b, ignoring ASCII case. The length of b must be at least
+// the length of a. MismatchFold returns the index of the first byte that
+// differs or is not ASCII in either a or b, or len(a) if there is no such
+// byte. LastMismatchFold returns the index of the last such byte, or -1 if
+// there is no such byte.
+//
+// They are used to skip over the part of two strings that is equal under
+// ASCII case folding before handing off to the (much slower) Unicode aware
+// comparison. The assembly implementations compare 16 or 32 bytes at a time.
+
+// equalFoldASCII reports if x and y are ASCII and equal ignoring case.
+func equalFoldASCII(x, y byte) bool {
+	return (x|y) < 0x80 && toLowerASCII(x) == toLowerASCII(y)
+}
+
+// mismatchFoldGeneric is the generic implementation of MismatchFold.
+func mismatchFoldGeneric(a, b []byte) int {
+	b = b[:len(a)]
+	for i := 0; i < len(a); i++ {
+		if !equalFoldASCII(a[i], b[i]) {
+			return i
+		}
+	}
+	return len(a)
+}
+
+// mismatchFoldGenericString is the generic implementation of MismatchFoldString.
+func mismatchFoldGenericString(a, b string) int {
+	b = b[:len(a)]
+	for i := 0; i < len(a); i++ {
+		if !equalFoldASCII(a[i], b[i]) {
+			return i
+		}
+	}
+	return len(a)
+}
+
+// lastMismatchFoldGeneric is the generic implementation of LastMismatchFold.
+func lastMismatchFoldGeneric(a, b []byte) int {
+	b = b[:len(a)]
+	for i := len(a) - 1; i >= 0; i-- {
+		if !equalFoldASCII(a[i], b[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastMismatchFoldGenericString is the generic implementation of
+// LastMismatchFoldString.
+func lastMismatchFoldGenericString(a, b string) int {
+	b = b[:len(a)]
+	for i := len(a) - 1; i >= 0; i-- {
+		if !equalFoldASCII(a[i], b[i]) {
+			return i
+		}
+	}
+	return -1
+}
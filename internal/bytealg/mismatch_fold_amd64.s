@@ -0,0 +1,304 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "go_asm.h"
+#include "asm_amd64.h"
+#include "textflag.h"
+
+// Letters are folded to lower case by adding 0x25 to each byte, which maps
+// 'A'..'Z' to the signed range 102..127, and then setting bit 0x20 of each
+// byte that is greater than 101.
+
+// BLOCK_SSE sets the bits of DX that correspond to the 16 bytes at offset CX
+// that differ, ignoring case, or are not ASCII. It sets the zero flag if
+// there are no such bytes.
+#define BLOCK_SSE \
+	MOVOU    (SI)(CX*1), X0 \
+	MOVOU    (DI)(CX*1), X1 \
+	MOVOU    X0, X2         \
+	POR      X1, X2         \
+	MOVOU    X0, X3         \
+	PADDB    X10, X3        \
+	PCMPGTB  X11, X3        \
+	PAND     X12, X3        \
+	POR      X3, X0         \
+	MOVOU    X1, X4         \
+	PADDB    X10, X4        \
+	PCMPGTB  X11, X4        \
+	PAND     X12, X4        \
+	POR      X4, X1         \
+	PCMPEQB  X1, X0         \
+	PANDN    X0, X2         \
+	PMOVMSKB X2, DX         \
+	XORL     $0xffff, DX
+
+// BLOCK_AVX2 is like BLOCK_SSE but checks 32 bytes.
+#define BLOCK_AVX2 \
+	VMOVDQU   (SI)(CX*1), Y0 \
+	VMOVDQU   (DI)(CX*1), Y1 \
+	VPOR      Y1, Y0, Y2     \
+	VPADDB    Y10, Y0, Y3    \
+	VPCMPGTB  Y11, Y3, Y3    \
+	VPAND     Y12, Y3, Y3    \
+	VPOR      Y3, Y0, Y0     \
+	VPADDB    Y10, Y1, Y4    \
+	VPCMPGTB  Y11, Y4, Y4    \
+	VPAND     Y12, Y4, Y4    \
+	VPOR      Y4, Y1, Y1     \
+	VPCMPEQB  Y1, Y0, Y0     \
+	VPANDN    Y0, Y2, Y2     \
+	VPMOVMSKB Y2, DX         \
+	NOTL      DX             \
+	TESTL     DX, DX
+
+// SETUP loads the constants used to fold letters to lower case.
+#define SETUP \
+	MOVL   $0x25252525, AX \
+	MOVD   AX, X10         \
+	PSHUFL $0, X10, X10    \
+	MOVL   $0x65656565, AX \
+	MOVD   AX, X11         \
+	PSHUFL $0, X11, X11    \
+	MOVL   $0x20202020, AX \
+	MOVD   AX, X12         \
+	PSHUFL $0, X12, X12
+
+// SETUP_AVX2 broadcasts the constants loaded by SETUP to the Y registers.
+#define SETUP_AVX2 \
+	VPBROADCASTB X10, Y10 \
+	VPBROADCASTB X11, Y11 \
+	VPBROADCASTB X12, Y12
+
+// func MismatchFold(a, b []byte) int
+TEXT ·MismatchFold(SB), NOSPLIT, $0-56
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), BX
+	MOVQ b_base+24(FP), DI
+	LEAQ ret+48(FP), R8
+	JMP  mismatchFoldBody<>(SB)
+
+// func MismatchFoldString(a, b string) int
+TEXT ·MismatchFoldString(SB), NOSPLIT, $0-40
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), BX
+	MOVQ b_base+16(FP), DI
+	LEAQ ret+32(FP), R8
+	JMP  mismatchFoldBody<>(SB)
+
+// input:
+//   SI: a
+//   DI: b
+//   BX: length of a and b
+//   R8: address to put result
+TEXT mismatchFoldBody<>(SB), NOSPLIT, $0
+	XORQ CX, CX
+	CMPQ BX, $16
+	JB   small
+
+	SETUP
+
+	CMPQ BX, $32
+	JA   avx2
+
+sse:
+	LEAQ -16(BX), R9 // R9 = offset of the last 16 bytes
+
+sseloop:
+	CMPQ CX, R9
+	JAE  sselast
+	BLOCK_SSE
+	JNZ  found
+	ADDQ $16, CX
+	JMP  sseloop
+
+sselast:
+	// Check the last 16 bytes. This block may overlap with the bytes
+	// we've already checked, but that's ok since they are all equal.
+	MOVQ R9, CX
+	BLOCK_SSE
+	JNZ  found
+	MOVQ BX, (R8)
+	RET
+
+// The block was loaded from offset CX and the bits of DX are the bytes
+// that differ.
+found:
+	BSFL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, (R8)
+	RET
+
+// handle lengths < 16 a byte at a time
+small:
+	CMPQ    CX, BX
+	JAE     smallret
+	MOVBLZX (SI)(CX*1), AX
+	MOVBLZX (DI)(CX*1), DX
+	MOVL    AX, R9
+	ORL     DX, R9
+	TESTL   $0x80, R9
+	JNZ     smallret
+	XORL    AX, DX
+	JZ      smallnext
+	CMPL    DX, $0x20
+	JNE     smallret
+	ORL     $0x20, AX
+	SUBL    $0x61, AX
+	CMPL    AX, $25
+	JA      smallret
+
+smallnext:
+	INCQ CX
+	JMP  small
+
+smallret:
+	MOVQ CX, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	SETUP_AVX2
+	LEAQ -32(BX), R9 // R9 = offset of the last 32 bytes
+
+avx2loop:
+	CMPQ CX, R9
+	JAE  avx2last
+	BLOCK_AVX2
+	JNZ  avx2found
+	ADDQ $32, CX
+	JMP  avx2loop
+
+avx2last:
+	// Check the last (possibly overlapping) 32 bytes.
+	MOVQ       R9, CX
+	BLOCK_AVX2
+	JNZ        avx2found
+	VZEROUPPER
+	MOVQ       BX, (R8)
+	RET
+
+avx2found:
+	VZEROUPPER
+	JMP found
+
+// func LastMismatchFold(a, b []byte) int
+TEXT ·LastMismatchFold(SB), NOSPLIT, $0-56
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), BX
+	MOVQ b_base+24(FP), DI
+	LEAQ ret+48(FP), R8
+	JMP  lastMismatchFoldBody<>(SB)
+
+// func LastMismatchFoldString(a, b string) int
+TEXT ·LastMismatchFoldString(SB), NOSPLIT, $0-40
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), BX
+	MOVQ b_base+16(FP), DI
+	LEAQ ret+32(FP), R8
+	JMP  lastMismatchFoldBody<>(SB)
+
+// lastMismatchFoldBody is the reverse of mismatchFoldBody.
+//
+// input:
+//   SI: a
+//   DI: b
+//   BX: length of a and b
+//   R8: address to put result
+TEXT lastMismatchFoldBody<>(SB), NOSPLIT, $0
+	CMPQ BX, $16
+	JB   small
+
+	SETUP
+
+	CMPQ BX, $32
+	JA   avx2
+
+sse:
+	LEAQ -16(BX), CX // CX = offset of the last 16 bytes
+
+sseloop:
+	BLOCK_SSE
+	JNZ   found
+	TESTQ CX, CX
+	JZ    failure
+	SUBQ  $16, CX
+	JCC   sseloop
+
+	// Check the first 16 bytes. This block may overlap with the bytes
+	// we've already checked, but that's ok since they are all equal.
+	XORQ CX, CX
+	JMP  sseloop
+
+// The block was loaded from offset CX and the bits of DX are the bytes
+// that differ.
+found:
+	BSRL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, (R8)
+	RET
+
+failure:
+	MOVQ $-1, (R8)
+	RET
+
+// handle lengths < 16 a byte at a time
+small:
+	MOVQ BX, CX
+
+smallloop:
+	DECQ    CX
+	JS      failure
+	MOVBLZX (SI)(CX*1), AX
+	MOVBLZX (DI)(CX*1), DX
+	MOVL    AX, R9
+	ORL     DX, R9
+	TESTL   $0x80, R9
+	JNZ     smallret
+	XORL    AX, DX
+	JZ      smallloop
+	CMPL    DX, $0x20
+	JNE     smallret
+	ORL     $0x20, AX
+	SUBL    $0x61, AX
+	CMPL    AX, $25
+	JBE     smallloop
+
+smallret:
+	MOVQ CX, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	SETUP_AVX2
+	LEAQ -32(BX), CX // CX = offset of the last 32 bytes
+
+avx2loop:
+	BLOCK_AVX2
+	JNZ   avx2found
+	TESTQ CX, CX
+	JZ    avx2failure
+	SUBQ  $32, CX
+	JCC   avx2loop
+
+	// Check the first (possibly overlapping) 32 bytes.
+	XORQ CX, CX
+	JMP  avx2loop
+
+avx2found:
+	VZEROUPPER
+	JMP found
+
+avx2failure:
+	VZEROUPPER
+	JMP failure
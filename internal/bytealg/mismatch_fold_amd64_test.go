@@ -0,0 +1,22 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+package bytealg
+
+import (
+	"testing"
+
+	"golang.org/x/sys/cpu"
+)
+
+// Test the SSE fallback of the amd64 implementation.
+func TestMismatchFoldFallback(t *testing.T) {
+	avx2 := cpu.X86.HasAVX2
+	t.Cleanup(func() { cpu.X86.HasAVX2 = avx2 })
+
+	cpu.X86.HasAVX2 = false
+	t.Run("SSE", TestMismatchFoldRandom)
+}
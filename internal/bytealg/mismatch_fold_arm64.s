@@ -0,0 +1,254 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// Letters are folded to lower case by subtracting 'A' from each byte and
+// setting bit 0x20 of each byte where the result is at most 25.
+
+// BLOCK sets each byte of V16 (V17) to 0xff if the corresponding bytes of
+// V0 and V2 (V1 and V3) differ, ignoring case, or are not ASCII and sets R6
+// to a non-zero value if there are any such bytes.
+#define BLOCK \
+	VORR   V0.B16, V2.B16, V16.B16   \
+	VAND   V23.B16, V16.B16, V16.B16 \
+	VORR   V1.B16, V3.B16, V17.B16   \
+	VAND   V23.B16, V17.B16, V17.B16 \
+	VSUB   V20.B16, V0.B16, V18.B16  \
+	VUMIN  V21.B16, V18.B16, V19.B16 \
+	VCMEQ  V18.B16, V19.B16, V19.B16 \
+	VAND   V22.B16, V19.B16, V19.B16 \
+	VORR   V19.B16, V0.B16, V0.B16   \
+	VSUB   V20.B16, V1.B16, V18.B16  \
+	VUMIN  V21.B16, V18.B16, V19.B16 \
+	VCMEQ  V18.B16, V19.B16, V19.B16 \
+	VAND   V22.B16, V19.B16, V19.B16 \
+	VORR   V19.B16, V1.B16, V1.B16   \
+	VSUB   V20.B16, V2.B16, V18.B16  \
+	VUMIN  V21.B16, V18.B16, V19.B16 \
+	VCMEQ  V18.B16, V19.B16, V19.B16 \
+	VAND   V22.B16, V19.B16, V19.B16 \
+	VORR   V19.B16, V2.B16, V2.B16   \
+	VSUB   V20.B16, V3.B16, V18.B16  \
+	VUMIN  V21.B16, V18.B16, V19.B16 \
+	VCMEQ  V18.B16, V19.B16, V19.B16 \
+	VAND   V22.B16, V19.B16, V19.B16 \
+	VORR   V19.B16, V3.B16, V3.B16   \
+	VEOR   V0.B16, V2.B16, V18.B16   \
+	VORR   V18.B16, V16.B16, V16.B16 \
+	VCMTST V16.B16, V16.B16, V16.B16 \
+	VEOR   V1.B16, V3.B16, V19.B16   \
+	VORR   V19.B16, V17.B16, V17.B16 \
+	VCMTST V17.B16, V17.B16, V17.B16 \
+	VORR   V16.B16, V17.B16, V18.B16 \
+	VADDP  V18.D2, V18.D2, V18.D2    \
+	VMOV   V18.D[0], R6
+
+// SYNDROME sets R6 to the syndrome of V16 and V17. Bit 2*i of R6 is set if
+// byte i of the block differs.
+#define SYNDROME \
+	VAND  V5.B16, V16.B16, V16.B16  \
+	VAND  V5.B16, V17.B16, V17.B16  \
+	VADDP V17.B16, V16.B16, V18.B16 \
+	VADDP V18.B16, V18.B16, V18.B16 \
+	VMOV  V18.D[0], R6
+
+// SETUP loads the constants used to fold letters to lower case.
+#define SETUP \
+	MOVD $0x41, R4       \
+	VDUP R4, V20.B16     \
+	MOVD $25, R4         \
+	VDUP R4, V21.B16     \
+	MOVD $0x20, R4       \
+	VDUP R4, V22.B16     \
+	MOVD $0x80, R4       \
+	VDUP R4, V23.B16     \
+	MOVD $0x40100401, R4 \
+	VMOV R4, V5.S4
+
+// LOAD loads the 32 bytes of a and b at offset R3.
+#define LOAD \
+	ADD  R0, R3, R4               \
+	ADD  R1, R3, R5               \
+	VLD1 (R4), [V0.B16, V1.B16] \
+	VLD1 (R5), [V2.B16, V3.B16]
+
+// func MismatchFold(a, b []byte) int
+TEXT ·MismatchFold(SB), NOSPLIT, $0-56
+	MOVD a_base+0(FP), R0
+	MOVD a_len+8(FP), R2
+	MOVD b_base+24(FP), R1
+	MOVD $ret+48(FP), R8
+	B    mismatchFoldBody<>(SB)
+
+// func MismatchFoldString(a, b string) int
+TEXT ·MismatchFoldString(SB), NOSPLIT, $0-40
+	MOVD a_base+0(FP), R0
+	MOVD a_len+8(FP), R2
+	MOVD b_base+16(FP), R1
+	MOVD $ret+32(FP), R8
+	B    mismatchFoldBody<>(SB)
+
+// input:
+//   R0: a
+//   R1: b
+//   R2: length of a and b
+//   R8: address to put result
+TEXT mismatchFoldBody<>(SB), NOSPLIT, $0
+	MOVD $0, R3
+	CMP  $32, R2
+	BLO  small
+
+	SETUP
+
+	// R9 = offset of the last 32 bytes
+	SUB $32, R2, R9
+
+loop:
+	CMP  R9, R3
+	BHS  last
+	LOAD
+	BLOCK
+	CBNZ R6, found
+	ADD  $32, R3, R3
+	B    loop
+
+last:
+	// Check the last 32 bytes. This block may overlap with the bytes
+	// we've already checked, but that's ok since they are all equal.
+	MOVD R9, R3
+	LOAD
+	BLOCK
+	CBNZ R6, found
+	MOVD R2, (R8)
+	RET
+
+// The block was loaded from offset R3.
+found:
+	SYNDROME
+
+	// Count the trailing zeros using bit reversing
+	RBIT R6, R6
+	CLZ  R6, R6
+
+	// R6 is twice the offset into the block
+	ADD  R6>>1, R3, R3
+	MOVD R3, (R8)
+	RET
+
+// handle lengths < 32 a byte at a time
+small:
+	CMP   R2, R3
+	BHS   smallret
+	MOVBU (R0)(R3), R4
+	MOVBU (R1)(R3), R5
+	ORR   R4, R5, R6
+	TST   $0x80, R6
+	BNE   smallret
+	EOR   R4, R5, R6
+	CBZ   R6, smallnext
+	CMP   $0x20, R6
+	BNE   smallret
+	ORR   $0x20, R4, R4
+	SUB   $0x61, R4, R4
+	CMP   $25, R4
+	BHI   smallret
+
+smallnext:
+	ADD $1, R3, R3
+	B   small
+
+smallret:
+	MOVD R3, (R8)
+	RET
+
+// func LastMismatchFold(a, b []byte) int
+TEXT ·LastMismatchFold(SB), NOSPLIT, $0-56
+	MOVD a_base+0(FP), R0
+	MOVD a_len+8(FP), R2
+	MOVD b_base+24(FP), R1
+	MOVD $ret+48(FP), R8
+	B    lastMismatchFoldBody<>(SB)
+
+// func LastMismatchFoldString(a, b string) int
+TEXT ·LastMismatchFoldString(SB), NOSPLIT, $0-40
+	MOVD a_base+0(FP), R0
+	MOVD a_len+8(FP), R2
+	MOVD b_base+16(FP), R1
+	MOVD $ret+32(FP), R8
+	B    lastMismatchFoldBody<>(SB)
+
+// lastMismatchFoldBody is the reverse of mismatchFoldBody.
+//
+// input:
+//   R0: a
+//   R1: b
+//   R2: length of a and b
+//   R8: address to put result
+TEXT lastMismatchFoldBody<>(SB), NOSPLIT, $0
+	CMP $32, R2
+	BLO small
+
+	SETUP
+
+	// R3 = offset of the last 32 bytes
+	SUB $32, R2, R3
+
+loop:
+	LOAD
+	BLOCK
+	CBNZ R6, found
+	CBZ  R3, fail
+	SUBS $32, R3, R3
+	BHS  loop
+
+	// Check the first 32 bytes. This block may overlap with the bytes
+	// we've already checked, but that's ok since they are all equal.
+	MOVD $0, R3
+	B    loop
+
+// The block was loaded from offset R3.
+found:
+	SYNDROME
+
+	// Compute the index of the highest set bit, which is
+	// twice the offset into the block.
+	CLZ  R6, R6
+	MOVD $63, R7
+	SUB  R6, R7, R6
+	ADD  R6>>1, R3, R3
+	MOVD R3, (R8)
+	RET
+
+fail:
+	MOVD $-1, R3
+	MOVD R3, (R8)
+	RET
+
+// handle lengths < 32 a byte at a time
+small:
+	MOVD R2, R3
+
+smallloop:
+	SUBS  $1, R3, R3
+	BLT   fail
+	MOVBU (R0)(R3), R4
+	MOVBU (R1)(R3), R5
+	ORR   R4, R5, R6
+	TST   $0x80, R6
+	BNE   smallret
+	EOR   R4, R5, R6
+	CBZ   R6, smallloop
+	CMP   $0x20, R6
+	BNE   smallret
+	ORR   $0x20, R4, R4
+	SUB   $0x61, R4, R4
+	CMP   $25, R4
+	BLS   smallloop
+
+smallret:
+	MOVD R3, (R8)
+	RET
@@ -0,0 +1,27 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build purego || (!amd64 && !arm64)
+// +build purego !amd64,!arm64
+
+package bytealg
+
+// NativeMismatchFold is true if we have a fast native (assembly)
+// implementation of MismatchFold and LastMismatchFold.
+const NativeMismatchFold = false
+
+func MismatchFold(a, b []byte) int {
+	return mismatchFoldGeneric(a, b)
+}
+
+func MismatchFoldString(a, b string) int {
+	return mismatchFoldGenericString(a, b)
+}
+
+func LastMismatchFold(a, b []byte) int {
+	return lastMismatchFoldGeneric(a, b)
+}
+
+func LastMismatchFoldString(a, b string) int {
+	return lastMismatchFoldGenericString(a, b)
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build (amd64 || arm64) && !purego
+// +build amd64 arm64
+// +build !purego
+
+package bytealg
+
+// NativeMismatchFold is true if we have a fast native (assembly)
+// implementation of MismatchFold and LastMismatchFold.
+const NativeMismatchFold = true
+
+// Make golangci-lint think these functions are accessed since they are
+// only used on architectures without an assembly implementation.
+var _ = mismatchFoldGeneric
+var _ = mismatchFoldGenericString
+var _ = lastMismatchFoldGeneric
+var _ = lastMismatchFoldGenericString
+
+//go:noescape
+func MismatchFold(a, b []byte) int
+
+//go:noescape
+func MismatchFoldString(a, b string) int
+
+//go:noescape
+func LastMismatchFold(a, b []byte) int
+
+//go:noescape
+func LastMismatchFoldString(a, b string) int
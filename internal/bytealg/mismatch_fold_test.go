@@ -0,0 +1,131 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mismatchFoldTest struct {
+	a, b       string
+	first, end int
+}
+
+var mismatchFoldTests = []mismatchFoldTest{
+	{"", "", 0, -1},
+	{"a", "A", 1, -1},
+	{"a", "b", 0, 0},
+	{"abc", "ABC", 3, -1},
+	{"abc", "aBd", 2, 2},
+	{"abc", "ABCD", 3, -1},
+	{"abc", "xBCD", 0, 0},
+	{"xbc", "aBd", 0, 2},
+	// Only letters are folded.
+	{"@[`{", "`{@[", 0, 3},
+	{"@[`{", "@[`{", 4, -1},
+	{"\x00\x20", "\x20\x00", 0, 1},
+	// Non-ASCII bytes never match.
+	{"a\x80b", "A\x80B", 1, 1},
+	{"\xff", "\xff", 0, 0},
+	{"a\xe2\x84\xaab", "a\xe2\x84\xaab", 1, 3},
+	{strings.Repeat("a", 64), strings.Repeat("A", 64), 64, -1},
+	{strings.Repeat("a", 64) + "b", strings.Repeat("A", 64) + "C", 64, 64},
+	{"b" + strings.Repeat("a", 64), "C" + strings.Repeat("A", 64), 0, 0},
+	{strings.Repeat("a", 31) + "é", strings.Repeat("A", 31) + "é", 31, 32},
+	{strings.Repeat("a", 17) + "x" + strings.Repeat("a", 15), strings.Repeat("A", 33), 17, 17},
+	{strings.Repeat("a", 100) + "x" + strings.Repeat("a", 31), strings.Repeat("A", 132), 100, 100},
+}
+
+func TestMismatchFold(t *testing.T) {
+	for _, tt := range mismatchFoldTests {
+		if got := MismatchFoldString(tt.a, tt.b); got != tt.first {
+			t.Errorf("MismatchFoldString(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.first)
+		}
+		if got := MismatchFold([]byte(tt.a), []byte(tt.b)); got != tt.first {
+			t.Errorf("MismatchFold(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.first)
+		}
+		if got := LastMismatchFoldString(tt.a, tt.b); got != tt.end {
+			t.Errorf("LastMismatchFoldString(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.end)
+		}
+		if got := LastMismatchFold([]byte(tt.a), []byte(tt.b)); got != tt.end {
+			t.Errorf("LastMismatchFold(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.end)
+		}
+	}
+}
+
+func TestMismatchFoldRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	const chars = "abcABC@[`{\x00\x20\x7f\x80\xff"
+	for i := 0; i < 5000; i++ {
+		a := make([]byte, rr.Intn(160))
+		b := make([]byte, len(a))
+		for j := range a {
+			a[j] = chars[rr.Intn(6)]
+			b[j] = a[j] ^ byte(rr.Intn(2)<<5) // swap case
+		}
+		// Change a few bytes
+		for j := rr.Intn(3); j > 0 && len(a) > 0; j-- {
+			a[rr.Intn(len(a))] = chars[rr.Intn(len(chars))]
+		}
+		// Test all offsets to exercise the alignment of the final block
+		for j := 0; j < len(a) && j < 40; j++ {
+			first := mismatchFoldGeneric(a[j:], b[j:])
+			end := lastMismatchFoldGeneric(a[j:], b[j:])
+			if got := MismatchFold(a[j:], b[j:]); got != first {
+				t.Fatalf("MismatchFold(%q, %q) = %d; want: %d", a[j:], b[j:], got, first)
+			}
+			if got := MismatchFoldString(string(a[j:]), string(b[j:])); got != first {
+				t.Fatalf("MismatchFoldString(%q, %q) = %d; want: %d", a[j:], b[j:], got, first)
+			}
+			if got := LastMismatchFold(a[j:], b[j:]); got != end {
+				t.Fatalf("LastMismatchFold(%q, %q) = %d; want: %d", a[j:], b[j:], got, end)
+			}
+			if got := LastMismatchFoldString(string(a[j:]), string(b[j:])); got != end {
+				t.Fatalf("LastMismatchFoldString(%q, %q) = %d; want: %d", a[j:], b[j:], got, end)
+			}
+		}
+	}
+}
+
+func TestMismatchFoldGeneric(t *testing.T) {
+	for _, tt := range mismatchFoldTests {
+		if got := mismatchFoldGenericString(tt.a, tt.b); got != tt.first {
+			t.Errorf("mismatchFoldGenericString(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.first)
+		}
+		if got := mismatchFoldGeneric([]byte(tt.a), []byte(tt.b)); got != tt.first {
+			t.Errorf("mismatchFoldGeneric(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.first)
+		}
+		if got := lastMismatchFoldGenericString(tt.a, tt.b); got != tt.end {
+			t.Errorf("lastMismatchFoldGenericString(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.end)
+		}
+		if got := lastMismatchFoldGeneric([]byte(tt.a), []byte(tt.b)); got != tt.end {
+			t.Errorf("lastMismatchFoldGeneric(%q, %q) = %d; want: %d", tt.a, tt.b, got, tt.end)
+		}
+	}
+}
+
+func BenchmarkMismatchFold(b *testing.B) {
+	s1 := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	s2 := strings.ToUpper(s1)
+	b.SetBytes(int64(len(s1)))
+	for i := 0; i < b.N; i++ {
+		if MismatchFoldString(s1, s2) != len(s1) {
+			b.Fatal("unexpected mismatch")
+		}
+	}
+}
+
+func BenchmarkLastMismatchFold(b *testing.B) {
+	s1 := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	s2 := strings.ToUpper(s1)
+	b.SetBytes(int64(len(s1)))
+	for i := 0; i < b.N; i++ {
+		if LastMismatchFoldString(s1, s2) != -1 {
+			b.Fatal("unexpected mismatch")
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// SWAR (SIMD within a register) implementations of IndexByte, Count and
+// IndexNonASCII that process 8 bytes at a time using 64-bit arithmetic.
+// These are the fallback for architectures without an assembly
+// implementation, but are built everywhere so that they can be tested.
+//
+// Letters are matched without regard to case by setting bit 0x20 of each
+// byte before comparing, which is safe since the only bytes that equal
+// a lower case letter c with bit 0x20 set are c and its upper case.
+
+const (
+	swarLo = 0x0101010101010101
+	swarHi = 0x8080808080808080
+)
+
+// swarZero returns a word with the high bit of each zero byte of x set and
+// all other bits cleared. Unlike the well-known "(x - lo) & ^x & hi" trick
+// this is exact and does not report bytes following a zero byte, which
+// allows the result to be used for counting.
+func swarZero(x uint64) uint64 {
+	const lo7 = 0x7f7f7f7f7f7f7f7f
+	return ^((x&lo7 + lo7) | x | lo7)
+}
+
+func isAlpha(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
+}
+
+// swarPattern returns the mask to OR each byte with and the pattern to
+// compare each byte to when searching for c.
+func swarPattern(c byte) (mask, pat uint64) {
+	if isAlpha(c) {
+		c |= ' '
+		mask = swarLo * ' '
+	}
+	return mask, swarLo * uint64(c)
+}
+
+// load64 returns the 8 bytes of b starting at i as a little-endian uint64.
+// The halves are loaded separately since the compiler only merges the byte
+// loads of binary.LittleEndian.Uint64 on 64-bit architectures.
+func load64(b []byte, i int) uint64 {
+	b = b[i : i+8]
+	return uint64(binary.LittleEndian.Uint32(b)) |
+		uint64(binary.LittleEndian.Uint32(b[4:]))<<32
+}
+
+// loadString64 is like load64 but for strings.
+func loadString64(s string, i int) uint64 {
+	s = s[i : i+8]
+	lo := uint32(s[0]) | uint32(s[1])<<8 | uint32(s[2])<<16 | uint32(s[3])<<24
+	hi := uint32(s[4]) | uint32(s[5])<<8 | uint32(s[6])<<16 | uint32(s[7])<<24
+	return uint64(lo) | uint64(hi)<<32
+}
+
+// swarSum returns the sum of the bytes of x.
+func swarSum(x uint64) int {
+	// Sum adjacent bytes into 16-bit lanes so that the sum cannot overflow.
+	x = x&0x00ff00ff00ff00ff + (x>>8)&0x00ff00ff00ff00ff
+	return int((x * 0x0001000100010001) >> 48)
+}
+
+func indexByteSWAR(b []byte, c byte) int {
+	mask, pat := swarPattern(c)
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		x := (load64(b, i) | mask) ^ pat
+		if z := swarZero(x); z != 0 {
+			return i + bits.TrailingZeros64(z)/8
+		}
+	}
+	m := byte(mask)
+	c = byte(pat)
+	for ; i < len(b); i++ {
+		if b[i]|m == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexByteStringSWAR(s string, c byte) int {
+	mask, pat := swarPattern(c)
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		x := (loadString64(s, i) | mask) ^ pat
+		if z := swarZero(x); z != 0 {
+			return i + bits.TrailingZeros64(z)/8
+		}
+	}
+	m := byte(mask)
+	c = byte(pat)
+	for ; i < len(s); i++ {
+		if s[i]|m == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func countSWAR(b []byte, c byte) int {
+	mask, pat := swarPattern(c)
+	n := 0
+	i := 0
+	for i+8 <= len(b) {
+		// Accumulate the matches of up to 255 words in the bytes of
+		// acc before summing them so that no byte overflows.
+		k := (len(b) - i) / 8
+		if k > 255 {
+			k = 255
+		}
+		var acc uint64
+		for ; k > 0; k-- {
+			x := (load64(b, i) | mask) ^ pat
+			acc += swarZero(x) >> 7
+			i += 8
+		}
+		n += swarSum(acc)
+	}
+	m := byte(mask)
+	c = byte(pat)
+	for ; i < len(b); i++ {
+		if b[i]|m == c {
+			n++
+		}
+	}
+	return n
+}
+
+func countStringSWAR(s string, c byte) int {
+	mask, pat := swarPattern(c)
+	n := 0
+	i := 0
+	for i+8 <= len(s) {
+		k := (len(s) - i) / 8
+		if k > 255 {
+			k = 255
+		}
+		var acc uint64
+		for ; k > 0; k-- {
+			x := (loadString64(s, i) | mask) ^ pat
+			acc += swarZero(x) >> 7
+			i += 8
+		}
+		n += swarSum(acc)
+	}
+	m := byte(mask)
+	c = byte(pat)
+	for ; i < len(s); i++ {
+		if s[i]|m == c {
+			n++
+		}
+	}
+	return n
+}
+
+func indexByteNonASCIISWAR(b []byte) int {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		if x := load64(b, i) & swarHi; x != 0 {
+			return i + bits.TrailingZeros64(x)/8
+		}
+	}
+	for ; i < len(b); i++ {
+		if b[i]&0x80 != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexNonASCIISWAR(s string) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if x := loadString64(s, i) & swarHi; x != 0 {
+			return i + bits.TrailingZeros64(x)/8
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i]&0x80 != 0 {
+			return i
+		}
+	}
+	return -1
+}
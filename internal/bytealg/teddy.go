@@ -0,0 +1,140 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+// TeddyLen is the number of leading bytes of each needle that are used to
+// compute the fingerprint of a [Teddy] filter.
+const TeddyLen = 3
+
+// TeddyBuckets is the number of buckets of a [Teddy] filter.
+const TeddyBuckets = 8
+
+// Teddy is a packed SIMD filter for finding the first position in a string
+// that may be the start of one of a small set of needles. It is based on
+// the "Teddy" algorithm from the Hyperscan project.
+//
+// Each needle is assigned to one of the eight buckets and the bytes that may
+// appear at each of the first TeddyLen positions of the needle are added to
+// the filter. For each position, the filter stores two 16 byte tables that
+// map the low and high nibble of a byte to the set of buckets that contain
+// a byte with that nibble. The tables are looked up with a single shuffle
+// instruction for 16 or 32 positions at a time and the buckets that may
+// match at a position are the intersection of the tables for each of the
+// following TeddyLen bytes.
+//
+// Since the nibbles are looked up independently the filter may report
+// positions that do not match any of the bytes added to it so the caller
+// must verify each candidate.
+type Teddy struct {
+	// masks[i][0] maps the low nibble of the i'th byte to a set of buckets
+	// and masks[i][1] maps the high nibble.
+	masks [TeddyLen][2][16]byte
+}
+
+// Add adds byte c at position pos of the needles in bucket.
+func (t *Teddy) Add(bucket, pos int, c byte) {
+	bit := byte(1) << bucket
+	t.masks[pos][0][c&0xf] |= bit
+	t.masks[pos][1][c>>4] |= bit
+}
+
+// AddAny adds all bytes at position pos of the needles in bucket.
+func (t *Teddy) AddAny(bucket, pos int) {
+	bit := byte(1) << bucket
+	for i := 0; i < 16; i++ {
+		t.masks[pos][0][i] |= bit
+		t.masks[pos][1][i] |= bit
+	}
+}
+
+// Buckets returns the set of buckets that may match at b[i:]. Positions
+// past the end of b match all buckets.
+func (t *Teddy) Buckets(b []byte, i int) uint8 {
+	m := uint8(0xff)
+	for j := 0; j < TeddyLen && i+j < len(b); j++ {
+		c := b[i+j]
+		m &= t.masks[j][0][c&0xf] & t.masks[j][1][c>>4]
+	}
+	return m
+}
+
+// BucketsString returns the set of buckets that may match at s[i:].
+// Positions past the end of s match all buckets.
+func (t *Teddy) BucketsString(s string, i int) uint8 {
+	m := uint8(0xff)
+	for j := 0; j < TeddyLen && i+j < len(s); j++ {
+		c := s[i+j]
+		m &= t.masks[j][0][c&0xf] & t.masks[j][1][c>>4]
+	}
+	return m
+}
+
+// teddyMinLen is the minimum length of the input to the assembly
+// implementations of indexTeddy (one 16 byte block plus TeddyLen-1
+// trailing bytes).
+const teddyMinLen = 16 + TeddyLen - 1
+
+// Index returns the index of the first position in b that may match any of
+// the buckets of t, or -1 if there is none.
+func (t *Teddy) Index(b []byte) int {
+	i := 0
+	if len(b) >= teddyMinLen {
+		if n := indexTeddy(b, &t.masks); n != -1 {
+			return n
+		}
+		i = len(b) - (TeddyLen - 1)
+	}
+	for ; i < len(b); i++ {
+		if t.Buckets(b, i) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexString returns the index of the first position in s that may match
+// any of the buckets of t, or -1 if there is none.
+func (t *Teddy) IndexString(s string) int {
+	i := 0
+	if len(s) >= teddyMinLen {
+		if n := indexTeddyString(s, &t.masks); n != -1 {
+			return n
+		}
+		i = len(s) - (TeddyLen - 1)
+	}
+	for ; i < len(s); i++ {
+		if t.BucketsString(s, i) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexTeddyGeneric returns the index of the first position i in b, where
+// i+TeddyLen <= len(b), that may match any of the buckets of masks.
+func indexTeddyGeneric(b []byte, masks *[TeddyLen][2][16]byte) int {
+	for i := 0; i+TeddyLen <= len(b); i++ {
+		c0, c1, c2 := b[i], b[i+1], b[i+2]
+		if masks[0][0][c0&0xf]&masks[0][1][c0>>4]&
+			masks[1][0][c1&0xf]&masks[1][1][c1>>4]&
+			masks[2][0][c2&0xf]&masks[2][1][c2>>4] != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexTeddyGenericString returns the index of the first position i in s,
+// where i+TeddyLen <= len(s), that may match any of the buckets of masks.
+func indexTeddyGenericString(s string, masks *[TeddyLen][2][16]byte) int {
+	for i := 0; i+TeddyLen <= len(s); i++ {
+		c0, c1, c2 := s[i], s[i+1], s[i+2]
+		if masks[0][0][c0&0xf]&masks[0][1][c0>>4]&
+			masks[1][0][c1&0xf]&masks[1][1][c1>>4]&
+			masks[2][0][c2&0xf]&masks[2][1][c2>>4] != 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "go_asm.h"
+#include "asm_amd64.h"
+#include "textflag.h"
+
+// TEDDY_SSE finds the first candidate in the 16 positions starting at DI.
+// The zero flag is clear and DX is the index of the candidate within the
+// block if there is one.
+#define TEDDY_SSE \
+	MOVOU    0(DI), X0 \
+	MOVOU    X0, X1    \
+	PSRLW    $4, X1    \
+	PAND     X15, X0   \
+	PAND     X15, X1   \
+	MOVOU    X8, X2    \
+	PSHUFB   X0, X2    \
+	MOVOU    X9, X3    \
+	PSHUFB   X1, X3    \
+	PAND     X3, X2    \
+	MOVOU    1(DI), X0 \
+	MOVOU    X0, X1    \
+	PSRLW    $4, X1    \
+	PAND     X15, X0   \
+	PAND     X15, X1   \
+	MOVOU    X10, X3   \
+	PSHUFB   X0, X3    \
+	PAND     X3, X2    \
+	MOVOU    X11, X3   \
+	PSHUFB   X1, X3    \
+	PAND     X3, X2    \
+	MOVOU    2(DI), X0 \
+	MOVOU    X0, X1    \
+	PSRLW    $4, X1    \
+	PAND     X15, X0   \
+	PAND     X15, X1   \
+	MOVOU    X12, X3   \
+	PSHUFB   X0, X3    \
+	PAND     X3, X2    \
+	MOVOU    X13, X3   \
+	PSHUFB   X1, X3    \
+	PAND     X3, X2    \
+	PCMPEQB  X14, X2   \
+	PMOVMSKB X2, DX    \
+	XORL     $0xffff, DX \
+	BSFL     DX, DX
+
+// TEDDY_AVX2 is like TEDDY_SSE but searches 32 positions.
+#define TEDDY_AVX2 \
+	VMOVDQU   0(DI), Y0     \
+	VPSRLW    $4, Y0, Y1    \
+	VPAND     Y15, Y0, Y0   \
+	VPAND     Y15, Y1, Y1   \
+	VPSHUFB   Y0, Y8, Y2    \
+	VPSHUFB   Y1, Y9, Y3    \
+	VPAND     Y3, Y2, Y2    \
+	VMOVDQU   1(DI), Y0     \
+	VPSRLW    $4, Y0, Y1    \
+	VPAND     Y15, Y0, Y0   \
+	VPAND     Y15, Y1, Y1   \
+	VPSHUFB   Y0, Y10, Y3   \
+	VPAND     Y3, Y2, Y2    \
+	VPSHUFB   Y1, Y11, Y3   \
+	VPAND     Y3, Y2, Y2    \
+	VMOVDQU   2(DI), Y0     \
+	VPSRLW    $4, Y0, Y1    \
+	VPAND     Y15, Y0, Y0   \
+	VPAND     Y15, Y1, Y1   \
+	VPSHUFB   Y0, Y12, Y3   \
+	VPAND     Y3, Y2, Y2    \
+	VPSHUFB   Y1, Y13, Y3   \
+	VPAND     Y3, Y2, Y2    \
+	VPCMPEQB  Y14, Y2, Y2   \
+	VPMOVMSKB Y2, DX        \
+	NOTL      DX            \
+	BSFL      DX, DX
+
+TEXT ·indexTeddy(SB), NOSPLIT, $0-40
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexTeddyGeneric(SB)
+
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), BX
+	MOVQ masks+24(FP), R9
+	LEAQ ret+32(FP), R8
+	JMP  indexTeddyBody<>(SB)
+
+TEXT ·indexTeddyString(SB), NOSPLIT, $0-32
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasSSSE3(SB), $1
+	JEQ  2(PC)
+	JMP  ·indexTeddyGenericString(SB)
+
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), BX
+	MOVQ masks+16(FP), R9
+	LEAQ ret+24(FP), R8
+	JMP  indexTeddyBody<>(SB)
+
+// input:
+//   SI: data
+//   BX: data len (must be >= 18)
+//   R9: address of the Teddy masks
+//   R8: address to put result
+//
+// The nibble masks for byte i of the fingerprint are at 32*i(R9) (low
+// nibble) and 32*i+16(R9) (high nibble). A position p is a candidate if the
+// intersection of the buckets of bytes p, p+1 and p+2 is not empty.
+TEXT indexTeddyBody<>(SB), NOSPLIT, $0
+	MOVQ SI, DI
+
+	CMPQ BX, $34 // 32 positions plus two trailing bytes
+	JAE  avx2
+
+sse:
+	// Load the nibble masks.
+	MOVOU 0(R9), X8
+	MOVOU 16(R9), X9
+	MOVOU 32(R9), X10
+	MOVOU 48(R9), X11
+	MOVOU 64(R9), X12
+	MOVOU 80(R9), X13
+
+	// X15 = 0x0f in each byte, X14 = 0
+	MOVQ       $0x0f0f0f0f0f0f0f0f, AX
+	MOVQ       AX, X15
+	PUNPCKLQDQ X15, X15
+	PXOR       X14, X14
+
+	LEAQ -18(SI)(BX*1), AX // AX = address of the last block
+	JMP  sseloopentry
+
+sseloop:
+	TEDDY_SSE
+	JNZ  ssesuccess
+
+	// Advance to next block.
+	ADDQ $16, DI
+
+sseloopentry:
+	CMPQ DI, AX
+	JB   sseloop
+
+	// Search the last block. This block may overlap with the blocks we've
+	// already searched, but that's ok since they did not contain any
+	// candidates.
+	MOVQ AX, DI
+	TEDDY_SSE
+	JNZ  ssesuccess
+
+	MOVQ $-1, (R8)
+	RET
+
+// The block was loaded from DI.
+// The index of the candidate in the block is DX.
+// The start of the data is SI.
+ssesuccess:
+	SUBQ SI, DI   // Compute offset of block within data.
+	ADDQ DX, DI   // Add offset of candidate within block.
+	MOVQ DI, (R8)
+	RET
+
+avx2:
+#ifndef hasAVX2
+	CMPB golang·org∕x∕sys∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE  sse
+
+#endif
+	// Load the nibble masks into both lanes.
+	VBROADCASTI128 0(R9), Y8
+	VBROADCASTI128 16(R9), Y9
+	VBROADCASTI128 32(R9), Y10
+	VBROADCASTI128 48(R9), Y11
+	VBROADCASTI128 64(R9), Y12
+	VBROADCASTI128 80(R9), Y13
+
+	// Y15 = 0x0f in each byte, Y14 = 0
+	MOVQ         $0x0f, AX
+	MOVQ         AX, X15
+	VPBROADCASTB X15, Y15
+	VPXOR        Y14, Y14, Y14
+
+	LEAQ -34(SI)(BX*1), R11 // R11 = address of the last block
+	JMP  avx2loopentry
+
+avx2loop:
+	TEDDY_AVX2
+	JNZ  avx2success
+	ADDQ $32, DI
+
+avx2loopentry:
+	CMPQ DI, R11
+	JB   avx2loop
+
+	// Search the last (possibly overlapping) block.
+	MOVQ R11, DI
+	TEDDY_AVX2
+	JNZ  avx2success
+	VZEROUPPER
+	MOVQ $-1, (R8)
+	RET
+
+avx2success:
+	SUBQ SI, DI
+	ADDQ DX, DI
+	MOVQ DI, (R8)
+	VZEROUPPER
+	RET
@@ -0,0 +1,117 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// TEDDY_NEON computes the syndrome for the 16 positions starting at R4 in
+// R6. Bit 2*i of R6 is set if position i of the block is a candidate.
+#define TEDDY_NEON \
+	VLD1   (R4), [V0.B16]                 \
+	ADD    $1, R4, R7                     \
+	VLD1   (R7), [V1.B16]                 \
+	ADD    $2, R4, R7                     \
+	VLD1   (R7), [V2.B16]                 \
+	VUSHR  $4, V0.B16, V3.B16             \
+	VAND   V31.B16, V0.B16, V0.B16        \
+	VTBL   V0.B16, [V16.B16], V6.B16      \
+	VTBL   V3.B16, [V17.B16], V7.B16      \
+	VAND   V7.B16, V6.B16, V6.B16         \
+	VUSHR  $4, V1.B16, V3.B16             \
+	VAND   V31.B16, V1.B16, V1.B16        \
+	VTBL   V1.B16, [V18.B16], V7.B16      \
+	VAND   V7.B16, V6.B16, V6.B16         \
+	VTBL   V3.B16, [V19.B16], V7.B16      \
+	VAND   V7.B16, V6.B16, V6.B16         \
+	VUSHR  $4, V2.B16, V3.B16             \
+	VAND   V31.B16, V2.B16, V2.B16        \
+	VTBL   V2.B16, [V20.B16], V7.B16      \
+	VAND   V7.B16, V6.B16, V6.B16         \
+	VTBL   V3.B16, [V21.B16], V7.B16      \
+	VAND   V7.B16, V6.B16, V6.B16         \
+	VCMTST V6.B16, V6.B16, V6.B16         \
+	VAND   V5.B16, V6.B16, V6.B16         \
+	VADDP  V6.B16, V6.B16, V6.B16         \
+	VADDP  V6.B16, V6.B16, V6.B16         \
+	VMOV   V6.S[0], R6
+
+TEXT ·indexTeddy(SB), NOSPLIT, $0-40
+	MOVD b_base+0(FP), R0
+	MOVD b_len+8(FP), R2
+	MOVD masks+24(FP), R1
+	MOVD $ret+32(FP), R8
+
+	B indexTeddyBody<>(SB)
+
+TEXT ·indexTeddyString(SB), NOSPLIT, $0-32
+	MOVD s_base+0(FP), R0
+	MOVD s_len+8(FP), R2
+	MOVD masks+16(FP), R1
+	MOVD $ret+24(FP), R8
+
+	B indexTeddyBody<>(SB)
+
+// input:
+//   R0: data
+//   R1: address of the Teddy masks
+//   R2: data len (must be >= 18)
+//   R8: address to put result
+//
+// The nibble masks for byte i of the fingerprint are at 32*i(R1) (low
+// nibble) and 32*i+16(R1) (high nibble). A position p is a candidate if the
+// intersection of the buckets of bytes p, p+1 and p+2 is not empty.
+TEXT indexTeddyBody<>(SB), NOSPLIT, $0
+	// Load the nibble masks.
+	VLD1.P 64(R1), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1   (R1), [V20.B16, V21.B16]
+
+	// Mask for the low nibble of each byte.
+	VMOVQ $0x0f0f0f0f0f0f0f0f, $0x0f0f0f0f0f0f0f0f, V31
+
+	// Magic constant 0x40100401 allows us to identify
+	// which lane matches the requested byte.
+	// 0x40100401 = ((1<<0) + (4<<8) + (16<<16) + (64<<24))
+	// Different bytes have different bit masks (i.e: 1, 4, 16, 64)
+	MOVD $0x40100401, R5
+	VMOV R5, V5.S4
+
+	// R3 = address of the last block
+	ADD  R0, R2, R3
+	SUB  $18, R3, R3
+	MOVD R0, R4
+
+loop:
+	CMP R3, R4
+	BHS last
+	TEDDY_NEON
+	CBNZ R6, tail
+	ADD  $16, R4, R4
+	B    loop
+
+last:
+	// Search the last block. This block may overlap with the blocks we've
+	// already searched, but that's ok since they did not contain any
+	// candidates.
+	MOVD R3, R4
+	TEDDY_NEON
+	CBZ  R6, fail
+
+tail:
+	// Count the trailing zeros using bit reversing
+	RBIT R6, R6
+	CLZ  R6, R6
+
+	// R6 is twice the offset into the block
+	ADD R6>>1, R4, R4
+
+	// Compute the offset result
+	SUB  R0, R4, R4
+	MOVD R4, (R8)
+	RET
+
+fail:
+	MOVD $-1, R0
+	MOVD R0, (R8)
+	RET
@@ -0,0 +1,15 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build purego || (!amd64 && !arm64)
+// +build purego !amd64,!arm64
+
+package bytealg
+
+func indexTeddy(b []byte, masks *[TeddyLen][2][16]byte) int {
+	return indexTeddyGeneric(b, masks)
+}
+
+func indexTeddyString(s string, masks *[TeddyLen][2][16]byte) int {
+	return indexTeddyGenericString(s, masks)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package bytealg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// indexTeddyReference is a naive implementation of Teddy.Index.
+func indexTeddyReference(t *Teddy, s string) int {
+	for i := 0; i < len(s); i++ {
+		if t.BucketsString(s, i) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func newTeddy(needles ...string) *Teddy {
+	var t Teddy
+	for i, s := range needles {
+		b := i % TeddyBuckets
+		for j := 0; j < TeddyLen; j++ {
+			if j < len(s) {
+				t.Add(b, j, s[j])
+			} else {
+				t.AddAny(b, j)
+			}
+		}
+	}
+	return &t
+}
+
+func TestTeddy(t *testing.T) {
+	tests := []struct {
+		needles []string
+		s       string
+		out     int
+	}{
+		{[]string{"abc"}, "", -1},
+		{[]string{"abc"}, "abc", 0},
+		{[]string{"abc"}, "xabc", 1},
+		{[]string{"abc"}, "xab", 1}, // positions past the end match
+		{[]string{"abc"}, "xxx", -1},
+		{[]string{"abc", "xyz"}, strings.Repeat("-", 64) + "xyz", 64},
+		{[]string{"abc", "xyz"}, strings.Repeat("-", 64) + "xy", 64},
+		{[]string{"abc", "xyz"}, strings.Repeat("-", 64) + "x", 64},
+		{[]string{"abc", "xyz"}, strings.Repeat("-", 64), -1},
+		{[]string{"a"}, strings.Repeat("-", 17) + "a", 17},
+		{[]string{"a"}, strings.Repeat("-", 33) + "a", 33},
+		{[]string{"\x80\xff"}, strings.Repeat("\xff", 40) + "\x80\xff", 40},
+		// Nibbles from different needles in the same bucket may match.
+		{[]string{"\x12", "z", "z", "z", "z", "z", "z", "z", "\x34"}, "-\x14-", 1},
+	}
+	for _, tt := range tests {
+		td := newTeddy(tt.needles...)
+		if got := td.IndexString(tt.s); got != tt.out {
+			t.Errorf("IndexString(%q, %q) = %d; want: %d", tt.needles, tt.s, got, tt.out)
+		}
+		if got := td.Index([]byte(tt.s)); got != tt.out {
+			t.Errorf("Index(%q, %q) = %d; want: %d", tt.needles, tt.s, got, tt.out)
+		}
+	}
+}
+
+func TestTeddyRandom(t *testing.T) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int, chars string) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = chars[rr.Intn(len(chars))]
+		}
+		return string(b)
+	}
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ\x00\x80\xff"
+	for i := 0; i < 5000; i++ {
+		needles := make([]string, rr.Intn(16)+1)
+		for j := range needles {
+			needles[j] = randStr(rr.Intn(TeddyLen)+1, chars)
+		}
+		td := newTeddy(needles...)
+		s := randStr(rr.Intn(128), chars)
+		// Test all offsets to exercise the alignment of the final block
+		for j := 0; j < len(s) && j < 40; j++ {
+			want := indexTeddyReference(td, s[j:])
+			if got := td.IndexString(s[j:]); got != want {
+				t.Fatalf("IndexString(%q, %q) = %d; want: %d", needles, s[j:], got, want)
+			}
+			if got := td.Index([]byte(s[j:])); got != want {
+				t.Fatalf("Index(%q, %q) = %d; want: %d", needles, s[j:], got, want)
+			}
+		}
+	}
+}
+
+func TestTeddyGeneric(t *testing.T) {
+	td := newTeddy("foo", "bar", "baz")
+	for _, s := range []string{"", "fo", "xxfoo", "xxxxxxxxxxxxxxxxbaz", strings.Repeat("x", 40)} {
+		want := indexTeddyReference(td, s)
+		if want > len(s)-TeddyLen {
+			want = -1
+		}
+		if got := indexTeddyGenericString(s, &td.masks); got != want {
+			t.Errorf("indexTeddyGenericString(%q) = %d; want: %d", s, got, want)
+		}
+		if got := indexTeddyGeneric([]byte(s), &td.masks); got != want {
+			t.Errorf("indexTeddyGeneric(%q) = %d; want: %d", s, got, want)
+		}
+	}
+}
+
+func BenchmarkTeddy(b *testing.B) {
+	td := newTeddy("foo", "bar", "baz", "qux", "quux", "corge", "grault", "garply")
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		if td.IndexString(s) != -1 {
+			b.Fatal("unexpected match")
+		}
+	}
+}
@@ -0,0 +1,10 @@
+set -e
+
+case "$(go env GOHOSTARCH)" in
+amd64 | 386) ;;
+*)
+    echo 'error: this is only for amd64 and 386 hosts'
+    exit 1
+    ;;
+esac
+GOARCH='386' go test
@@ -78,6 +78,8 @@ const (
 	caseFoldSize         = 8192
 	foldMapShift         = 24
 	foldMapSize          = 256
+	fullCaseFoldShift    = 24
+	fullCaseFoldSize     = 256
 	upperLowerTableSize  = 8192
 	upperLowerTableShift = 19
 )
@@ -92,12 +94,20 @@ type foldPair struct {
 	To   uint32
 }
 
+// A fullFold is a full case-folding where a rune folds to more than one rune.
+type fullFold struct {
+	From rune
+	To   []rune
+}
+
 var (
-	categories *unicode.RangeTable
-	caseFolds  []foldPair
-	caseRanges []unicode.CaseRange // used by toLower and toUpper
-	caseOrbit  []foldPair          // used by simpleFold
-	asciiFold  [unicode.MaxASCII + 1]uint16
+	categories    *unicode.RangeTable
+	caseFolds     []foldPair
+	fullCaseFolds []fullFold          // full case-folds (status 'F')
+	turkicFolds   []foldPair          // Turkic case-folds (status 'T')
+	caseRanges    []unicode.CaseRange // used by toLower and toUpper
+	caseOrbit     []foldPair          // used by simpleFold
+	asciiFold     [unicode.MaxASCII + 1]uint16
 )
 
 var (
@@ -110,8 +120,19 @@ var (
 func loadCaseFolds() {
 	ucd.Parse(gen.OpenUCDFile("CaseFolding.txt"), func(p *ucd.Parser) {
 		kind := p.String(1)
-		if kind != "C" && kind != "S" {
-			// Only care about 'common' and 'simple' foldings.
+		switch kind {
+		case "F":
+			// Full foldings are stored separately since they map a
+			// single rune to multiple runes.
+			fullCaseFolds = append(fullCaseFolds, fullFold{p.Rune(0), p.Runes(2)})
+			return
+		case "T":
+			// Turkic foldings are only used by the Turkic functions.
+			turkicFolds = append(turkicFolds, foldPair{uint32(p.Rune(0)), uint32(p.Rune(2))})
+			return
+		case "C", "S":
+		default:
+			// Only care about 'common', 'simple', 'full', and 'Turkic' foldings.
 			return
 		}
 		p1 := p.Rune(0)
@@ -122,6 +143,12 @@ func loadCaseFolds() {
 	slices.SortFunc(caseFolds, func(a, b foldPair) int {
 		return cmp.Compare(a.From, b.From)
 	})
+	slices.SortFunc(fullCaseFolds, func(a, b fullFold) int {
+		return cmp.Compare(a.From, b.From)
+	})
+	slices.SortFunc(turkicFolds, func(a, b foldPair) int {
+		return cmp.Compare(a.From, b.From)
+	})
 }
 
 var buildTags = map[string]struct{ version, buildTags, filename string }{
@@ -741,6 +768,69 @@ func genCaseFolds(w *bytes.Buffer, firstValidHash bool) {
 	fmt.Fprint(w, "}\n\n")
 }
 
+func genFullCaseFolds(w *bytes.Buffer, firstValidHash bool) {
+	inputs := make([]uint32, len(fullCaseFolds))
+	for i, f := range fullCaseFolds {
+		// All full case-folds are in the BMP and fold to at most three
+		// runes, which allows us to store them in a [4]uint16.
+		if f.From > math.MaxUint16 {
+			log.Fatalf("rune 0x%04X is larger than MaxUint16 0x%04X", f.From, math.MaxUint16)
+		}
+		if len(f.To) < 2 || len(f.To) > 3 {
+			log.Fatalf("full case-fold of rune 0x%04X must have 2 or 3 runes got: %d",
+				f.From, len(f.To))
+		}
+		for _, r := range f.To {
+			if r > math.MaxUint16 {
+				log.Fatalf("rune 0x%04X is larger than MaxUint16 0x%04X", r, math.MaxUint16)
+			}
+		}
+		inputs[i] = uint32(f.From)
+	}
+
+	conf := HashConfig{
+		TableName:      "FullCaseFolds",
+		TableSize:      fullCaseFoldSize,
+		HashShift:      fullCaseFoldShift,
+		FirstValidHash: firstValidHash,
+	}
+	seed := conf.GenerateHashValues(inputs)
+
+	fmt.Fprint(w, "\n")
+	fmt.Fprintf(w, "const _FullCaseFoldsSeed = 0x%04X\n", seed)
+	fmt.Fprintf(w, "const _FullCaseFoldsShift = %d\n", fullCaseFoldShift)
+	fmt.Fprint(w, "\n")
+	fmt.Fprintln(w, "// _FullCaseFolds stores the Unicode full case-folds of characters\n"+
+		"// that fold to more than one character.")
+	fmt.Fprintf(w, "var _FullCaseFolds = [%d][4]uint16{\n", fullCaseFoldSize)
+	for _, f := range fullCaseFolds {
+		fmt.Fprintf(w, "\t%d: {0x%04X", hash(uint32(f.From), seed, fullCaseFoldShift), f.From)
+		for _, r := range f.To {
+			fmt.Fprintf(w, ", 0x%04X", r)
+		}
+		fmt.Fprintf(w, "}, // %q => %q\n", f.From, string(f.To))
+	}
+	fmt.Fprint(w, "}\n\n")
+}
+
+func genTurkicCaseFold(w *bytes.Buffer) {
+	if len(turkicFolds) == 0 {
+		log.Panic("missing Turkic case-folds")
+	}
+	fmt.Fprintln(w, `
+// TurkicCaseFold returns the Turkic (tr/az) case-fold of r. This is the same
+// as CaseFold except for the Turkic specific mappings of dotted and dotless I.
+func TurkicCaseFold(r rune) rune {
+	switch r {`)
+	for _, p := range turkicFolds {
+		fmt.Fprintf(w, "\tcase %q:\n", rune(p.From))
+		fmt.Fprintf(w, "\t\treturn %q\n", rune(p.To))
+	}
+	fmt.Fprintln(w, "\t}")
+	fmt.Fprintln(w, "\treturn CaseFold(r)")
+	fmt.Fprintln(w, "}")
+}
+
 func dedupe(r []rune) []rune {
 	if len(r) < 2 {
 		return r
@@ -1019,6 +1109,19 @@ func CaseFold(r rune) rune {
 	return r
 }
 
+// FullCaseFold returns the Unicode full case-fold of r or nil if r does not
+// fold to more than one rune. The first element of the returned array is r
+// and the remaining non-zero elements are the runes r folds to.
+func FullCaseFold(r rune) *[4]uint16 {
+	u := uint32(r)
+	h := (u * _FullCaseFoldsSeed) >> _FullCaseFoldsShift
+	p := &_FullCaseFolds[h]
+	if uint32(p[0]) == u && u != 0 {
+		return p
+	}
+	return nil
+}
+
 // TODO: rename
 func FoldMap(r rune) *[4]uint16 {
 	u := uint32(r)
@@ -1912,6 +2015,8 @@ func realMain() int {
 		writeFunctions(&w)
 
 		genCaseFolds(&w, *firstValidHash)
+		genFullCaseFolds(&w, *firstValidHash)
+		genTurkicCaseFold(&w)
 		genUpperLowerTable(&w, *firstValidHash)
 		genFoldTable(&w, *firstValidHash)
 
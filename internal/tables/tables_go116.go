@@ -45,6 +45,19 @@ func CaseFold(r rune) rune {
 	return r
 }
 
+// FullCaseFold returns the Unicode full case-fold of r or nil if r does not
+// fold to more than one rune. The first element of the returned array is r
+// and the remaining non-zero elements are the runes r folds to.
+func FullCaseFold(r rune) *[4]uint16 {
+	u := uint32(r)
+	h := (u * _FullCaseFoldsSeed) >> _FullCaseFoldsShift
+	p := &_FullCaseFolds[h]
+	if uint32(p[0]) == u && u != 0 {
+		return p
+	}
+	return nil
+}
+
 // TODO: rename
 func FoldMap(r rune) *[4]uint16 {
 	u := uint32(r)
@@ -1510,6 +1523,130 @@ var _CaseFolds = [8192]foldPair{
 	7445: {0x16E5F, 0x16E7F}, // '𖹟' => '𖹿'
 }
 
+const _FullCaseFoldsSeed = 0x7F4812C8
+const _FullCaseFoldsShift = 24
+
+// _FullCaseFolds stores the Unicode full case-folds of characters
+// that fold to more than one character.
+var _FullCaseFolds = [256][4]uint16{
+	223: {0x00DF, 0x0073, 0x0073},         // 'ß' => "ss"
+	37:  {0x0130, 0x0069, 0x0307},         // 'İ' => "i̇"
+	147: {0x0149, 0x02BC, 0x006E},         // 'ŉ' => "ʼn"
+	155: {0x01F0, 0x006A, 0x030C},         // 'ǰ' => "ǰ"
+	112: {0x0390, 0x03B9, 0x0308, 0x0301}, // 'ΐ' => "ΐ"
+	89:  {0x03B0, 0x03C5, 0x0308, 0x0301}, // 'ΰ' => "ΰ"
+	135: {0x0587, 0x0565, 0x0582},         // 'և' => "եւ"
+	6:   {0x1E96, 0x0068, 0x0331},         // 'ẖ' => "ẖ"
+	133: {0x1E97, 0x0074, 0x0308},         // 'ẗ' => "ẗ"
+	4:   {0x1E98, 0x0077, 0x030A},         // 'ẘ' => "ẘ"
+	132: {0x1E99, 0x0079, 0x030A},         // 'ẙ' => "ẙ"
+	3:   {0x1E9A, 0x0061, 0x02BE},         // 'ẚ' => "aʾ"
+	0:   {0x1E9E, 0x0073, 0x0073},         // 'ẞ' => "ss"
+	128: {0x1F50, 0x03C5, 0x0313},         // 'ὐ' => "ὐ"
+	127: {0x1F52, 0x03C5, 0x0313, 0x0300}, // 'ὒ' => "ὒ"
+	125: {0x1F54, 0x03C5, 0x0313, 0x0301}, // 'ὔ' => "ὔ"
+	124: {0x1F56, 0x03C5, 0x0313, 0x0342}, // 'ὖ' => "ὖ"
+	94:  {0x1F80, 0x1F00, 0x03B9},         // 'ᾀ' => "ἀι"
+	221: {0x1F81, 0x1F01, 0x03B9},         // 'ᾁ' => "ἁι"
+	92:  {0x1F82, 0x1F02, 0x03B9},         // 'ᾂ' => "ἂι"
+	220: {0x1F83, 0x1F03, 0x03B9},         // 'ᾃ' => "ἃι"
+	91:  {0x1F84, 0x1F04, 0x03B9},         // 'ᾄ' => "ἄι"
+	218: {0x1F85, 0x1F05, 0x03B9},         // 'ᾅ' => "ἅι"
+	90:  {0x1F86, 0x1F06, 0x03B9},         // 'ᾆ' => "ἆι"
+	217: {0x1F87, 0x1F07, 0x03B9},         // 'ᾇ' => "ἇι"
+	88:  {0x1F88, 0x1F00, 0x03B9},         // 'ᾈ' => "ἀι"
+	215: {0x1F89, 0x1F01, 0x03B9},         // 'ᾉ' => "ἁι"
+	87:  {0x1F8A, 0x1F02, 0x03B9},         // 'ᾊ' => "ἂι"
+	214: {0x1F8B, 0x1F03, 0x03B9},         // 'ᾋ' => "ἃι"
+	85:  {0x1F8C, 0x1F04, 0x03B9},         // 'ᾌ' => "ἄι"
+	212: {0x1F8D, 0x1F05, 0x03B9},         // 'ᾍ' => "ἅι"
+	84:  {0x1F8E, 0x1F06, 0x03B9},         // 'ᾎ' => "ἆι"
+	211: {0x1F8F, 0x1F07, 0x03B9},         // 'ᾏ' => "ἇι"
+	82:  {0x1F90, 0x1F20, 0x03B9},         // 'ᾐ' => "ἠι"
+	210: {0x1F91, 0x1F21, 0x03B9},         // 'ᾑ' => "ἡι"
+	81:  {0x1F92, 0x1F22, 0x03B9},         // 'ᾒ' => "ἢι"
+	208: {0x1F93, 0x1F23, 0x03B9},         // 'ᾓ' => "ἣι"
+	79:  {0x1F94, 0x1F24, 0x03B9},         // 'ᾔ' => "ἤι"
+	207: {0x1F95, 0x1F25, 0x03B9},         // 'ᾕ' => "ἥι"
+	78:  {0x1F96, 0x1F26, 0x03B9},         // 'ᾖ' => "ἦι"
+	205: {0x1F97, 0x1F27, 0x03B9},         // 'ᾗ' => "ἧι"
+	77:  {0x1F98, 0x1F20, 0x03B9},         // 'ᾘ' => "ἠι"
+	204: {0x1F99, 0x1F21, 0x03B9},         // 'ᾙ' => "ἡι"
+	75:  {0x1F9A, 0x1F22, 0x03B9},         // 'ᾚ' => "ἢι"
+	202: {0x1F9B, 0x1F23, 0x03B9},         // 'ᾛ' => "ἣι"
+	74:  {0x1F9C, 0x1F24, 0x03B9},         // 'ᾜ' => "ἤι"
+	201: {0x1F9D, 0x1F25, 0x03B9},         // 'ᾝ' => "ἥι"
+	72:  {0x1F9E, 0x1F26, 0x03B9},         // 'ᾞ' => "ἦι"
+	200: {0x1F9F, 0x1F27, 0x03B9},         // 'ᾟ' => "ἧι"
+	71:  {0x1FA0, 0x1F60, 0x03B9},         // 'ᾠ' => "ὠι"
+	198: {0x1FA1, 0x1F61, 0x03B9},         // 'ᾡ' => "ὡι"
+	69:  {0x1FA2, 0x1F62, 0x03B9},         // 'ᾢ' => "ὢι"
+	197: {0x1FA3, 0x1F63, 0x03B9},         // 'ᾣ' => "ὣι"
+	68:  {0x1FA4, 0x1F64, 0x03B9},         // 'ᾤ' => "ὤι"
+	195: {0x1FA5, 0x1F65, 0x03B9},         // 'ᾥ' => "ὥι"
+	67:  {0x1FA6, 0x1F66, 0x03B9},         // 'ᾦ' => "ὦι"
+	194: {0x1FA7, 0x1F67, 0x03B9},         // 'ᾧ' => "ὧι"
+	65:  {0x1FA8, 0x1F60, 0x03B9},         // 'ᾨ' => "ὠι"
+	192: {0x1FA9, 0x1F61, 0x03B9},         // 'ᾩ' => "ὡι"
+	64:  {0x1FAA, 0x1F62, 0x03B9},         // 'ᾪ' => "ὢι"
+	191: {0x1FAB, 0x1F63, 0x03B9},         // 'ᾫ' => "ὣι"
+	62:  {0x1FAC, 0x1F64, 0x03B9},         // 'ᾬ' => "ὤι"
+	189: {0x1FAD, 0x1F65, 0x03B9},         // 'ᾭ' => "ὥι"
+	61:  {0x1FAE, 0x1F66, 0x03B9},         // 'ᾮ' => "ὦι"
+	188: {0x1FAF, 0x1F67, 0x03B9},         // 'ᾯ' => "ὧι"
+	58:  {0x1FB2, 0x1F70, 0x03B9},         // 'ᾲ' => "ὰι"
+	185: {0x1FB3, 0x03B1, 0x03B9},         // 'ᾳ' => "αι"
+	56:  {0x1FB4, 0x03AC, 0x03B9},         // 'ᾴ' => "άι"
+	55:  {0x1FB6, 0x03B1, 0x0342},         // 'ᾶ' => "ᾶ"
+	182: {0x1FB7, 0x03B1, 0x0342, 0x03B9}, // 'ᾷ' => "ᾶι"
+	51:  {0x1FBC, 0x03B1, 0x03B9},         // 'ᾼ' => "αι"
+	46:  {0x1FC2, 0x1F74, 0x03B9},         // 'ῂ' => "ὴι"
+	174: {0x1FC3, 0x03B7, 0x03B9},         // 'ῃ' => "ηι"
+	45:  {0x1FC4, 0x03AE, 0x03B9},         // 'ῄ' => "ήι"
+	44:  {0x1FC6, 0x03B7, 0x0342},         // 'ῆ' => "ῆ"
+	171: {0x1FC7, 0x03B7, 0x0342, 0x03B9}, // 'ῇ' => "ῆι"
+	39:  {0x1FCC, 0x03B7, 0x03B9},         // 'ῌ' => "ηι"
+	35:  {0x1FD2, 0x03B9, 0x0308, 0x0300}, // 'ῒ' => "ῒ"
+	162: {0x1FD3, 0x03B9, 0x0308, 0x0301}, // 'ΐ' => "ΐ"
+	32:  {0x1FD6, 0x03B9, 0x0342},         // 'ῖ' => "ῖ"
+	159: {0x1FD7, 0x03B9, 0x0308, 0x0342}, // 'ῗ' => "ῗ"
+	23:  {0x1FE2, 0x03C5, 0x0308, 0x0300}, // 'ῢ' => "ῢ"
+	151: {0x1FE3, 0x03C5, 0x0308, 0x0301}, // 'ΰ' => "ΰ"
+	22:  {0x1FE4, 0x03C1, 0x0313},         // 'ῤ' => "ῤ"
+	21:  {0x1FE6, 0x03C5, 0x0342},         // 'ῦ' => "ῦ"
+	148: {0x1FE7, 0x03C5, 0x0308, 0x0342}, // 'ῧ' => "ῧ"
+	12:  {0x1FF2, 0x1F7C, 0x03B9},         // 'ῲ' => "ὼι"
+	139: {0x1FF3, 0x03C9, 0x03B9},         // 'ῳ' => "ωι"
+	10:  {0x1FF4, 0x03CE, 0x03B9},         // 'ῴ' => "ώι"
+	9:   {0x1FF6, 0x03C9, 0x0342},         // 'ῶ' => "ῶ"
+	136: {0x1FF7, 0x03C9, 0x0342, 0x03B9}, // 'ῷ' => "ῶι"
+	5:   {0x1FFC, 0x03C9, 0x03B9},         // 'ῼ' => "ωι"
+	170: {0xFB00, 0x0066, 0x0066},         // 'ﬀ' => "ff"
+	41:  {0xFB01, 0x0066, 0x0069},         // 'ﬁ' => "fi"
+	168: {0xFB02, 0x0066, 0x006C},         // 'ﬂ' => "fl"
+	40:  {0xFB03, 0x0066, 0x0066, 0x0069}, // 'ﬃ' => "ffi"
+	167: {0xFB04, 0x0066, 0x0066, 0x006C}, // 'ﬄ' => "ffl"
+	38:  {0xFB05, 0x0073, 0x0074},         // 'ﬅ' => "st"
+	166: {0xFB06, 0x0073, 0x0074},         // 'ﬆ' => "st"
+	28:  {0xFB13, 0x0574, 0x0576},         // 'ﬓ' => "մն"
+	156: {0xFB14, 0x0574, 0x0565},         // 'ﬔ' => "մե"
+	27:  {0xFB15, 0x0574, 0x056B},         // 'ﬕ' => "մի"
+	154: {0xFB16, 0x057E, 0x0576},         // 'ﬖ' => "վն"
+	25:  {0xFB17, 0x0574, 0x056D},         // 'ﬗ' => "մխ"
+}
+
+// TurkicCaseFold returns the Turkic (tr/az) case-fold of r. This is the same
+// as CaseFold except for the Turkic specific mappings of dotted and dotless I.
+func TurkicCaseFold(r rune) rune {
+	switch r {
+	case 'I':
+		return 'ı'
+	case 'İ':
+		return 'i'
+	}
+	return CaseFold(r)
+}
+
 const _UpperLowerSeed = 0x6AE7FD95
 const _UpperLowerShift = 19
 
@@ -94,6 +94,58 @@ func TestUpperLower(t *testing.T) {
 	}
 }
 
+func TestFullCaseFold(t *testing.T) {
+	for r := rune(0); r < utf8.RuneSelf; r++ {
+		if p := FullCaseFold(r); p != nil {
+			t.Errorf("FullCaseFold(%q) = %q; want: nil", r, p)
+		}
+	}
+	n := 0
+	for i := range _FullCaseFolds {
+		p := &_FullCaseFolds[i]
+		if p[0] == 0 {
+			continue
+		}
+		n++
+		r := rune(p[0])
+		if pp := FullCaseFold(r); pp != p {
+			t.Errorf("FullCaseFold(%q) = %q; want: %q", r, pp, p)
+		}
+		// The folded runes must already be case-folded.
+		for _, f := range p[1:] {
+			if f != 0 && CaseFold(rune(f)) != rune(f) {
+				t.Errorf("FullCaseFold(%q): rune %q is not folded", r, rune(f))
+			}
+		}
+		// All runes in the case orbit of r must have the same full case-fold.
+		for o := unicode.SimpleFold(r); o != r; o = unicode.SimpleFold(o) {
+			po := FullCaseFold(o)
+			if po == nil || po[1] != p[1] || po[2] != p[2] || po[3] != p[3] {
+				t.Errorf("FullCaseFold(%q) = %q; want: %q", o, po, p)
+			}
+		}
+	}
+	if n == 0 {
+		t.Fatal("no full case-folds")
+	}
+}
+
+func TestTurkicCaseFold(t *testing.T) {
+	special := map[rune]rune{
+		'I': 'ı',
+		'İ': 'i',
+	}
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		want, ok := special[r]
+		if !ok {
+			want = CaseFold(r)
+		}
+		if got := TurkicCaseFold(r); got != want {
+			t.Errorf("TurkicCaseFold(%q) = %q; want: %q", r, got, want)
+		}
+	}
+}
+
 // visit visits all runes in the given RangeTable in order, calling fn for each.
 func visit(rt *unicode.RangeTable, fn func(rune)) {
 	for _, r16 := range rt.R16 {
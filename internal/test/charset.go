@@ -0,0 +1,120 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+var charSetTests = []string{
+	"",
+	"a",
+	"aB",
+	"k",
+	"K",
+	"\u212A",
+	"s",
+	"ſ",
+	"σ",
+	"µ",
+	"ǅ",
+	"ß",
+	"İı",
+	"☺",
+	"\xff",
+	"\U00010400",
+	"abcxyz0123456789",
+	"\u212Aſ☺αβγ\U00010428",
+}
+
+// CharSetContains tests that the CharSet created from each test string
+// contains all of the string's characters and their simple case-folds
+// and nothing else. The fn argument is called with each test string and
+// must return the set's Contains method.
+func CharSetContains(t *testing.T, fn func(chars string) func(r rune) bool) {
+	for _, chars := range charSetTests {
+		want := make(map[rune]bool)
+		for _, r := range chars {
+			want[r] = true
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				want[f] = true
+			}
+		}
+		contains := fn(chars)
+		errors := 0
+		for r := rune(-1); r <= unicode.MaxRune+1; r++ {
+			if got := contains(r); got != want[r] {
+				t.Errorf("NewCharSet(%q).Contains(%q) = %t; want: %t", chars, r, got, want[r])
+				if errors++; errors >= 10 {
+					break
+				}
+			}
+		}
+	}
+}
+
+func charSetCountReference(s, chars string) int {
+	n := 0
+	for _, r := range s {
+		for _, c := range chars {
+			if r == c || strings.EqualFold(string(r), string(c)) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// CharSetCount tests that fn counts the number of characters in s that are
+// in chars (ignoring case).
+func CharSetCount(t *testing.T, fn func(s, chars string) int) {
+	tests := []struct {
+		s, chars string
+		out      int
+	}{
+		{"", "", 0},
+		{"abc", "", 0},
+		{"", "abc", 0},
+		{"abcABC", "a", 2},
+		{"abcABC", "aC", 4},
+		{"kK\u212A", "k", 3},
+		{"kK\u212A", "\u212A", 3},
+		{"sSſ", "S", 3},
+		{"σΣς", "ς", 3},
+		{"a\xffb\xfe", "\xff", 2},
+		{"a�b\xfe", "�", 2},
+		{"☺☻☹", "☹☺", 2},
+	}
+	for _, tt := range tests {
+		if got := fn(tt.s, tt.chars); got != tt.out {
+			t.Errorf("Count(%q, %q) = %d; want: %d", tt.s, tt.chars, got, tt.out)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	runes := []rune("aAbBkKsS\u212Aſ \u212BåÅσςΣ☺\uFFFD")
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			if rr.Intn(16) == 0 {
+				b.WriteByte(0xff) // invalid UTF-8
+			} else {
+				b.WriteRune(runes[rr.Intn(len(runes))])
+			}
+		}
+		return b.String()
+	}
+	for i := 0; i < 5000; i++ {
+		s := randStr(rr.Intn(16))
+		chars := randStr(rr.Intn(4))
+		want := charSetCountReference(s, chars)
+		if got := fn(s, chars); got != want {
+			t.Errorf("Count(%q, %q) = %d; want: %d", s, chars, got, want)
+		}
+	}
+}
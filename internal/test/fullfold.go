@@ -0,0 +1,388 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// IndexLenFunc is an index function that also returns the length of the match.
+type IndexLenFunc func(s, substr string) (int, int)
+
+func ByteIndexLenFunc(fn func(s, sep []byte) (int, int)) IndexLenFunc {
+	return func(s, sep string) (int, int) {
+		return fn([]byte(s), []byte(sep))
+	}
+}
+
+// PrefixLenFunc is a prefix/suffix function that also returns the length of
+// the match.
+type PrefixLenFunc func(s, prefix string) (bool, int)
+
+func BytePrefixLenFunc(fn func(s, prefix []byte) (bool, int)) PrefixLenFunc {
+	return func(s, prefix string) (bool, int) {
+		return fn([]byte(s), []byte(prefix))
+	}
+}
+
+// hasFullFold returns true if s contains any characters with a full
+// case-folding. The results of simple case-folding tests are only valid
+// for full case-folding if this is false for both strings.
+func hasFullFold(s ...string) bool {
+	for _, ss := range s {
+		for _, r := range ss {
+			if tables.FullCaseFold(r) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fullFoldRunes returns the full case-folding of s.
+func fullFoldRunes(s string) []rune {
+	var rs []rune
+	for _, r := range s {
+		if p := tables.FullCaseFold(r); p != nil {
+			for _, c := range p[1:] {
+				if c != 0 {
+					rs = append(rs, rune(c))
+				}
+			}
+			continue
+		}
+		if r < utf8.RuneSelf {
+			r = rune(strings.ToLower(string(r))[0])
+		}
+		rs = append(rs, tables.CaseFold(r))
+	}
+	return rs
+}
+
+func equalRunes(r1, r2 []rune) bool {
+	if len(r1) != len(r2) {
+		return false
+	}
+	for i := range r1 {
+		if r1[i] != r2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// indexFullReference is a slow but simple reference implementation of
+// IndexFull that checks all substrings of s.
+func indexFullReference(s, sep string) (int, int) {
+	want := fullFoldRunes(sep)
+	for i := range s {
+		for j := i; j <= len(s); j++ {
+			if j < len(s) && !utf8.RuneStart(s[j]) {
+				continue
+			}
+			if equalRunes(fullFoldRunes(s[i:j]), want) {
+				return i, j - i
+			}
+		}
+	}
+	if len(sep) == 0 {
+		return 0, 0
+	}
+	return -1, 0
+}
+
+// randFullFoldString returns a random string composed of characters that
+// have full case-folds and the characters they fold to.
+func randFullFoldString(rr *rand.Rand, n int) string {
+	chars := []string{"s", "S", "ß", "ẞ", "f", "F", "i", "I", "ﬀ", "ﬁ", "ﬃ",
+		"\u0307", "İ", "α", "ι", "ᾳ", "ᾼ", "\u212A", "k", "ſ", "x"}
+	var w strings.Builder
+	for i := 0; i < n; i++ {
+		w.WriteString(chars[rr.Intn(len(chars))])
+	}
+	return w.String()
+}
+
+var compareFullTests = []compareTest{
+	{"ß", "ss", 0},
+	{"ss", "ß", 0},
+	{"ß", "SS", 0},
+	{"ß", "s", 1},
+	{"s", "ß", -1},
+	{"ß", "st", -1},
+	{"ß", "sst", -1},
+	{"ßt", "ss", 1},
+	{"aß", "ASSA", -1},
+	{"straße", "STRASSE", 0},
+	{"STRASSE", "straße", 0},
+	{"straße", "STRAẞE", 0},
+	{"ẞ", "ss", 0},
+	{"ẞ", "ß", 0},
+	{"office", "oﬃce", 0},
+	{"oﬃce", "OFFICE", 0},
+	{"oﬃce", "oﬀice", 0},
+	{"ﬁ", "FI", 0},
+	{"ﬀ", "ff", 0},
+	{"ﬀ", "f", 1},
+	{"İ", "i̇", 0},
+	{"İ", "İ", 0},
+	{"İ", "i", 1},
+	{"ᾳ", "ΑΙ", 0},
+	{"ᾳ", "ᾼ", 0},
+	{"ΐ", "ΐ", 0},
+	{"ŉ", "ʼN", 0},
+	{"αβδß", "ΑΒΔSS", 0},
+	{"αβδß", "ΑΒΔS", 1},
+}
+
+func CompareFull(t *testing.T, fn IndexFunc) {
+	for i, test := range compareTests {
+		if hasFullFold(test.s, test.t) {
+			continue
+		}
+		got := fn(test.s, test.t)
+		if got != test.out {
+			t.Errorf("%d: CompareFull(%q, %q) = %d; want: %d", i, test.s, test.t, got, test.out)
+		}
+	}
+	for i, test := range compareFullTests {
+		got := fn(test.s, test.t)
+		if got != test.out {
+			t.Errorf("%d: CompareFull(%q, %q) = %d; want: %d", i, test.s, test.t, got, test.out)
+		}
+	}
+}
+
+func EqualFoldFull(t *testing.T, fn func(s1, s2 string) bool) {
+	tests := append(compareTests, compareFullTests...)
+	for _, test := range tests {
+		want := test.out == 0
+		got := fn(test.s, test.t)
+		if got != want {
+			t.Errorf("EqualFoldFull(%q, %q) = %t; want: %t", test.s, test.t, got, want)
+		}
+	}
+}
+
+type indexLenTest struct {
+	s   string
+	sep string
+	out int
+	n   int
+}
+
+var indexFullTests = []indexLenTest{
+	{"", "", 0, 0},
+	{"abc", "", 0, 0},
+	{"", "a", -1, 0},
+	{"abc", "d", -1, 0},
+	{"a\u212Ab", "KB", 1, len("\u212Ab")},
+	{"Straße", "SS", 4, 2},
+	{"Straße", "s", 0, 1},
+	{"Straße", "aß", 3, 3},
+	{"Straße", "ASSE", 3, 4},
+	{"STRASSE", "straße", 0, 7},
+	{"aaaß", "SS", 3, 2},
+	{"ß", "s", -1, 0},
+	{"sß", "ss", 1, 2},
+	{"sß", "sss", 0, 3},
+	{"ssss", "ß", 0, 2},
+	{"oﬃce", "office", 0, len("oﬃce")},
+	{"oﬃce", "ffi", 1, len("ﬃ")},
+	{"oﬃce", "fi", -1, 0},
+	{"oﬃce", "ff", -1, 0},
+	{"the office", "OﬃCE", 4, len("office")},
+	{"the oﬃce", "ﬃ", 5, len("ﬃ")},
+	{"xoffice", "oﬃ", 1, len("offi")},
+	{"İstanbul", "i̇s", 0, len("İs")},
+	{"istanbul", "İs", -1, 0},
+	{"ᾳ", "αι", 0, len("ᾳ")},
+	{"ΑΙ", "ᾳ", 0, len("ΑΙ")},
+	{"ᾳ", "α", -1, 0},
+}
+
+func IndexFull(t *testing.T, fn IndexLenFunc) {
+	for _, test := range indexTests {
+		if hasFullFold(test.s, test.sep) {
+			continue
+		}
+		if got, _ := fn(test.s, test.sep); got != test.out {
+			t.Errorf("IndexFull(%q, %q) = %d; want: %d", test.s, test.sep, got, test.out)
+		}
+	}
+	for _, test := range indexFullTests {
+		got, n := fn(test.s, test.sep)
+		if got != test.out || n != test.n {
+			t.Errorf("IndexFull(%q, %q) = %d, %d; want: %d, %d",
+				test.s, test.sep, got, n, test.out, test.n)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 5000; i++ {
+		s := randFullFoldString(rr, rr.Intn(8))
+		sep := randFullFoldString(rr, rr.Intn(4))
+		i0, n0 := indexFullReference(s, sep)
+		i1, n1 := fn(s, sep)
+		if i0 != i1 || n0 != n1 {
+			t.Errorf("IndexFull(%q, %q) = %d, %d; want: %d, %d", s, sep, i1, n1, i0, n0)
+		}
+	}
+}
+
+type prefixLenTest struct {
+	s, prefix string
+	out       bool
+	n         int
+}
+
+var hasPrefixFullTests = []prefixLenTest{
+	{"", "", true, 0},
+	{"abc", "", true, 0},
+	{"", "a", false, 0},
+	{"\u212Ax", "k", true, len("\u212A")},
+	{"straße", "STRASS", true, len("straß")},
+	{"straße", "STRAS", false, 0},
+	{"STRASSE", "straß", true, len("STRASS")},
+	{"ßa", "ss", true, len("ß")},
+	{"ßa", "s", false, 0},
+	{"ﬃx", "FFI", true, len("ﬃ")},
+	{"ﬃx", "FF", false, 0},
+	{"office", "oﬃ", true, len("offi")},
+	{"İx", "i̇", true, len("İ")},
+	{"İx", "i", false, 0},
+}
+
+func HasPrefixFull(t *testing.T, fn PrefixLenFunc) {
+	for _, test := range prefixTests {
+		if hasFullFold(test.s, test.prefix) {
+			continue
+		}
+		if got, _ := fn(test.s, test.prefix); got != test.out {
+			t.Errorf("HasPrefixFull(%q, %q) = %t; want: %t", test.s, test.prefix, got, test.out)
+		}
+	}
+	for _, test := range hasPrefixFullTests {
+		got, n := fn(test.s, test.prefix)
+		if got != test.out || n != test.n {
+			t.Errorf("HasPrefixFull(%q, %q) = %t, %d; want: %t, %d",
+				test.s, test.prefix, got, n, test.out, test.n)
+		}
+	}
+}
+
+var hasSuffixFullTests = []prefixLenTest{
+	{"", "", true, 0},
+	{"abc", "", true, 0},
+	{"", "a", false, 0},
+	{"x\u212A", "k", true, len("\u212A")},
+	{"straße", "SSE", true, len("ße")},
+	{"straße", "SE", false, 0},
+	{"STRASSE", "ße", true, len("SSE")},
+	{"aß", "ss", true, len("ß")},
+	{"aß", "s", false, 0},
+	{"oﬃ", "FI", false, 0},
+	{"oﬃ", "ffi", true, len("ﬃ")},
+	{"oﬃ", "OFFI", true, len("oﬃ")},
+	{"ᾳ", "ι", false, 0},
+	{"ᾳ", "αι", true, len("ᾳ")},
+}
+
+func HasSuffixFull(t *testing.T, fn PrefixLenFunc) {
+	for _, test := range suffixTests {
+		if hasFullFold(test.s, test.suffix) {
+			continue
+		}
+		if got, _ := fn(test.s, test.suffix); got != test.out {
+			t.Errorf("HasSuffixFull(%q, %q) = %t; want: %t", test.s, test.suffix, got, test.out)
+		}
+	}
+	for _, test := range hasSuffixFullTests {
+		got, n := fn(test.s, test.prefix)
+		if got != test.out || n != test.n {
+			t.Errorf("HasSuffixFull(%q, %q) = %t, %d; want: %t, %d",
+				test.s, test.prefix, got, n, test.out, test.n)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 5000; i++ {
+		s := randFullFoldString(rr, rr.Intn(6))
+		suffix := randFullFoldString(rr, rr.Intn(4))
+		want := fullFoldRunes(suffix)
+		var ok bool
+		var n int
+		for j := len(s); j >= 0; j-- {
+			if j < len(s) && !utf8.RuneStart(s[j]) {
+				continue
+			}
+			if equalRunes(fullFoldRunes(s[j:]), want) {
+				ok, n = true, len(s)-j
+				break
+			}
+		}
+		got, gotN := fn(s, suffix)
+		if got != ok || gotN != n {
+			t.Errorf("HasSuffixFull(%q, %q) = %t, %d; want: %t, %d", s, suffix, got, gotN, ok, n)
+		}
+	}
+}
+
+var countFullTests = []struct {
+	s, sep string
+	num    int
+}{
+	{"straße strasse STRAẞE", "SS", 3},
+	{"straße strasse STRAẞE", "ß", 3},
+	{"ßß", "ss", 2},
+	{"ßß", "s", 0},
+	{"ssss", "ß", 2},
+	{"sss", "ß", 1},
+	{"ﬃ ﬃ", "ffi", 2},
+	{"ffiffi", "ﬃ", 2},
+	{"ﬃ", "f", 0},
+}
+
+func CountFull(t *testing.T, fn IndexFunc) {
+	for _, tt := range countTests {
+		if hasFullFold(tt.s, tt.sep) {
+			continue
+		}
+		if num := fn(tt.s, tt.sep); num != tt.num {
+			t.Errorf("CountFull(%q, %q) = %d, want %d", tt.s, tt.sep, num, tt.num)
+		}
+	}
+	for _, tt := range countFullTests {
+		if num := fn(tt.s, tt.sep); num != tt.num {
+			t.Errorf("CountFull(%q, %q) = %d, want %d", tt.s, tt.sep, num, tt.num)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 2000; i++ {
+		s := randFullFoldString(rr, rr.Intn(32))
+		sep := randFullFoldString(rr, rr.Intn(3)+1)
+		want := 0
+		for rest := s; ; {
+			j, n := indexFullReference(rest, sep)
+			if j == -1 {
+				break
+			}
+			want++
+			rest = rest[j+n:]
+		}
+		if num := fn(s, sep); num != want {
+			t.Errorf("CountFull(%q, %q) = %d, want %d", s, sep, num, want)
+		}
+	}
+}
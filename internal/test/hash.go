@@ -0,0 +1,128 @@
+package test
+
+import (
+	"hash/maphash"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+type HashFunc func(seed maphash.Seed, s string) uint64
+
+func ByteHashFunc(fn func(seed maphash.Seed, b []byte) uint64) HashFunc {
+	return func(seed maphash.Seed, s string) uint64 {
+		return fn(seed, []byte(s))
+	}
+}
+
+var hashEqualTests = []struct {
+	s, t string
+}{
+	{"", ""},
+	{"abc", "abc"},
+	{"abc", "ABC"},
+	{"Hello, World!", "hELLO, wORLD!"},
+	{"αβγ", "ΑΒΓ"},
+	{"σας", "ΣΑΣ"},
+	{"k", "\u212A"},      // Kelvin K
+	{"K", "\u212A"},      // Kelvin K
+	{"s", "\u017F"},      // LATIN SMALL LETTER LONG S
+	{"\u00DF", "\u1E9E"}, // LATIN CAPITAL LETTER SHARP S
+	{"\u01C6", "\u01C5"}, // Title case
+	{"stop", "\u017FTOP"},
+	{"a\xffb", "A\xfeB"},
+	{"a\xffb", "a\uFFFDb"},
+	{"\xe2\x84", "\uFFFD\uFFFD"}, // Truncated Kelvin K
+	{strings.Repeat("aB", 100), strings.Repeat("Ab", 100)},
+	{strings.Repeat("k", 100), strings.Repeat("\u212A", 100)},
+	{strings.Repeat("abc", 50) + "\u212A", strings.Repeat("ABC", 50) + "k"},
+}
+
+var hashNotEqualTests = []struct {
+	s, t string
+}{
+	{"", "a"},
+	{"a", "b"},
+	{"abc", "abd"},
+	{"abc", "abc\x00"},
+	{"i", "\u0130"},
+	{"i", "\u0131"},
+	{"\u00DF", "ss"}, // Only equal under full case-folding
+	{strings.Repeat("a", 200), strings.Repeat("a", 199) + "b"},
+}
+
+func Hash(t *testing.T, fn HashFunc) {
+	seed := maphash.MakeSeed()
+	for _, test := range hashEqualTests {
+		h1 := fn(seed, test.s)
+		h2 := fn(seed, test.t)
+		if h1 != h2 {
+			t.Errorf("Hash(%q) = %#x != Hash(%q) = %#x", test.s, h1, test.t, h2)
+		}
+		// Upper and lower case strings of the same length must hash to
+		// the same value as s.
+		for _, s := range []string{strings.ToUpper(test.s), strings.ToLower(test.s)} {
+			if strings.EqualFold(s, test.s) {
+				if h := fn(seed, s); h != h1 {
+					t.Errorf("Hash(%q) = %#x != Hash(%q) = %#x", s, h, test.s, h1)
+				}
+			}
+		}
+	}
+	for _, test := range hashNotEqualTests {
+		h1 := fn(seed, test.s)
+		h2 := fn(seed, test.t)
+		if h1 == h2 {
+			t.Errorf("Hash(%q) == Hash(%q) = %#x", test.s, test.t, h1)
+		}
+	}
+
+	// The same string hashed with different seeds should (almost
+	// certainly) produce different hashes.
+	if h1, h2 := fn(seed, "abc"), fn(maphash.MakeSeed(), "abc"); h1 == h2 {
+		t.Errorf("Hash(%q) is the same for different seeds: %#x", "abc", h1)
+	}
+
+	// Every rune must hash to the same value as every other rune
+	// in its fold orbit.
+	var sb, tb strings.Builder
+	for _, r := range foldableRunes {
+		want := fn(seed, string(r))
+		for rr := unicode.SimpleFold(r); rr != r; rr = unicode.SimpleFold(rr) {
+			if got := fn(seed, string(rr)); got != want {
+				t.Errorf("Hash(%q) = %#x; want: %#x (Hash(%q))", rr, got, want, r)
+			}
+		}
+		// Test runes that cross the buffer boundary of the implementation.
+		sb.WriteRune(r)
+		tb.WriteRune(unicode.SimpleFold(r))
+	}
+	if h1, h2 := fn(seed, sb.String()), fn(seed, tb.String()); h1 != h2 {
+		t.Errorf("Hash(foldableRunes) = %#x != Hash(SimpleFold(foldableRunes)) = %#x", h1, h2)
+	}
+}
+
+func HashFuzz(t *testing.T, fn HashFunc) {
+	seed := maphash.MakeSeed()
+	runRandomTest(t, func(t *fuzzTest) {
+		n := t.rr.Intn(30) + 2
+		r0 := appendRandRunes(t.haystack[:0], t.rr, n)
+		r1 := append(t.needle[:0], r0...)
+		if t.rr.Float64() <= 0.5 {
+			r1 = changeRuneCase(t.rr, r1)
+		} else {
+			r1 = replaceOneRune(t.rr, r1)
+		}
+		s0 := string(r0)
+		s1 := string(r1)
+		h0 := fn(seed, s0)
+		h1 := fn(seed, s1)
+		if strings.EqualFold(s0, s1) {
+			if h0 != h1 {
+				t.Errorf("Hash(%q) = %#x != Hash(%q) = %#x", s0, h0, s1, h1)
+			}
+		} else if h0 == h1 {
+			t.Errorf("Hash(%q) == Hash(%q) = %#x", s0, s1, h0)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+// IndexLenCheckFunc returns an IndexFunc that calls fn and reports an error
+// if the length that it returns is not the length of a case-insensitive
+// match of substr. This allows index functions that return the length of
+// the match to be tested with the Index and LastIndex test suites.
+func IndexLenCheckFunc(t testing.TB, name string, fn IndexLenFunc) IndexFunc {
+	return func(s, substr string) int {
+		i, n := fn(s, substr)
+		if i < 0 {
+			if n != 0 {
+				t.Errorf("%s(%q, %q) = %d, %d; want: -1, 0", name, s, substr, i, n)
+			}
+			return i
+		}
+		if n < 0 || i+n > len(s) || !strings.EqualFold(s[i:i+n], substr) {
+			t.Errorf("%s(%q, %q) = %d, %d: invalid match length", name, s, substr, i, n)
+		}
+		return i
+	}
+}
+
+// PrefixLenCheckFunc returns a ContainsFunc that calls fn and reports an
+// error if the length that it returns is not the length of a case-insensitive
+// match of prefix at the start of s, or at the end of s if suffix is true.
+func PrefixLenCheckFunc(t testing.TB, name string, fn PrefixLenFunc, suffix bool) ContainsFunc {
+	return func(s, prefix string) bool {
+		ok, n := fn(s, prefix)
+		if !ok {
+			if n != 0 {
+				t.Errorf("%s(%q, %q) = %t, %d; want: false, 0", name, s, prefix, ok, n)
+			}
+			return ok
+		}
+		if n < 0 || n > len(s) {
+			t.Errorf("%s(%q, %q) = %t, %d: invalid match length", name, s, prefix, ok, n)
+			return ok
+		}
+		match := s[:n]
+		if suffix {
+			match = s[len(s)-n:]
+		}
+		if !strings.EqualFold(match, prefix) {
+			t.Errorf("%s(%q, %q) = %t, %d: invalid match length", name, s, prefix, ok, n)
+		}
+		return ok
+	}
+}
+
+var indexLenTests = []indexLenTest{
+	{"", "", 0, 0},
+	{"abc", "", 0, 0},
+	{"", "a", -1, 0},
+	{"abc", "x", -1, 0},
+	{"xabcx", "ABC", 1, 3},
+	{"xαβγx", "ΑΒΓ", 1, 6},
+	{"Kelvin", "k", 0, 3},
+	{"Kelvin", "KELVIN", 0, 8},
+	{"kelvin", "K", 0, 1},
+	{"xſx", "S", 1, 2},
+	{"ſK", "sk", 0, 5},
+	{"aKaK", "ka", 1, 4},
+	{"a\xffb", "\xff", 1, 1},
+	{"a\xffb", "�", 1, 1},
+	{"a�b", "\xff", 1, 3},
+}
+
+var lastIndexLenTests = []indexLenTest{
+	{"", "", 0, 0},
+	{"abc", "", 3, 0},
+	{"", "a", -1, 0},
+	{"abc", "x", -1, 0},
+	{"abcABC", "abc", 3, 3},
+	{"Kelvin kelvin", "KELVIN", 9, 6},
+	{"kelvin Kelvin", "KELVIN", 7, 8},
+	{"kK", "k", 1, 3},
+	{"Kk", "K", 3, 1},
+	{"sſ", "S", 1, 2},
+	{"aKaK", "ak", 4, 4},
+}
+
+func testIndexLen(t *testing.T, name string, fn IndexLenFunc, tests []indexLenTest) {
+	for _, tt := range tests {
+		i, n := fn(tt.s, tt.sep)
+		if i != tt.out || n != tt.n {
+			t.Errorf("%s(%q, %q) = %d, %d; want: %d, %d", name, tt.s, tt.sep, i, n, tt.out, tt.n)
+		}
+	}
+}
+
+func IndexLen(t *testing.T, fn IndexLenFunc) {
+	testIndexLen(t, "IndexLen", fn, indexLenTests)
+}
+
+func LastIndexLen(t *testing.T, fn IndexLenFunc) {
+	testIndexLen(t, "LastIndexLen", fn, lastIndexLenTests)
+}
+
+var hasPrefixLenTests = []prefixLenTest{
+	{"", "", true, 0},
+	{"abc", "", true, 0},
+	{"", "a", false, 0},
+	{"abc", "ABC", true, 3},
+	{"abc", "ABCD", false, 0},
+	{"Kelvin", "k", true, 3},
+	{"Kelvin", "KEL", true, 5},
+	{"kelvin", "K", true, 1},
+	{"ſx", "S", true, 2},
+	{"αβγ", "ΑΒ", true, 4},
+	{"İx", "i", false, 0},
+}
+
+var hasSuffixLenTests = []prefixLenTest{
+	{"", "", true, 0},
+	{"abc", "", true, 0},
+	{"", "a", false, 0},
+	{"abc", "BC", true, 2},
+	{"abc", "XABC", false, 0},
+	{"xK", "k", true, 3},
+	{"xKelvin", "KELVIN", true, 8},
+	{"xk", "K", true, 1},
+	{"xſ", "S", true, 2},
+	{"αβγ", "ΒΓ", true, 4},
+	{"xİ", "i", false, 0},
+}
+
+func testPrefixLen(t *testing.T, name string, fn PrefixLenFunc, tests []prefixLenTest) {
+	for _, tt := range tests {
+		ok, n := fn(tt.s, tt.prefix)
+		if ok != tt.out || n != tt.n {
+			t.Errorf("%s(%q, %q) = %t, %d; want: %t, %d", name, tt.s, tt.prefix, ok, n, tt.out, tt.n)
+		}
+	}
+}
+
+func HasPrefixLen(t *testing.T, fn PrefixLenFunc) {
+	testPrefixLen(t, "HasPrefixLen", fn, hasPrefixLenTests)
+	check := PrefixLenCheckFunc(t, "HasPrefixLen", fn, false)
+	for _, test := range prefixTests {
+		if out := check(test.s, test.prefix); out != test.out {
+			t.Errorf("HasPrefixLen(%q, %q) = %t; want: %t", test.s, test.prefix, out, test.out)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	runRandomTest(t, func(t *fuzzTest) {
+		s, prefix, want, _ := t.HasPrefixArgs()
+		if got := check(s, prefix); got != want {
+			t.Errorf("HasPrefixLen(%q, %q) = %t; want: %t", s, prefix, got, want)
+		}
+	})
+}
+
+func HasSuffixLen(t *testing.T, fn PrefixLenFunc) {
+	testPrefixLen(t, "HasSuffixLen", fn, hasSuffixLenTests)
+	check := PrefixLenCheckFunc(t, "HasSuffixLen", fn, true)
+	HasSuffix(t, check)
+	if t.Failed() {
+		return
+	}
+	HasSuffixFuzz(t, check)
+}
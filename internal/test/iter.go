@@ -0,0 +1,138 @@
+//go:build go1.23
+// +build go1.23
+
+package test
+
+import (
+	"iter"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// AllIndexesFunc is the signature of AllIndexes.
+type AllIndexesFunc func(s, substr string) iter.Seq2[int, int]
+
+func ByteAllIndexesFunc(fn func(s, substr []byte) iter.Seq2[int, int]) AllIndexesFunc {
+	return func(s, substr string) iter.Seq2[int, int] {
+		return fn([]byte(s), []byte(substr))
+	}
+}
+
+// SeqSplitFunc returns a SplitFunc that collects the results of seq when n
+// is negative and otherwise calls splitN.
+func SeqSplitFunc(seq func(s, sep string) iter.Seq[string], splitN SplitFunc) SplitFunc {
+	return func(s, sep string, n int) []string {
+		if n >= 0 {
+			return splitN(s, sep, n)
+		}
+		a := []string{}
+		for v := range seq(s, sep) {
+			a = append(a, v)
+		}
+		return a
+	}
+}
+
+func ByteSeqSplitFunc(seq func(s, sep []byte) iter.Seq[[]byte], splitN func(s, sep []byte, n int) [][]byte) SplitFunc {
+	return SeqSplitFunc(func(s, sep string) iter.Seq[string] {
+		return func(yield func(string) bool) {
+			for b := range seq([]byte(s), []byte(sep)) {
+				if !yield(string(b)) {
+					return
+				}
+			}
+		}
+	}, ByteSplitFunc(splitN))
+}
+
+type match struct{ Index, Len int }
+
+func collectIndexes(seq iter.Seq2[int, int]) []match {
+	a := []match{}
+	for i, n := range seq {
+		a = append(a, match{i, n})
+	}
+	return a
+}
+
+var allIndexesTests = []struct {
+	s, substr string
+	out       []match
+}{
+	{"", "", []match{{0, 0}}},
+	{"", "a", []match{}},
+	{"abc", "", []match{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+	{"a☺b", "", []match{{0, 0}, {1, 0}, {4, 0}, {5, 0}}},
+	{"a\xffb", "", []match{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+	{"abc", "x", []match{}},
+	{"aXbxc", "x", []match{{1, 1}, {3, 1}}},
+	{"aaaa", "AA", []match{{0, 2}, {2, 2}}},
+	{"aaa", "aa", []match{{0, 2}}},
+	{"αΣβσγς", "σ", []match{{2, 2}, {6, 2}, {10, 2}}},
+
+	// The length of a match may differ from the length of substr.
+	{"aKb", "k", []match{{1, 3}}},
+	{"KkK", "k", []match{{0, 3}, {3, 1}, {4, 3}}},
+	{"akb", "K", []match{{1, 1}}},
+	{"aKſb", "KS", []match{{1, 5}}},
+	{"ſtop STOP stop", "stop", []match{{0, 5}, {6, 4}, {11, 4}}},
+}
+
+func AllIndexes(t *testing.T, fn AllIndexesFunc) {
+	for _, tt := range allIndexesTests {
+		out := collectIndexes(fn(tt.s, tt.substr))
+		if !reflect.DeepEqual(out, tt.out) {
+			t.Errorf("AllIndexes(%q, %q) = %v; want: %v", tt.s, tt.substr, out, tt.out)
+		}
+	}
+
+	// Stopping early must not panic.
+	for range fn("aaa", "a") {
+		break
+	}
+	for range fn("aaa", "") {
+		break
+	}
+
+	// The iterator can be used more than once.
+	seq := fn("aXbxc", "x")
+	if a, b := collectIndexes(seq), collectIndexes(seq); !reflect.DeepEqual(a, b) {
+		t.Errorf("AllIndexes: second iteration = %v; want: %v", b, a)
+	}
+	if t.Failed() {
+		return
+	}
+
+	// For lower case ASCII strings the result must match strings.Index.
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rr.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		s := randStr(rr.Intn(16))
+		substr := randStr(rr.Intn(3) + 1)
+		want := []match{}
+		for j := 0; ; {
+			o := strings.Index(s[j:], substr)
+			if o < 0 {
+				break
+			}
+			want = append(want, match{j + o, len(substr)})
+			j += o + len(substr)
+		}
+		if got := collectIndexes(fn(s, substr)); !reflect.DeepEqual(got, want) {
+			t.Errorf("AllIndexes(%q, %q) = %v; want: %v", s, substr, got, want)
+		}
+		upper := strings.ToUpper(s)
+		if got := collectIndexes(fn(upper, substr)); !reflect.DeepEqual(got, want) {
+			t.Errorf("AllIndexes(%q, %q) = %v; want: %v", upper, substr, got, want)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// MultiMatcherFuncs are the methods of a MultiMatcher. Matches are
+// represented as {start, end, pattern}.
+type MultiMatcherFuncs struct {
+	Contains func(s string) bool
+	Find     func(s string) ([3]int, bool)
+	FindAll  func(s string, n int) [][3]int
+}
+
+// multiMatchReference returns all of the leftmost-first matches of needles
+// in s, using the same rules as Replacer.
+func multiMatchReference(needles []string, s string, n int) [][3]int {
+	matches := [][3]int{}
+	prevEmpty := false
+	for i := 0; i <= len(s) && (n < 0 || len(matches) < n); {
+		found := false
+		for id, needle := range needles {
+			if needle == "" && prevEmpty {
+				continue
+			}
+			// Simple case-folding maps one rune to one rune.
+			end := i
+			for range needle {
+				if end == len(s) {
+					end = -1
+					break
+				}
+				_, size := utf8.DecodeRuneInString(s[end:])
+				end += size
+			}
+			if end >= 0 && strings.EqualFold(s[i:end], needle) {
+				matches = append(matches, [3]int{i, end, id})
+				prevEmpty = i == end
+				i = end
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		prevEmpty = false
+		if i == len(s) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return matches
+}
+
+type multiMatchTest struct {
+	needles []string
+	s       string
+	out     [][3]int
+}
+
+var multiMatchTests = []multiMatchTest{
+	{nil, "abc", [][3]int{}},
+	{[]string{"x"}, "", [][3]int{}},
+	{[]string{"x"}, "abc", [][3]int{}},
+	{[]string{"b"}, "abc", [][3]int{{1, 2, 0}}},
+	{[]string{"B"}, "abcABC", [][3]int{{1, 2, 0}, {4, 5, 0}}},
+	{[]string{"he", "she", "his", "hers"}, "ushers", [][3]int{{1, 4, 1}}},
+	{[]string{"HE", "SHE", "HIS", "HERS"}, "uShErS", [][3]int{{1, 4, 1}}},
+	{[]string{"hers", "he"}, "hershe", [][3]int{{0, 4, 0}, {4, 6, 1}}},
+	{[]string{"he", "hers"}, "hershe", [][3]int{{0, 2, 0}, {4, 6, 0}}},
+	{[]string{"abcd", "bc"}, "abce", [][3]int{{1, 3, 1}}},
+	{[]string{"abcd", "b"}, "abcd", [][3]int{{0, 4, 0}}},
+	{[]string{"b", "abcd"}, "abcd", [][3]int{{0, 4, 1}}},
+	{[]string{"a", "a"}, "aA", [][3]int{{0, 1, 0}, {1, 2, 0}}},
+	{[]string{"aaa", "aa", "a"}, "aAaA", [][3]int{{0, 3, 0}, {3, 4, 2}}},
+	{[]string{"αβγ", "ΒΓ"}, "xΑΒx", [][3]int{}},
+	{[]string{"αβγ", "ΒΓ"}, "xΑβΓx", [][3]int{{1, 7, 0}}},
+	{[]string{"σ"}, "σΣς", [][3]int{{0, 2, 0}, {2, 4, 0}, {4, 6, 0}}},
+
+	// The length of a match may differ from the length of the needle.
+	{[]string{"k"}, "Kk", [][3]int{{0, 3, 0}, {3, 4, 0}}},
+	{[]string{"K"}, "kK", [][3]int{{0, 1, 0}, {1, 2, 0}}},
+	{[]string{"ks", "s"}, "Kſ", [][3]int{{0, 5, 0}}},
+	{[]string{"stop", "top"}, "ſtop", [][3]int{{0, 5, 0}}},
+	{[]string{"i"}, "İiıI", [][3]int{{2, 3, 0}, {5, 6, 0}}},
+
+	// Empty needles match at every character boundary.
+	{[]string{""}, "", [][3]int{{0, 0, 0}}},
+	{[]string{""}, "a☺", [][3]int{{0, 0, 0}, {1, 1, 0}, {4, 4, 0}}},
+	{[]string{"a", ""}, "ab", [][3]int{{0, 1, 0}, {1, 1, 1}, {2, 2, 1}}},
+	{[]string{"", "a"}, "ab", [][3]int{{0, 0, 0}, {0, 1, 1}, {1, 1, 0}, {2, 2, 0}}},
+
+	// Invalid UTF-8
+	{[]string{"\xff"}, "a\xffb\xfe", [][3]int{{1, 2, 0}, {3, 4, 0}}},
+	{[]string{"b\xff"}, "ab\xfeB\xff", [][3]int{{1, 3, 0}, {3, 5, 0}}},
+}
+
+func MultiMatcher(t *testing.T, compile func(needles ...string) MultiMatcherFuncs) {
+	test := func(needles []string, s string, want [][3]int) {
+		t.Helper()
+		m := compile(needles...)
+		got := m.FindAll(s, -1)
+		if got == nil {
+			got = [][3]int{}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("NewMultiMatcher(%q).FindAll(%q, -1) = %v; want: %v", needles, s, got, want)
+			return
+		}
+		if len(want) > 1 {
+			if got := m.FindAll(s, 1); !reflect.DeepEqual(got, want[:1]) {
+				t.Errorf("NewMultiMatcher(%q).FindAll(%q, 1) = %v; want: %v", needles, s, got, want[:1])
+			}
+		}
+		if got := m.FindAll(s, 0); len(got) != 0 {
+			t.Errorf("NewMultiMatcher(%q).FindAll(%q, 0) = %v; want: []", needles, s, got)
+		}
+		match, ok := m.Find(s)
+		if ok != (len(want) > 0) || (ok && match != want[0]) {
+			t.Errorf("NewMultiMatcher(%q).Find(%q) = %v, %t; want: %v", needles, s, match, ok, want)
+		}
+		if got := m.Contains(s); got != (len(want) > 0) {
+			t.Errorf("NewMultiMatcher(%q).Contains(%q) = %t; want: %t", needles, s, got, len(want) > 0)
+		}
+	}
+	for _, tt := range multiMatchTests {
+		if want := multiMatchReference(tt.needles, tt.s, -1); !reflect.DeepEqual(want, tt.out) {
+			t.Fatalf("invalid test: reference(%q, %q) = %v; want: %v", tt.needles, tt.s, want, tt.out)
+		}
+		test(tt.needles, tt.s, tt.out)
+	}
+	if t.Failed() {
+		return
+	}
+
+	// Compare against the reference using random strings that contain
+	// characters with multiple case-folds.
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	runes := []rune("aAbBkKsSKſσς")
+	randStr := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			if rr.Intn(32) == 0 {
+				b.WriteByte(0xff) // invalid UTF-8
+			} else {
+				b.WriteRune(runes[rr.Intn(len(runes))])
+			}
+		}
+		return b.String()
+	}
+	for i := 0; i < 2000; i++ {
+		needles := make([]string, rr.Intn(6)+1)
+		for j := range needles {
+			needles[j] = randStr(rr.Intn(4))
+			if needles[j] == "" && rr.Intn(4) != 0 {
+				needles[j] = randStr(1)
+			}
+		}
+		s := randStr(rr.Intn(24))
+		test(needles, s, multiMatchReference(needles, s, -1))
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// IndexAnyStringFunc returns the index of the first instance of any of
+// needles in s and the index of the needle that matched.
+type IndexAnyStringFunc func(s string, needles []string) (int, int)
+
+func ByteIndexAnyStringFunc(fn func(s []byte, needles []string) (int, int)) IndexAnyStringFunc {
+	return func(s string, needles []string) (int, int) {
+		return fn([]byte(s), needles)
+	}
+}
+
+type indexAnyStringTest struct {
+	s       string
+	needles []string
+	index   int
+	needle  int
+}
+
+var indexAnyStringTests = []indexAnyStringTest{
+	{"", nil, -1, -1},
+	{"abc", nil, -1, -1},
+	{"", []string{"a", "b"}, -1, -1},
+	{"", []string{"a", ""}, 0, 1},
+	{"abc", []string{"b"}, 1, 0},
+	{"abc", []string{"B", "C"}, 1, 0},
+	{"abc", []string{"C", "B"}, 1, 1},
+	{"abc", []string{"x", "y"}, -1, -1},
+	{"abc", []string{"x", ""}, 0, 1},
+	{"abc", []string{"A", ""}, 0, 0},
+	{"foobar", []string{"FOOBAR", "foo"}, 0, 0},
+	{"foobar", []string{"FOO", "foobar"}, 0, 0},
+	{"xxfoo", []string{"bar", "FOO", "oo"}, 2, 1},
+	{"The Kelvin scale", []string{"SCALE", "KELVIN"}, 4, 1},
+	{"αβγ ΑΒΓ", []string{"xyz", "ΒΓ"}, 2, 1},
+	{"STRAẞE", []string{"q", "ß"}, 4, 1},
+	{"İi", []string{"x", "i"}, 2, 1},
+	{"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxy", []string{"YZ", "XY"}, 60, 1},
+	{strings.Repeat("ab", 40) + "abc", []string{"BAC", "ABCD", "BC"}, 81, 2},
+
+	// The length of a match may differ from the length of the needle.
+	{"aKb", []string{"zz", "KB"}, 1, 1},
+	{"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxaKb", []string{"zz", "AK"}, 38, 1},
+	{"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxkkk", []string{"zz", "KKK"}, 38, 1},
+	{"xſtop", []string{"TOP", "STOP"}, 1, 1},
+	{"xstop", []string{"top", "ſtop"}, 1, 1},
+
+	// Invalid UTF-8
+	{"a\xffb", []string{"x", "\xffB"}, 1, 1},
+	{"a\xffb", []string{"x", "�B"}, 1, 1},
+	{"a\u212A", []string{"x", "\xff"}, -1, -1},
+	{"a\u212A\xff", []string{"x", "\xff"}, 4, 1},
+}
+
+func IndexAnyString(t *testing.T, fn IndexAnyStringFunc) {
+	// reference returns the expected result using the MultiMatcher reference.
+	reference := func(s string, needles []string) (int, int) {
+		if m := multiMatchReference(needles, s, 1); len(m) > 0 {
+			return m[0][0], m[0][2]
+		}
+		return -1, -1
+	}
+	for _, tt := range indexAnyStringTests {
+		if i, j := reference(tt.s, tt.needles); i != tt.index || j != tt.needle {
+			t.Fatalf("invalid test: reference(%q, %q) = %d, %d; want: %d, %d",
+				tt.s, tt.needles, i, j, tt.index, tt.needle)
+		}
+		if i, j := fn(tt.s, tt.needles); i != tt.index || j != tt.needle {
+			t.Errorf("IndexAnyString(%q, %q) = %d, %d; want: %d, %d",
+				tt.s, tt.needles, i, j, tt.index, tt.needle)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+
+	// Compare against the reference using random strings that contain
+	// characters with multiple case-folds and numbers of needles that
+	// are searched for with one or more SIMD filters.
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	runes := []rune("abcxyzABCXYZkKsSKſσς")
+	randStr := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			if rr.Intn(64) == 0 {
+				b.WriteByte(0xff) // invalid UTF-8
+			} else {
+				b.WriteRune(runes[rr.Intn(len(runes))])
+			}
+		}
+		return b.String()
+	}
+	for i := 0; i < 2000; i++ {
+		var needles []string
+		switch rr.Intn(4) {
+		case 0:
+			needles = make([]string, rr.Intn(200)+1)
+		default:
+			needles = make([]string, rr.Intn(12)+2)
+		}
+		for j := range needles {
+			needles[j] = randStr(rr.Intn(5) + 1)
+		}
+		if rr.Intn(32) == 0 {
+			needles[rr.Intn(len(needles))] = ""
+		}
+		s := randStr(rr.Intn(128))
+		wantIndex, wantNeedle := reference(s, needles)
+		if i, j := fn(s, needles); i != wantIndex || j != wantNeedle {
+			t.Fatalf("IndexAnyString(%q, %q) = %d, %d; want: %d, %d",
+				s, needles, i, j, wantIndex, wantNeedle)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// matchAt reports whether sep matches s[i:] rune by rune ignoring case.
+func matchAt(s, sep string, i int) bool {
+	for _, r := range sep {
+		if i == len(s) {
+			return false
+		}
+		sr, n := utf8.DecodeRuneInString(s[i:])
+		if !EqualRune(sr, r) {
+			return false
+		}
+		i += n
+	}
+	return true
+}
+
+// indexReference is a slow, but accurate case-insensitive version of
+// strings.Index that handles invalid UTF-8.
+func indexReference(s, sep string) int {
+	for i := 0; i <= len(s); {
+		if matchAt(s, sep, i) {
+			return i
+		}
+		if i == len(s) {
+			break
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+	}
+	return -1
+}
+
+// lastIndexReference is a slow, but accurate case-insensitive version of
+// strings.LastIndex that handles invalid UTF-8.
+func lastIndexReference(s, sep string) int {
+	last := -1
+	for i := 0; i <= len(s); {
+		if matchAt(s, sep, i) {
+			last = i
+		}
+		if i == len(s) {
+			break
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+	}
+	return last
+}
+
+// periodicArgs returns a needle that consists of repetitions of a short
+// random pattern, which may be mutated, and a string to search that
+// contains many partial matches of the needle.
+func periodicArgs(rr *rand.Rand) (s, sep string) {
+	// Characters with case-folds of different lengths and invalid UTF-8.
+	runes := []string{"a", "A", "b", "B", "k", "K", "K", "s", "ſ", "\xff"}
+	randStr := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteString(runes[rr.Intn(len(runes))])
+		}
+		return b.String()
+	}
+	pattern := randStr(rr.Intn(3) + 1)
+	sep = strings.Repeat(pattern, rr.Intn(32)+1)
+	if rr.Intn(2) == 0 {
+		sep += randStr(rr.Intn(3) + 1)
+	}
+	var b strings.Builder
+	for n := rr.Intn(256) + len(sep)/2; b.Len() < n; {
+		switch rr.Intn(4) {
+		case 0:
+			b.WriteString(randStr(1))
+		case 1:
+			b.WriteString(sep)
+		default:
+			b.WriteString(pattern)
+		}
+	}
+	return b.String(), sep
+}
+
+// IndexPeriodic tests fn with periodic needles, which exercise the periodic
+// case of the Two-Way algorithm.
+func IndexPeriodic(t *testing.T, fn IndexFunc) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 5000; i++ {
+		s, sep := periodicArgs(rr)
+		if got, want := fn(s, sep), indexReference(s, sep); got != want {
+			t.Fatalf("Index(%q, %q) = %d; want: %d", s, sep, got, want)
+		}
+	}
+}
+
+// LastIndexPeriodic tests fn with periodic needles, which exercise the
+// periodic case of the Two-Way algorithm.
+func LastIndexPeriodic(t *testing.T, fn IndexFunc) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 5000; i++ {
+		s, sep := periodicArgs(rr)
+		if got, want := fn(s, sep), lastIndexReference(s, sep); got != want {
+			t.Fatalf("LastIndex(%q, %q) = %d; want: %d", s, sep, got, want)
+		}
+	}
+}
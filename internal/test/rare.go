@@ -0,0 +1,71 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// rareArgs returns a string to search that mostly consists of common runes
+// and a needle taken from it (with the case of its runes changed) that
+// contains rare runes, which are not at the start of the needle.
+func rareArgs(rr *rand.Rand) (s, sep string) {
+	common := []string{"t", "T", "h", "H", "e", "E", " "}
+	// Rare runes and runes with case-folds of different lengths.
+	rare := []string{"z", "Z", "q", "k", "K", "K", "s", "ſ", "\xff", "é", "É"}
+	var b strings.Builder
+	for n := rr.Intn(512) + 17; b.Len() < n; {
+		if rr.Intn(8) == 0 {
+			b.WriteString(rare[rr.Intn(len(rare))])
+		} else {
+			b.WriteString(common[rr.Intn(len(common))])
+		}
+	}
+	s = b.String()
+
+	// Byte offsets of the runes in s.
+	var offs []int
+	for i := range s {
+		offs = append(offs, i)
+	}
+	offs = append(offs, len(s))
+
+	i := rr.Intn(len(offs) - 3)
+	n := len(offs) - i - 3
+	if n > 32 {
+		n = 32
+	}
+	j := i + 2 + rr.Intn(n+1)
+
+	// Change the case of the needle, but keep invalid UTF-8 as is since
+	// converting it to a string of runes would replace it with U+FFFD.
+	var sb strings.Builder
+	for k := i; k < j; k++ {
+		r := s[offs[k]:offs[k+1]]
+		if r[0] >= utf8.RuneSelf && len(r) == 1 {
+			sb.WriteString(r)
+		} else {
+			sb.WriteRune(randCaseRune(rr, []rune(r)[0]))
+		}
+	}
+	sep = sb.String()
+	if rr.Intn(4) == 0 && utf8.ValidString(sep) {
+		sep = string(replaceOneRune(rr, []rune(sep)))
+	}
+	return s, sep
+}
+
+// IndexRare tests fn with needles that start with common runes and contain
+// rare ones, which exercises searching for rare runes in the middle of the
+// needle.
+func IndexRare(t *testing.T, fn IndexFunc) {
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 5000; i++ {
+		s, sep := rareArgs(rr)
+		if got, want := fn(s, sep), indexReference(s, sep); got != want {
+			t.Fatalf("Index(%q, %q) = %d; want: %d", s, sep, got, want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ReplaceFunc func(s, old, new string, n int) string
+
+func ByteReplaceFunc(fn func(s, old, new []byte, n int) []byte) ReplaceFunc {
+	return func(s, old, new string, n int) string {
+		return string(fn([]byte(s), []byte(old), []byte(new), n))
+	}
+}
+
+type replaceTest struct {
+	in       string
+	old, new string
+	n        int
+	out      string
+}
+
+var replaceTests = []replaceTest{
+	// From the strings package
+	{"hello", "l", "L", 0, "hello"},
+	{"hello", "l", "L", -1, "heLLo"},
+	{"hello", "x", "X", -1, "hello"},
+	{"", "x", "X", -1, ""},
+	{"radar", "r", "<r>", -1, "<r>ada<r>"},
+	{"", "", "<>", -1, "<>"},
+	{"banana", "a", "<>", -1, "b<>n<>n<>"},
+	{"banana", "a", "<>", 1, "b<>nana"},
+	{"banana", "a", "<>", 1000, "b<>n<>n<>"},
+	{"banana", "an", "<>", -1, "b<><>a"},
+	{"banana", "ana", "<>", -1, "b<>na"},
+	{"banana", "", "<>", -1, "<>b<>a<>n<>a<>n<>a<>"},
+	{"banana", "", "<>", 10, "<>b<>a<>n<>a<>n<>a<>"},
+	{"banana", "", "<>", 6, "<>b<>a<>n<>a<>n<>a"},
+	{"banana", "", "<>", 5, "<>b<>a<>n<>a<>na"},
+	{"banana", "", "<>", 1, "<>banana"},
+	{"banana", "a", "a", -1, "banana"},
+	{"banana", "a", "a", 1, "banana"},
+	{"☺☻☹", "", "<>", -1, "<>☺<>☻<>☹<>"},
+
+	// Case-insensitive
+	{"hello", "L", "x", -1, "hexxo"},
+	{"HELLO", "l", "x", -1, "HExxO"},
+	{"HELLO", "l", "x", 1, "HExLO"},
+	{"BANANA", "ana", "<>", -1, "B<>NA"},
+	{"bAnAnA", "A", "a", -1, "banana"},
+	{"αβγ ΑΒΓ", "ΑΒΓ", "x", -1, "x x"},
+	{"İi", "i", "x", -1, "İx"},
+	{"ıI", "i", "x", -1, "ıx"},
+
+	// The length of a match may differ from the length of old.
+	{"aKb", "k", "x", -1, "axb"},
+	{"aKb", "KB", "x", -1, "ax"},
+	{"KKk", "k", "", -1, ""},
+	{"KKk", "kk", "x", -1, "xk"},
+	{"akb", "K", "x", -1, "axb"},
+	{"akb", "KB", "x", -1, "ax"},
+	{"ſtop STOP stop", "stop", "go", -1, "go go go"},
+	{"ſtop STOP stop", "STOP", "go", 2, "go go stop"},
+	{"ſſſ", "S", "s", -1, "sss"},
+	{"aKſb", "ks", "", -1, "ab"},
+
+	// Invalid UTF-8
+	{"a\xffb", "\xff", "x", -1, "axb"},
+	{"a\xffb\xfe", "\xfe", "x", -1, "axbx"},
+}
+
+func Replace(t *testing.T, fn ReplaceFunc) {
+	for _, tt := range replaceTests {
+		if s := fn(tt.in, tt.old, tt.new, tt.n); s != tt.out {
+			t.Errorf("Replace(%q, %q, %q, %d) = %q; want: %q", tt.in, tt.old, tt.new, tt.n, s, tt.out)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	// For lower case ASCII strings the result must match strings.Replace.
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rr.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		s := randStr(rr.Intn(16))
+		old := randStr(rr.Intn(3))
+		n := rr.Intn(5) - 1
+		want := strings.Replace(s, old, "X", n)
+		if got := fn(s, old, "X", n); got != want {
+			t.Errorf("Replace(%q, %q, %q, %d) = %q; want: %q", s, old, "X", n, got, want)
+		}
+		// Changing the case of s must not change which instances are replaced.
+		if got := fn(strings.ToUpper(s), old, "X", n); got != strings.ToUpper(want) {
+			t.Errorf("Replace(%q, %q, %q, %d) = %q; want: %q",
+				strings.ToUpper(s), old, "X", n, got, strings.ToUpper(want))
+		}
+	}
+}
+
+// ReplacerFunc returns a function that replaces all of the old/new pairs in
+// oldnew (see strings.NewReplacer).
+type ReplacerFunc func(oldnew ...string) func(s string) string
+
+type replacerTest struct {
+	oldnew []string
+	in     string
+	out    string
+}
+
+var replacerTests = []replacerTest{
+	// From the strings package
+	{[]string{"a", "1", "a", "2"}, "brad", "br1d"},
+	{[]string{"a", "1", "b", "2"}, "brad", "2r1d"},
+	{[]string{"aaa", "3", "aa", "2", "a", "1"}, "aaaa", "31"},
+	{[]string{"", "X"}, "", "X"},
+	{[]string{"", "X"}, "foo", "XfXoXoX"},
+	{[]string{"a", "1", "", "X"}, "ab", "1XbX"},
+	{[]string{"", "X", "a", "1"}, "ab", "X1XbX"},
+	{[]string{"a", "1", "", "X", "b", "2"}, "abc", "1X2XcX"},
+	{[]string{"a", "A", "b", "B"}, "abc", "ABc"},
+	{[]string{"&", "&amp;", "<", "&lt;", ">", "&gt;"}, "<a&b>", "&lt;a&amp;b&gt;"},
+	{[]string{"hello", "world"}, "hello hello", "world world"},
+	{[]string{"a", "b", "b", "a"}, "abba", "baab"},
+	{nil, "abc", "abc"},
+
+	// Case-insensitive
+	{[]string{"A", "1", "a", "2"}, "brad BRAD", "br1d BR1D"},
+	{[]string{"HELLO", "world"}, "Hello hELLo", "world world"},
+	{[]string{"aaa", "3", "aa", "2", "a", "1"}, "aAaA", "31"},
+	{[]string{"a", "b", "B", "a"}, "AbBa", "baab"},
+	{[]string{"k", "x", "s", "y"}, "Kſ", "xy"},
+	{[]string{"K", "x", "ſ", "y"}, "kKsS", "xxyy"},
+	{[]string{"ks", "x"}, "aKſA", "axA"},
+	{[]string{"i", "x"}, "iIİı", "xxİı"},
+
+	// Unlike the strings package empty strings only match on character
+	// boundaries.
+	{[]string{"", "X"}, "é☺", "XéX☺X"},
+	{[]string{"a", "1", "", "X"}, "aé", "1XéX"},
+}
+
+func Replacer(t *testing.T, fn ReplacerFunc) {
+	for _, tt := range replacerTests {
+		if s := fn(tt.oldnew...)(tt.in); s != tt.out {
+			t.Errorf("NewReplacer(%q).Replace(%q) = %q; want: %q", tt.oldnew, tt.in, s, tt.out)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	// For lower case ASCII strings the result must match strings.Replacer.
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rr.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		oldnew := make([]string, 2*(rr.Intn(4)+1))
+		for j := 0; j < len(oldnew); j += 2 {
+			oldnew[j] = randStr(rr.Intn(4))
+			oldnew[j+1] = strings.Repeat("X", j/2+1)
+		}
+		s := randStr(rr.Intn(16))
+		want := strings.NewReplacer(oldnew...).Replace(s)
+		if got := fn(oldnew...)(s); got != want {
+			t.Errorf("NewReplacer(%q).Replace(%q) = %q; want: %q", oldnew, s, got, want)
+		}
+		if got := fn(oldnew...)(strings.ToUpper(s)); got != strings.ToUpper(want) {
+			t.Errorf("NewReplacer(%q).Replace(%q) = %q; want: %q",
+				oldnew, strings.ToUpper(s), got, strings.ToUpper(want))
+		}
+	}
+}
+
+var replacePreserveCaseTests = []replaceTest{
+	{"color Color COLOR", "color", "colour", -1, "colour Colour COLOUR"},
+	{"color Color COLOR", "COLOR", "colour", -1, "colour Colour COLOUR"},
+	{"color Color COLOR", "color", "COLOUR", -1, "colour Colour COLOUR"},
+	{"color Color COLOR", "color", "cOlOuR", 2, "colour Colour COLOR"},
+	{"color Color COLOR", "color", "colour", 0, "color Color COLOR"},
+	{"no match", "color", "colour", -1, "no match"},
+	{"", "", "X", -1, "X"},
+	{"ab", "", "X", -1, "XaXbX"},
+
+	// A single upper case character is title case.
+	{"a A", "a", "xyz", -1, "xyz Xyz"},
+	{"go Go GO gO", "go", "rust", -1, "rust Rust RUST rUst"},
+
+	// Characters without case are ignored when matching the case pattern.
+	{"1a 1A", "1a", "2xy", -1, "2xy 2Xy"},
+	{"1ab 1AB", "1ab", "2xy", -1, "2xy 2XY"},
+	{"x-ray X-ray X-RAY", "x-ray", "y-ray", -1, "y-ray Y-ray Y-RAY"},
+	{"123", "123", "abc", -1, "abc"},
+	{"123", "123", "ABC", -1, "ABC"},
+	{"a1", "A1", "b", -1, "b"},
+
+	// Mixed case is applied per-character.
+	{"HeLLo", "hello", "world", -1, "WoRLd"},
+	{"HeLLo", "hello", "wo", -1, "Wo"},
+	{"hELLO", "hello", "worlds!", -1, "wORLDs!"},
+	{"iPhone", "iphone", "ipad", -1, "iPad"},
+
+	// Unicode
+	{"straße STRASSE", "straße", "weg", -1, "weg STRASSE"},
+	{"ΑΒΓ αβγ Αβγ", "αβγ", "δεζ", -1, "ΔΕΖ δεζ Δεζ"},
+	{"Kelvin Kelvin kelvin", "kelvin", "celsius", -1, "Celsius Celsius celsius"},
+	{"ſtop STOP", "stop", "go", -1, "go GO"},
+	{"ǅ", "ǆ", "xy", -1, "xy"},
+
+	// Invalid UTF-8 is preserved
+	{"Ab", "ab", "x\xffy", -1, "X\xffy"},
+	{"AB", "ab", "x\xffy", -1, "X\xffY"},
+}
+
+func ReplacePreserveCase(t *testing.T, fn ReplaceFunc) {
+	for _, tt := range replacePreserveCaseTests {
+		if s := fn(tt.in, tt.old, tt.new, tt.n); s != tt.out {
+			t.Errorf("ReplacePreserveCase(%q, %q, %q, %d) = %q; want: %q",
+				tt.in, tt.old, tt.new, tt.n, s, tt.out)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+type AppendSortKeyFunc func(dst []byte, s string) []byte
+
+func ByteAppendSortKeyFunc(fn func(dst, s []byte) []byte) AppendSortKeyFunc {
+	return func(dst []byte, s string) []byte {
+		return fn(dst, []byte(s))
+	}
+}
+
+var sortKeyTests = []string{
+	"",
+	"a",
+	"A",
+	"ab",
+	"aB",
+	"abc",
+	"b",
+	"Z",
+	"[",
+	"_",
+	"`",
+	"{",
+	"\x00",
+	"\x7f",
+	"k",
+	"K",
+	"\u212A",      // Kelvin K
+	"\u212Aelvin", // Kelvin K
+	"s",
+	"\u017F", // LATIN SMALL LETTER LONG S
+	"\u017Ftop",
+	"\u00DF", // LATIN SMALL LETTER SHARP S
+	"\u1E9E", // LATIN CAPITAL LETTER SHARP S
+	"ss",
+	"αβγ",
+	"ΑΒΓ",
+	"σας",
+	"ΣΑΣ",
+	"i",
+	"\u0130", // LATIN CAPITAL LETTER I WITH DOT ABOVE
+	"\u0131", // LATIN SMALL LETTER DOTLESS I
+	"\u023A", // Folds to a rune with a longer UTF-8 encoding
+	"\u2C65",
+	"\uFFFD",
+	"\xff",
+	"a\xffb",
+	"a\uFFFDb",
+	"\U0001E900", // ADLAM CAPITAL LETTER ALIF
+	"\U0001E922", // ADLAM SMALL LETTER ALIF
+	"\U0010FFFF",
+	strings.Repeat("aB", 64),
+	strings.Repeat("Ab", 64) + "\u212A",
+}
+
+func foldRunes(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteRune(tables.CaseFold(r))
+	}
+	return b.String()
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func checkSortKey(t testing.TB, fn AppendSortKeyFunc, compare func(s0, s1 string) int, s0, s1 string) {
+	t.Helper()
+	k0 := fn(nil, s0)
+	k1 := fn(nil, s1)
+	got := bytes.Compare(k0, k1)
+	want := sign(compare(s0, s1))
+	if got != want {
+		t.Errorf("bytes.Compare(AppendSortKey(%q), AppendSortKey(%q)) = %d; want: %d\n"+
+			"key0: %q\nkey1: %q", s0, s1, got, want, k0, k1)
+	}
+}
+
+func AppendSortKey(t *testing.T, fn AppendSortKeyFunc, compare func(s0, s1 string) int) {
+	for _, s := range sortKeyTests {
+		key := fn(nil, s)
+		if want := foldRunes(s); string(key) != want {
+			t.Errorf("AppendSortKey(nil, %q) = %q; want: %q", s, key, want)
+		}
+		if !utf8.Valid(key) {
+			t.Errorf("AppendSortKey(nil, %q) = %q; want valid UTF-8", s, key)
+		}
+		// Appending to dst must not modify its existing contents.
+		dst := make([]byte, 3, 8)
+		copy(dst, "xyz")
+		if key := fn(dst, s); string(key[:3]) != "xyz" || string(key[3:]) != foldRunes(s) {
+			t.Errorf("AppendSortKey(%q, %q) = %q; want: %q", "xyz", s, key, "xyz"+foldRunes(s))
+		}
+	}
+	for _, s0 := range sortKeyTests {
+		for _, s1 := range sortKeyTests {
+			checkSortKey(t, fn, compare, s0, s1)
+		}
+	}
+
+	// Every foldable rune must have the same key as the runes it folds to
+	// and must sort relative to its neighbors the same as Compare.
+	runes := FoldableRunes()
+	for i, r := range runes {
+		checkSortKey(t, fn, compare, string(r), foldRunes(string(r)))
+		if i > 0 {
+			checkSortKey(t, fn, compare, string(runes[i-1]), string(r))
+		}
+	}
+}
+
+func AppendSortKeyFuzz(t *testing.T, fn AppendSortKeyFunc, compare func(s0, s1 string) int) {
+	runRandomTest(t, func(t *fuzzTest) {
+		n := t.rr.Intn(30) + 2
+		r0 := appendRandRunes(t.haystack[:0], t.rr, n)
+		r1 := append(t.needle[:0], r0...)
+		switch t.rr.Intn(3) {
+		case 0:
+			r1 = changeRuneCase(t.rr, r1)
+		case 1:
+			r1 = replaceOneRune(t.rr, r1)
+		default:
+			r1 = r1[:t.rr.Intn(len(r1))]
+		}
+		checkSortKey(t, fn, compare, string(r0), string(r1))
+		checkSortKey(t, fn, compare, string(r1), string(r0))
+	})
+}
@@ -0,0 +1,174 @@
+package test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// SplitFunc is the signature of SplitN and SplitAfterN.
+type SplitFunc func(s, sep string, n int) []string
+
+func ByteSplitFunc(fn func(s, sep []byte, n int) [][]byte) SplitFunc {
+	return func(s, sep string, n int) []string {
+		a := fn([]byte(s), []byte(sep), n)
+		if a == nil {
+			return nil
+		}
+		out := make([]string, len(a))
+		for i, b := range a {
+			// Appending to a subslice must not overwrite the next one.
+			if i < len(a)-1 && cap(b) != len(b) {
+				panic("subslice capacity must equal its length")
+			}
+			out[i] = string(b)
+		}
+		return out
+	}
+}
+
+type splitTest struct {
+	s   string
+	sep string
+	n   int
+	a   []string
+}
+
+const (
+	abcd   = "abcd"
+	faces  = "☺☻☹"
+	commas = "1,2,3,4"
+)
+
+var splitTests = []splitTest{
+	// From the strings package
+	{"", "", -1, []string{}},
+	{abcd, "", 2, []string{"a", "bcd"}},
+	{abcd, "", 4, []string{"a", "b", "c", "d"}},
+	{abcd, "", -1, []string{"a", "b", "c", "d"}},
+	{faces, "", -1, []string{"☺", "☻", "☹"}},
+	{faces, "", 3, []string{"☺", "☻", "☹"}},
+	{faces, "", 17, []string{"☺", "☻", "☹"}},
+	{"☺�☹", "", -1, []string{"☺", "�", "☹"}},
+	{abcd, "a", 0, nil},
+	{abcd, "a", -1, []string{"", "bcd"}},
+	{abcd, "z", -1, []string{"abcd"}},
+	{commas, ",", -1, []string{"1", "2", "3", "4"}},
+	{dots, "...", -1, []string{"1", ".2", ".3", ".4"}},
+	{faces, "☹", -1, []string{"☺☻", ""}},
+	{faces, "~", -1, []string{faces}},
+	{"1 2 3 4", " ", 3, []string{"1", "2", "3 4"}},
+	{"1 2", " ", 3, []string{"1", "2"}},
+	{"", "T", -1, []string{""}},
+	{"\xff-\xff", "", -1, []string{"\xff", "-", "\xff"}},
+	{"\xff-\xff", "-", -1, []string{"\xff", "\xff"}},
+
+	// Case-insensitive
+	{"aXbxc", "x", -1, []string{"a", "b", "c"}},
+	{"aXbxc", "X", 2, []string{"a", "bxc"}},
+	{"ABCD", "bc", -1, []string{"A", "D"}},
+	{"1 AND 2 and 3", " and ", -1, []string{"1", "2", "3"}},
+	{"αΣβσγς", "σ", -1, []string{"α", "β", "γ", ""}},
+	{"İi", "i", -1, []string{"İ", ""}},
+	{"ıI", "i", -1, []string{"ı", ""}},
+
+	// The length of a match may differ from the length of sep.
+	{"aKb", "k", -1, []string{"a", "b"}},
+	{"aKbkc", "K", -1, []string{"a", "b", "c"}},
+	{"KK", "k", -1, []string{"", "", ""}},
+	{"KK", "k", 2, []string{"", "K"}},
+	{"1ſ2s3S4", "s", -1, []string{"1", "2", "3", "4"}},
+	{"1ſ2s3S4", "ſ", -1, []string{"1", "2", "3", "4"}},
+	{"aKſb", "ks", -1, []string{"a", "b"}},
+}
+
+var splitAfterTests = []splitTest{
+	// From the strings package
+	{abcd, "a", -1, []string{"a", "bcd"}},
+	{abcd, "z", -1, []string{"abcd"}},
+	{abcd, "", -1, []string{"a", "b", "c", "d"}},
+	{commas, ",", -1, []string{"1,", "2,", "3,", "4"}},
+	{dots, "...", -1, []string{"1...", ".2...", ".3...", ".4"}},
+	{faces, "☹", -1, []string{"☺☻☹", ""}},
+	{faces, "~", -1, []string{faces}},
+	{faces, "", -1, []string{"☺", "☻", "☹"}},
+	{"1 2 3 4", " ", 3, []string{"1 ", "2 ", "3 4"}},
+	{"1 2 3", " ", 3, []string{"1 ", "2 ", "3"}},
+	{"1 2", " ", 3, []string{"1 ", "2"}},
+	{"123", "", 2, []string{"1", "23"}},
+	{"123", "", 17, []string{"1", "2", "3"}},
+	{abcd, "a", 0, nil},
+
+	// Case-insensitive
+	{"aXbxc", "x", -1, []string{"aX", "bx", "c"}},
+	{"aXbxc", "X", 2, []string{"aX", "bxc"}},
+	{"1 AND 2 and 3", " and ", -1, []string{"1 AND ", "2 and ", "3"}},
+
+	// The matched separator is included as it appears in s.
+	{"aKb", "k", -1, []string{"aK", "b"}},
+	{"aKbkc", "K", -1, []string{"aK", "bk", "c"}},
+	{"KK", "k", -1, []string{"K", "K", ""}},
+	{"1ſ2s3S4", "s", -1, []string{"1ſ", "2s", "3S", "4"}},
+	{"aKſb", "ks", -1, []string{"aKſ", "b"}},
+}
+
+func testSplit(t *testing.T, name string, fn SplitFunc, tests []splitTest, sepSave bool) {
+	for _, tt := range tests {
+		a := fn(tt.s, tt.sep, tt.n)
+		if !reflect.DeepEqual(a, tt.a) {
+			t.Errorf("%s(%q, %q, %d) = %q; want: %q", name, tt.s, tt.sep, tt.n, a, tt.a)
+			continue
+		}
+		if tt.n < 0 {
+			// Joining the substrings must return the original string.
+			join := ""
+			if !sepSave {
+				join = tt.sep
+			}
+			if s := strings.Join(a, join); !strings.EqualFold(s, tt.s) {
+				t.Errorf("Join(%s(%q, %q, %d), %q) = %q", name, tt.s, tt.sep, tt.n, join, s)
+			}
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	// For lower case ASCII strings the result must match the strings package.
+	want := strings.SplitN
+	if sepSave {
+		want = strings.SplitAfterN
+	}
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rr.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		s := randStr(rr.Intn(16))
+		sep := randStr(rr.Intn(3))
+		n := rr.Intn(5) - 1
+		exp := want(s, sep, n)
+		if got := fn(s, sep, n); !reflect.DeepEqual(got, exp) {
+			t.Errorf("%s(%q, %q, %d) = %q; want: %q", name, s, sep, n, got, exp)
+		}
+		// Changing the case of s must not change where it is split.
+		upper := strings.ToUpper(s)
+		exp = want(upper, strings.ToUpper(sep), n)
+		if got := fn(upper, sep, n); !reflect.DeepEqual(got, exp) {
+			t.Errorf("%s(%q, %q, %d) = %q; want: %q", name, upper, sep, n, got, exp)
+		}
+	}
+}
+
+func Split(t *testing.T, fn SplitFunc) {
+	testSplit(t, "SplitN", fn, splitTests, false)
+}
+
+func SplitAfter(t *testing.T, fn SplitFunc) {
+	testSplit(t, "SplitAfterN", fn, splitAfterTests, true)
+}
@@ -114,6 +114,17 @@ var compareTests = []compareTest{
 	{"αabd", "αABC", 1},
 	{strings.Repeat("\u212a", 8), strings.Repeat("k", 8), 0},
 
+	// Long ASCII strings
+	{strings.Repeat("abc", 12), strings.Repeat("ABC", 12), 0},
+	{strings.Repeat("abc", 12), strings.Repeat("ABC", 12) + "a", -1},
+	{strings.Repeat("abc", 12) + "d", strings.Repeat("ABC", 12) + "E", -1},
+	{strings.Repeat("abc", 12) + "e", strings.Repeat("ABC", 12) + "D", 1},
+	{strings.Repeat("abc", 12) + "[", strings.Repeat("ABC", 12) + "{", -1},
+	{"@" + strings.Repeat("x", 31), "`" + strings.Repeat("X", 31), -1},
+	{strings.Repeat("abc", 12) + "αβδ", strings.Repeat("ABC", 12) + "ΑΒΔ", 0},
+	{strings.Repeat("abc", 12) + "\u212a" + strings.Repeat("x", 32), strings.Repeat("ABC", 12) + "k" + strings.Repeat("X", 32), 0},
+	{strings.Repeat("abc", 12) + "\u212a" + strings.Repeat("x", 32), strings.Repeat("ABC", 12) + "k" + strings.Repeat("X", 31) + "Y", -1},
+
 	// Invalid UTF-8 should be considered equal (mapped to RuneError)
 	{"a" + string(utf8.RuneError), "a" + string(unicode.MaxRune+1), 0},
 	{"a" + string(utf8.RuneError), "a\xFF", 0},
@@ -904,6 +915,23 @@ func IndexKelvin(t *testing.T, fn IndexFunc) {
 			test(t, r("k", i-1)+"a", r(K, i), -1)
 		}
 	})
+	// Match at the end of a long string, which is not searched by brute-force.
+	t.Run("MatchSuffix", func(t *testing.T) {
+		for i := 2; i < 16; i++ {
+			test(t, r("x", 32)+r("k", i), r(K, i), 32)
+			test(t, r("x", 32)+"e"+r("k", i), "e"+r(K, i), 32)
+		}
+	})
+	// ASCII needles where the Kelvin K in s shifts the offsets of the
+	// other bytes of the needle.
+	t.Run("MatchASCII", func(t *testing.T) {
+		for i := 0; i < 48; i++ {
+			test(t, r("x", i)+K+"/P"+r("x", 16), "k/p", i)
+			test(t, r("x", i)+"/"+K+"/P", "k/p", i+1)
+			test(t, r("/", i)+K+"/P", "k/p", i)
+			test(t, r("x", i)+"Z"+K, "zk", i)
+		}
+	})
 }
 
 var invalidSequenceTests = []string{
@@ -1360,6 +1388,21 @@ func LastIndexByte(t *testing.T, fn IndexByteFunc) {
 		{"x", 'S', -1},
 		{"akK", 'k', len("ak")},
 		{"aſSx", 's', len("aſ")},
+		{"kxK", 'K', len("kx")},
+		{"sxſ", 's', len("sx")},
+		{"sxſα", 'S', len("sx")},
+		{"Kxαβ", 'k', 0},
+		{"αβK", 'k', len("αβ")},
+
+		// Long strings
+		{strings.Repeat("a", 64) + "b" + strings.Repeat("a", 64), 'B', 64},
+		{"B" + strings.Repeat("a", 64), 'b', 0},
+		{strings.Repeat("a", 64) + "b", 'B', 64},
+		{strings.Repeat("a", 64), 'B', -1},
+		{strings.Repeat("k", 64) + "K" + strings.Repeat("α", 32), 'K', 64},
+		{strings.Repeat("k", 64) + "K" + strings.Repeat("α", 32), 'k', 64},
+		{"K" + strings.Repeat("α", 32) + "K" + strings.Repeat("α", 32), 'k', 1 + len("α")*32},
+		{strings.Repeat("α", 32) + "s" + strings.Repeat("α", 32), 'S', len("α") * 32},
 	}
 	for _, tt := range tests {
 		if got := fn(tt.in, tt.char); got != tt.want {
@@ -1444,6 +1487,8 @@ var prefixTests = []prefixTest{
 	{"abc", "XYZ", false, false},
 	{"abc", "abc", true, true},
 	{"abc", "abd", false, true},
+	{"abc", "ABCD", false, true},
+	{"ab", "ab\u212A", false, true},
 	{"abcdefghijk", "abcdefghijX", false, true},
 	{"abcdefghijk", "abcdefghij\u212A", true, true},
 	{"abcdefghijk", "abcdefghij\u212Axyz", false, true},
@@ -1462,6 +1507,13 @@ var prefixTests = []prefixTest{
 	{strings.Repeat("k", 8), strings.Repeat("\u212a", 8), true, true},
 	{"k-k", "\u212a-\u212a", true, true},
 
+	// Long ASCII strings
+	{strings.Repeat("abc", 12) + "xyz", strings.Repeat("ABC", 12), true, false},
+	{strings.Repeat("abc", 12), strings.Repeat("ABC", 12) + "\u212a", false, true},
+	{strings.Repeat("abc", 12) + "k", strings.Repeat("ABC", 12) + "\u212a", true, true},
+	{strings.Repeat("abc", 12) + "\u212ax", strings.Repeat("ABC", 12) + "kX", true, true},
+	{strings.Repeat("abc", 12) + "d" + strings.Repeat("x", 32), strings.Repeat("ABC", 12) + "E", false, false},
+
 	{"a", "bbb", false, true},
 	{"\u212a", strings.Repeat("a", len("\u212a")*2), false, true},
 	{"\u212a", strings.Repeat("a", len("\u212a")*3), false, true},
@@ -1555,6 +1607,15 @@ var suffixTests = []suffixTest{
 	{strings.Repeat("k", 8), strings.Repeat("\u212a", 8), true},
 	{"k-k", "\u212a-\u212a", true},
 
+	// Long ASCII strings
+	{strings.Repeat("x", 32) + strings.Repeat("abc", 12), strings.Repeat("ABC", 12), true},
+	{strings.Repeat("abc", 12), "z" + strings.Repeat("ABC", 12), false},
+	{strings.Repeat("abc", 12) + "d", strings.Repeat("ABC", 12) + "E", false},
+	{"d" + strings.Repeat("abc", 12), "E" + strings.Repeat("ABC", 12), false},
+	{"\u212a" + strings.Repeat("abc", 12), "k" + strings.Repeat("ABC", 12), true},
+	{"k" + strings.Repeat("abc", 12), "\u212a" + strings.Repeat("ABC", 12), true},
+	{"αβδ" + strings.Repeat("abc", 12), "ΑΒΔ" + strings.Repeat("ABC", 12), true},
+
 	{"g^Y3i", "I", true},
 	{"G|S&>;C", "&>;C", true},
 }
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+type trimTest struct {
+	f            string
+	in, arg, out string
+}
+
+var trimTests = []trimTest{
+	// From the strings package
+	{"Trim", "abba", "a", "bb"},
+	{"Trim", "abba", "ab", ""},
+	{"TrimLeft", "abba", "ab", ""},
+	{"TrimRight", "abba", "ab", ""},
+	{"TrimLeft", "abba", "a", "bba"},
+	{"TrimLeft", "abba", "b", "abba"},
+	{"TrimRight", "abba", "a", "abb"},
+	{"TrimRight", "abba", "b", "abba"},
+	{"Trim", "<tag>", "<>", "tag"},
+	{"Trim", "* listitem", " *", "listitem"},
+	{"Trim", `"quote"`, `"`, "quote"},
+	{"Trim", "ⱯⱯɐɐⱯⱯ", "Ɐ", ""},
+	{"Trim", "\x80test\xff", "\xff", "test"},
+	{"Trim", " Ġ ", " ", "Ġ"},
+	{"Trim", " Ġİ0", "0 ", "Ġİ"},
+	{"Trim", "", "123", ""},
+	{"Trim", "abba", "", "abba"},
+	{"Trim", "☺\xc0", "☺", "\xc0"},
+	{"TrimLeft", "", "123", ""},
+	{"TrimLeft", "abba", "", "abba"},
+	{"TrimRight", "", "123", ""},
+	{"TrimRight", "abba", "", "abba"},
+	{"TrimRight", "☺\xc0", "☺", "☺\xc0"},
+
+	// Case-insensitive
+	{"Trim", "ABBA", "a", "BB"},
+	{"Trim", "aBbA", "AB", ""},
+	{"TrimLeft", "AbBa", "a", "bBa"},
+	{"TrimRight", "AbBa", "A", "AbB"},
+	{"Trim", "xXhelloXx", "x", "hello"},
+	{"Trim", "ΑαβΑ", "α", "β"},
+	{"Trim", "σΣςxς", "Σ", "x"},
+	{"Trim", "İiıIx", "i", "İiıIx"},
+	{"Trim", "iIİı", "i", "İı"},
+
+	// Characters that fold to ASCII
+	{"Trim", "\u212AkxK\u212A", "k", "x"},
+	{"Trim", "kKx\u212Ak", "K", "x"},
+	{"TrimLeft", "\u212Ax", "K", "x"},
+	{"TrimRight", "x\u212A", "\u212A", "x"},
+	{"Trim", "ſsSxſ", "s", "x"},
+	{"Trim", "sSxſ", "ſ", "x"},
+	{"TrimLeft", "sſSx", "S", "x"},
+	{"TrimRight", "xsſS", "S", "x"},
+	{"Trim", "xyz\u212Aſabc", "ks", "xyz\u212Aſabc"},
+	{"Trim", "\u212AſabcKſ", "KS", "abc"},
+	{"Trim", "\u212Aſ☺abc☺kſ", "KS☺", "abc"},
+}
+
+func trimReference(fn, s, cutset string) string {
+	inCutset := func(r rune) bool {
+		for _, c := range cutset {
+			if r == c || strings.EqualFold(string(r), string(c)) {
+				return true
+			}
+		}
+		return false
+	}
+	switch fn {
+	case "Trim":
+		return strings.TrimFunc(s, inCutset)
+	case "TrimLeft":
+		return strings.TrimLeftFunc(s, inCutset)
+	case "TrimRight":
+		return strings.TrimRightFunc(s, inCutset)
+	}
+	panic("invalid trim function: " + fn)
+}
+
+func testTrim(t *testing.T, name string, fn TrimFunc) {
+	for _, tt := range trimTests {
+		if tt.f != name {
+			continue
+		}
+		if got := fn(tt.in, tt.arg); got != tt.out {
+			t.Errorf("%s(%q, %q) = %q; want: %q", name, tt.in, tt.arg, got, tt.out)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+	// Compare against a reference implementation using random strings that
+	// contain characters with multiple case-folds.
+	const chars = "aAbBkKsS\u212Aſ \u212BåÅσςΣ☺\uFFFD"
+	runes := []rune(chars)
+	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randStr := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			if rr.Intn(16) == 0 {
+				b.WriteByte(0xff) // invalid UTF-8
+			} else {
+				b.WriteRune(runes[rr.Intn(len(runes))])
+			}
+		}
+		return b.String()
+	}
+	for i := 0; i < 5000; i++ {
+		s := randStr(rr.Intn(12))
+		cutset := randStr(rr.Intn(4))
+		want := trimReference(name, s, cutset)
+		if got := fn(s, cutset); got != want {
+			t.Errorf("%s(%q, %q) = %q; want: %q", name, s, cutset, got, want)
+		}
+	}
+}
+
+func Trim(t *testing.T, fn TrimFunc) {
+	testTrim(t, "Trim", fn)
+}
+
+func TrimLeft(t *testing.T, fn TrimFunc) {
+	testTrim(t, "TrimLeft", fn)
+}
+
+func TrimRight(t *testing.T, fn TrimFunc) {
+	testTrim(t, "TrimRight", fn)
+}
@@ -0,0 +1,357 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// A turkicMapper maps strings between the default and Turkic case-folding
+// rules, which allows the tests for the default rules to be reused for the
+// Turkic functions.
+//
+// With the default rules 'I', 'i', 'İ', and 'ı' form the equivalence classes
+// {I, i}, {İ}, and {ı} while with the Turkic rules they form {I, ı} and
+// {İ, i}. Swapping 'I' and 'İ' and replacing 'ı' with a rune that does not
+// fold maps each of the default classes onto a Turkic class so that:
+//
+//	Default(s, t) == Turkic(m.encode(s), m.encode(t))
+//
+// Once any indexes or strings returned by the Turkic function have been
+// mapped back with m.index or m.decode.
+type turkicMapper struct {
+	dotless rune // replacement for 'ı'
+	ordered bool // the mapping preserves the ordering of Compare
+}
+
+// newTurkicMapper returns a turkicMapper for the strings strs. The
+// replacement for 'ı' is 'ĸ' (U+0138) if no string contains a rune that
+// folds to 'ĸ' or any of the runes between it and 'ı', since this also
+// preserves the ordering of Compare, otherwise the first noncharacter
+// (U+FDD0..U+FDEF) not found in strs is used.
+func newTurkicMapper(strs ...string) turkicMapper {
+	const kra = 'ĸ'
+	ordered := true
+	used := make(map[rune]bool)
+	for _, s := range strs {
+		for _, r := range s {
+			if f := tables.CaseFold(r); 'ı' < f && f <= kra {
+				ordered = false
+			}
+			if 0xFDD0 <= r && r <= 0xFDEF {
+				used[r] = true
+			}
+		}
+	}
+	if ordered {
+		return turkicMapper{dotless: kra, ordered: true}
+	}
+	for r := rune(0xFDD0); r <= 0xFDEF; r++ {
+		if !used[r] {
+			return turkicMapper{dotless: r}
+		}
+	}
+	panic("newTurkicMapper: no replacement for 'ı' available")
+}
+
+func (m turkicMapper) encodeRune(r rune) rune {
+	switch r {
+	case 'I':
+		return 'İ'
+	case 'İ':
+		return 'I'
+	case 'ı':
+		return m.dotless
+	}
+	return r
+}
+
+func (m turkicMapper) decodeRune(r rune) rune {
+	switch r {
+	case 'I':
+		return 'İ'
+	case 'İ':
+		return 'I'
+	case m.dotless:
+		return 'ı'
+	}
+	return r
+}
+
+func (m turkicMapper) mapString(s string, fn func(rune) rune) string {
+	var w strings.Builder
+	w.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			w.WriteByte(s[i]) // preserve invalid UTF-8
+		} else {
+			w.WriteRune(fn(r))
+		}
+		i += size
+	}
+	return w.String()
+}
+
+// encode maps s from the default to the Turkic rules.
+func (m turkicMapper) encode(s string) string { return m.mapString(s, m.encodeRune) }
+
+// decode maps s from the Turkic to the default rules.
+func (m turkicMapper) decode(s string) string { return m.mapString(s, m.decodeRune) }
+
+// index maps index i of m.encode(s) to the corresponding index of s.
+// Negative indexes are returned unchanged and -2 is returned if i does
+// not map to an index of s.
+func (m turkicMapper) index(s string, i int) int {
+	if i < 0 {
+		return i
+	}
+	j := 0 // index in encoded string
+	for k := 0; k < len(s); {
+		if i == j {
+			return k
+		}
+		r, size := utf8.DecodeRuneInString(s[k:])
+		n := size
+		if r != utf8.RuneError || size != 1 {
+			n = utf8.RuneLen(m.encodeRune(r))
+		}
+		if i < j+n {
+			if m.encodeRune(r) == r {
+				return k + i - j // index in the middle of an unchanged rune
+			}
+			return -2
+		}
+		j += n
+		k += size
+	}
+	if i == j {
+		return len(s)
+	}
+	return -2
+}
+
+// TurkicIndexFunc wraps Turkic index function fn so that it can be tested
+// using the tests for the default index function.
+func TurkicIndexFunc(fn IndexFunc) IndexFunc {
+	return func(s, sep string) int {
+		m := newTurkicMapper(s, sep)
+		return m.index(s, fn(m.encode(s), m.encode(sep)))
+	}
+}
+
+// TurkicCountFunc is like TurkicIndexFunc but the result of fn is not an
+// index so it is returned unchanged.
+func TurkicCountFunc(fn IndexFunc) IndexFunc {
+	return func(s, sep string) int {
+		m := newTurkicMapper(s, sep)
+		return fn(m.encode(s), m.encode(sep))
+	}
+}
+
+// compareFold is a simple reference implementation of Compare.
+func compareFold(s, t string) int {
+	for len(s) > 0 && len(t) > 0 {
+		sr, n0 := utf8.DecodeRuneInString(s)
+		tr, n1 := utf8.DecodeRuneInString(t)
+		sr = tables.CaseFold(sr)
+		tr = tables.CaseFold(tr)
+		if sr < tr {
+			return -1
+		}
+		if sr > tr {
+			return 1
+		}
+		s = s[n0:]
+		t = t[n1:]
+	}
+	switch {
+	case len(s) < len(t):
+		return -1
+	case len(s) > len(t):
+		return 1
+	}
+	return 0
+}
+
+// TurkicCompareFunc wraps Turkic compare function fn so that it can be
+// tested using the tests for the default compare function. If the strings
+// being compared prevent the mapping from preserving the ordering of
+// compare, only equality is checked.
+func TurkicCompareFunc(fn IndexFunc) IndexFunc {
+	return func(s, t string) int {
+		m := newTurkicMapper(s, t)
+		n := fn(m.encode(s), m.encode(t))
+		if n != 0 && !m.ordered {
+			if want := compareFold(s, t); want != 0 {
+				return want
+			}
+		}
+		return n
+	}
+}
+
+// TurkicContainsFunc wraps Turkic function fn so that it can be tested
+// using the tests for the default function.
+func TurkicContainsFunc(fn ContainsFunc) ContainsFunc {
+	return func(s, substr string) bool {
+		m := newTurkicMapper(s, substr)
+		return fn(m.encode(s), m.encode(substr))
+	}
+}
+
+// TurkicIndexRuneFunc wraps Turkic function fn so that it can be tested
+// using the tests for the default function.
+func TurkicIndexRuneFunc(fn IndexRuneFunc) IndexRuneFunc {
+	return func(s string, r rune) int {
+		m := newTurkicMapper(s, string(r))
+		return m.index(s, fn(m.encode(s), m.encodeRune(r)))
+	}
+}
+
+// TurkicContainsRuneFunc wraps Turkic function fn so that it can be tested
+// using the tests for the default function.
+func TurkicContainsRuneFunc(fn func(s string, r rune) bool) func(s string, r rune) bool {
+	return func(s string, r rune) bool {
+		m := newTurkicMapper(s, string(r))
+		return fn(m.encode(s), m.encodeRune(r))
+	}
+}
+
+// TurkicIndexByteFunc wraps Turkic function fn so that it can be tested
+// using the tests for the default function.
+func TurkicIndexByteFunc(fn IndexByteFunc) IndexByteFunc {
+	return func(s string, c byte) int {
+		// Non-ASCII bytes are matched exactly.
+		if c >= utf8.RuneSelf {
+			return fn(s, c)
+		}
+		if c == 'I' {
+			c = 'i'
+		}
+		m := newTurkicMapper(s)
+		return m.index(s, fn(m.encode(s), c))
+	}
+}
+
+// TurkicTrimFunc wraps Turkic function fn so that it can be tested using
+// the tests for the default function.
+func TurkicTrimFunc(fn TrimFunc) TrimFunc {
+	return func(s1, s2 string) string {
+		m := newTurkicMapper(s1, s2)
+		return m.decode(fn(m.encode(s1), m.encode(s2)))
+	}
+}
+
+// TurkicCutFunc wraps Turkic function fn so that it can be tested using
+// the tests for the default function.
+func TurkicCutFunc(fn func(s, sep string) (before, after string, found bool)) func(s, sep string) (before, after string, found bool) {
+	return func(s, sep string) (before, after string, found bool) {
+		m := newTurkicMapper(s, sep)
+		before, after, found = fn(m.encode(s), m.encode(sep))
+		return m.decode(before), m.decode(after), found
+	}
+}
+
+// TurkicCutAffixFunc wraps Turkic CutPrefix or CutSuffix function fn so
+// that it can be tested using the tests for the default function.
+func TurkicCutAffixFunc(fn func(s, affix string) (string, bool)) func(s, affix string) (string, bool) {
+	return func(s, affix string) (string, bool) {
+		m := newTurkicMapper(s, affix)
+		out, found := fn(m.encode(s), m.encode(affix))
+		return m.decode(out), found
+	}
+}
+
+var turkicCompareTests = []compareTest{
+	{"", "", 0},
+	{"I", "ı", 0},
+	{"ı", "I", 0},
+	{"i", "İ", 0},
+	{"İ", "i", 0},
+	{"I", "i", 1},
+	{"i", "I", -1},
+	{"İ", "I", -1},
+	{"ı", "i", 1},
+	{"İSTANBUL", "istanbul", 0},
+	{"istanbul", "İSTANBUL", 0},
+	{"ISPARTA", "ısparta", 0},
+	{"ısparta", "ISPARTA", 0},
+	{"ISPARTA", "isparta", 1},
+	{"DİYARBAKIR", "diyarbakır", 0},
+	{"DIYARBAKIR", "diyarbakır", 1},
+	{"ıi", "IİI", -1},
+	{"K", "k", 0},
+	{"ΑΒΓ", "αβγ", 0},
+}
+
+func TurkicCompare(t *testing.T, fn IndexFunc) {
+	for _, test := range turkicCompareTests {
+		if got := fn(test.s, test.t); got != test.out {
+			t.Errorf("Compare(%q, %q) = %d; want: %d", test.s, test.t, got, test.out)
+		}
+	}
+}
+
+func TurkicEqualFold(t *testing.T, fn func(s1, s2 string) bool) {
+	for _, test := range turkicCompareTests {
+		want := test.out == 0
+		if got := fn(test.s, test.t); got != want {
+			t.Errorf("EqualFold(%q, %q) = %t; want: %t", test.s, test.t, got, want)
+		}
+	}
+}
+
+var turkicIndexTests = []indexTest{
+	{"", "i", -1},
+	{"I", "i", -1},
+	{"ı", "I", 0},
+	{"İ", "i", 0},
+	{"xİ", "i", 1},
+	{"Iİıi", "i", 1},
+	{"Iİıi", "ı", 0},
+	{"İSTANBUL", "stanbul", 2},
+	{"İSTANBUL", "istanbul", 0},
+	{"İSTANBUL", "İstanbul", 0},
+	{"xISTANBUL", "istanbul", -1},
+	{"Isparta ISPARTA", "ısparta", 0},
+	{"isparta ISPARTA", "ısparta", 8},
+	{"diyarbakir DİYARBAKIR", "diyarbakır", 11},
+	{"aIb aıb", "AIB", 0},
+	{"aib aıb", "AIB", 4},
+	{"aib aİb", "AIB", -1},
+	{"aib aİb", "Aib", 0},
+}
+
+func TurkicIndex(t *testing.T, fn IndexFunc) {
+	for _, test := range turkicIndexTests {
+		if got := fn(test.s, test.sep); got != test.out {
+			t.Errorf("Index(%q, %q) = %d; want: %d", test.s, test.sep, got, test.out)
+		}
+	}
+}
+
+var turkicLastIndexTests = []indexTest{
+	{"", "i", -1},
+	{"I", "i", -1},
+	{"ı", "I", 0},
+	{"İ", "i", 0},
+	{"iİ", "i", 1},
+	{"İi", "İ", 2},
+	{"ıIİi", "ı", 2},
+	{"İSTANBUL istanbul", "İstanbul", 10},
+	{"istanbul İSTANBUL", "İstanbul", 9},
+	{"ısparta ISPARTA isparta", "ısparta", 9},
+	{"aIb aıb", "AIB", 4},
+	{"aIb aib", "AIB", 0},
+}
+
+func TurkicLastIndex(t *testing.T, fn IndexFunc) {
+	for _, test := range turkicLastIndexTests {
+		if got := fn(test.s, test.sep); got != test.out {
+			t.Errorf("LastIndex(%q, %q) = %d; want: %d", test.s, test.sep, got, test.out)
+		}
+	}
+}
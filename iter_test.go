@@ -0,0 +1,59 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+//go:build go1.23
+// +build go1.23
+
+package strcase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestAllIndexes(t *testing.T) {
+	test.AllIndexes(t, AllIndexes)
+}
+
+func TestSplitSeq(t *testing.T) {
+	test.Split(t, test.SeqSplitFunc(SplitSeq, SplitN))
+}
+
+func TestSplitAfterSeq(t *testing.T) {
+	test.SplitAfter(t, test.SeqSplitFunc(SplitAfterSeq, SplitAfterN))
+}
+
+func BenchmarkSplitSeq(b *testing.B) {
+	s := "a,B,c,D,e,F,g,H,i,J,k,L,m,N,o,P,q,R,s,T,u,V,w,X,y,Z"
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for range SplitSeq(s, ",") {
+		}
+	}
+}
+
+func TestMapAll(t *testing.T) {
+	var m Map[int]
+	for i, key := range []string{"a", "B", "c", "D"} {
+		m.Set(key, i)
+	}
+	m.Set("b", 4)
+	var keys []string
+	var values []int
+	for key, value := range m.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+		if key == "c" {
+			break
+		}
+	}
+	if want := []string{"a", "B", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("All() keys = %q; want: %q", keys, want)
+	}
+	if want := []int{0, 4, 2}; !reflect.DeepEqual(values, want) {
+		t.Errorf("All() values = %d; want: %d", values, want)
+	}
+}
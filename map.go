@@ -0,0 +1,213 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import "hash/maphash"
+
+// A Map is a map of string keys to values of type V where keys are compared
+// with EqualFold. Keys that are equal under simple Unicode case-folding,
+// such as "k", "K" and Kelvin K (U+212A), refer to the same entry.
+//
+// The key stored for an entry is the spelling of the key that was first
+// used to set it. Iterating over a Map visits its entries in the order they
+// were first set.
+//
+// The zero value is an empty map ready to use. A Map must not be copied
+// after first use and is not safe for concurrent use by multiple
+// goroutines without additional locking or coordination.
+type Map[V any] struct {
+	seed    maphash.Seed
+	index   map[uint64]int // hash of key => index of first entry with that hash
+	entries []mapEntry[V]  // entries in insertion order
+	deleted int            // number of deleted entries
+}
+
+type mapEntry[V any] struct {
+	key     string
+	value   V
+	hash    uint64
+	next    int // index of next entry with the same hash or -1
+	deleted bool
+}
+
+// find returns the index of the entry for key and the index of the entry
+// before it with the same hash, or -1 if there is no such entry.
+func (m *Map[V]) find(key string, h uint64) (i, prev int) {
+	prev = -1
+	i, ok := m.index[h]
+	if !ok {
+		return -1, -1
+	}
+	for ; i != -1; prev, i = i, m.entries[i].next {
+		if EqualFold(m.entries[i].key, key) {
+			return i, prev
+		}
+	}
+	return -1, -1
+}
+
+func (m *Map[V]) lookup(key string) int {
+	if m.Len() == 0 {
+		return -1
+	}
+	i, _ := m.find(key, Hash(m.seed, key))
+	return i
+}
+
+// Len returns the number of entries in m.
+func (m *Map[V]) Len() int {
+	return len(m.entries) - m.deleted
+}
+
+// Get returns the value stored for key, ignoring case, and reports whether
+// key is present in m.
+func (m *Map[V]) Get(key string) (V, bool) {
+	if i := m.lookup(key); i != -1 {
+		return m.entries[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Lookup returns the key, as it is spelled in m, and the value stored for
+// key, ignoring case, and reports whether key is present in m.
+func (m *Map[V]) Lookup(key string) (string, V, bool) {
+	if i := m.lookup(key); i != -1 {
+		e := &m.entries[i]
+		return e.key, e.value, true
+	}
+	var zero V
+	return "", zero, false
+}
+
+// Contains reports whether key is present in m, ignoring case.
+func (m *Map[V]) Contains(key string) bool {
+	return m.lookup(key) != -1
+}
+
+// Set sets the value for key, ignoring case. If m already contains a key
+// that is equal to key under simple Unicode case-folding the value is
+// replaced but the original spelling of the key and its position in the
+// iteration order are retained.
+func (m *Map[V]) Set(key string, value V) {
+	if m.index == nil {
+		m.seed = maphash.MakeSeed()
+		m.index = make(map[uint64]int)
+	}
+	m.set(key, Hash(m.seed, key), value)
+}
+
+// set sets the value for key, which must have hash h.
+func (m *Map[V]) set(key string, h uint64, value V) {
+	if i, _ := m.find(key, h); i != -1 {
+		m.entries[i].value = value
+		return
+	}
+	if m.deleted > 16 && m.deleted > len(m.entries)/2 {
+		m.compact()
+	}
+	next, ok := m.index[h]
+	if !ok {
+		next = -1
+	}
+	m.index[h] = len(m.entries)
+	m.entries = append(m.entries, mapEntry[V]{
+		key:   key,
+		value: value,
+		hash:  h,
+		next:  next,
+	})
+}
+
+// Delete removes the entry for key, ignoring case, from m and reports
+// whether it was present.
+func (m *Map[V]) Delete(key string) bool {
+	if m.Len() == 0 {
+		return false
+	}
+	return m.delete(key, Hash(m.seed, key))
+}
+
+// delete removes the entry for key, which must have hash h.
+func (m *Map[V]) delete(key string, h uint64) bool {
+	i, prev := m.find(key, h)
+	if i == -1 {
+		return false
+	}
+	e := &m.entries[i]
+	switch {
+	case prev != -1:
+		m.entries[prev].next = e.next
+	case e.next != -1:
+		m.index[h] = e.next
+	default:
+		delete(m.index, h)
+	}
+	*e = mapEntry[V]{next: -1, deleted: true} // release key and value
+	m.deleted++
+	return true
+}
+
+// Clear removes all entries from m.
+func (m *Map[V]) Clear() {
+	for h := range m.index {
+		delete(m.index, h)
+	}
+	for i := range m.entries {
+		m.entries[i] = mapEntry[V]{}
+	}
+	m.entries = m.entries[:0]
+	m.deleted = 0
+}
+
+// compact removes deleted entries from m.entries and rebuilds the index.
+func (m *Map[V]) compact() {
+	n := 0
+	for _, e := range m.entries {
+		if !e.deleted {
+			m.entries[n] = e
+			n++
+		}
+	}
+	for i := n; i < len(m.entries); i++ {
+		m.entries[i] = mapEntry[V]{}
+	}
+	m.entries = m.entries[:n]
+	m.deleted = 0
+	for h := range m.index {
+		delete(m.index, h)
+	}
+	for i := range m.entries {
+		e := &m.entries[i]
+		if next, ok := m.index[e.hash]; ok {
+			e.next = next
+		} else {
+			e.next = -1
+		}
+		m.index[e.hash] = i
+	}
+}
+
+// Range calls f for each key and value in m in insertion order. If f
+// returns false, Range stops the iteration. Entries may be deleted, or have
+// their value replaced, during iteration but adding new keys during
+// iteration may cause entries to be skipped or visited more than once.
+func (m *Map[V]) Range(f func(key string, value V) bool) {
+	for i := 0; i < len(m.entries); i++ {
+		e := &m.entries[i]
+		if !e.deleted && !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Keys returns the keys of m, as they are spelled in m, in insertion order.
+func (m *Map[V]) Keys() []string {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
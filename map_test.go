@@ -0,0 +1,302 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+func TestMap(t *testing.T) {
+	var m Map[int]
+	if _, ok := m.Get("a"); ok {
+		t.Error("Get on an empty Map returned true")
+	}
+	if m.Delete("a") {
+		t.Error("Delete on an empty Map returned true")
+	}
+	if n := m.Len(); n != 0 {
+		t.Errorf("Len() = %d; want: %d", n, 0)
+	}
+
+	m.Set("Content-Type", 1)
+	m.Set("kelvin", 2)
+	m.Set("STOP", 3)
+	m.Set("CONTENT-TYPE", 4) // Replace value and retain spelling
+
+	tests := []struct {
+		key, stored string
+		value       int
+	}{
+		{"content-type", "Content-Type", 4},
+		{"Content-Type", "Content-Type", 4},
+		{"KELVIN", "kelvin", 2},
+		{"\u212Aelvin", "kelvin", 2}, // Kelvin K
+		{"\u017Ftop", "STOP", 3},     // LATIN SMALL LETTER LONG S
+	}
+	for _, test := range tests {
+		if v, ok := m.Get(test.key); !ok || v != test.value {
+			t.Errorf("Get(%q) = %d, %t; want: %d, %t", test.key, v, ok, test.value, true)
+		}
+		key, v, ok := m.Lookup(test.key)
+		if !ok || key != test.stored || v != test.value {
+			t.Errorf("Lookup(%q) = %q, %d, %t; want: %q, %d, %t",
+				test.key, key, v, ok, test.stored, test.value, true)
+		}
+		if !m.Contains(test.key) {
+			t.Errorf("Contains(%q) = false; want: true", test.key)
+		}
+	}
+	for _, key := range []string{"", "content", "kelvins", "stop\x00"} {
+		if v, ok := m.Get(key); ok {
+			t.Errorf("Get(%q) = %d, %t; want: %d, %t", key, v, ok, 0, false)
+		}
+		if key, v, ok := m.Lookup(key); ok {
+			t.Errorf("Lookup(%q) = %q, %d, %t; want: %q, %d, %t", key, key, v, ok, "", 0, false)
+		}
+	}
+
+	want := []string{"Content-Type", "kelvin", "STOP"}
+	if keys := m.Keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys() = %q; want: %q", keys, want)
+	}
+	if n := m.Len(); n != 3 {
+		t.Errorf("Len() = %d; want: %d", n, 3)
+	}
+
+	if !m.Delete("\u212AELVIN") {
+		t.Errorf("Delete(%q) = false; want: true", "\u212AELVIN")
+	}
+	if m.Delete("kelvin") {
+		t.Errorf("Delete(%q) = true; want: false", "kelvin")
+	}
+	m.Set("Kelvin", 5) // Re-added keys are moved to the end
+	want = []string{"Content-Type", "STOP", "Kelvin"}
+	if keys := m.Keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys() = %q; want: %q", keys, want)
+	}
+
+	m.Clear()
+	if n := m.Len(); n != 0 {
+		t.Errorf("Len() = %d; want: %d", n, 0)
+	}
+	if _, ok := m.Get("stop"); ok {
+		t.Error("Get after Clear returned true")
+	}
+	m.Set("a", 1)
+	if v, ok := m.Get("A"); !ok || v != 1 {
+		t.Errorf("Get(%q) = %d, %t; want: %d, %t", "A", v, ok, 1, true)
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	var m Map[int]
+	for i := 0; i < 8; i++ {
+		m.Set(fmt.Sprintf("K%d", i), i)
+	}
+	var keys []string
+	m.Range(func(key string, value int) bool {
+		keys = append(keys, key)
+		if value%2 == 0 {
+			m.Delete(fmt.Sprintf("k%d", value+1)) // Delete the next entry
+		}
+		return value < 6
+	})
+	want := []string{"K0", "K2", "K4", "K6"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Range: got keys %q; want: %q", keys, want)
+	}
+}
+
+// Test entries with the same hash.
+func TestMapCollisions(t *testing.T) {
+	const h = 1
+	m := Map[int]{index: make(map[uint64]int)}
+	var want []string
+	for i := 0; i < 64; i++ {
+		key := fmt.Sprintf("key%d", i)
+		m.set(key, h, i)
+		want = append(want, key)
+		if i == 0 {
+			m.set("other", 2, -1)
+			want = append(want, "other")
+		}
+	}
+	check := func() {
+		t.Helper()
+		for _, key := range want {
+			hash := uint64(h)
+			if key == "other" {
+				hash = 2
+			}
+			if i, _ := m.find(strings.ToUpper(key), hash); i == -1 || m.entries[i].key != key {
+				t.Fatalf("failed to find key %q", key)
+			}
+		}
+		if keys := m.Keys(); !reflect.DeepEqual(keys, want) {
+			t.Fatalf("Keys() = %q; want: %q", keys, want)
+		}
+	}
+	check()
+
+	// Delete entries from the head, middle and tail of the chain
+	deleted := func(i int) bool { return i%3 != 1 }
+	for i := 63; i >= 0; i-- {
+		if key := fmt.Sprintf("KEY%d", i); deleted(i) && !m.delete(key, h) {
+			t.Fatalf("delete(%q) = false; want: true", key)
+		}
+	}
+	want = want[:0]
+	for i := 0; i < 64; i++ {
+		if !deleted(i) {
+			want = append(want, fmt.Sprintf("key%d", i))
+		}
+		if i == 0 {
+			want = append(want, "other")
+		}
+	}
+	check()
+
+	// Adding a new entry compacts the Map
+	m.set("new", h, 64)
+	if m.deleted != 0 {
+		t.Errorf("expected Map to be compacted: %d deleted entries", m.deleted)
+	}
+	want = append(want, "new")
+	check()
+}
+
+// randCase randomly changes the case of the runes in s.
+func randCase(rr *rand.Rand, s string) string {
+	return strings.Map(func(r rune) rune {
+		for n := rr.Intn(4); n > 0; n-- {
+			r = unicode.SimpleFold(r)
+		}
+		return r
+	}, s)
+}
+
+// Test Map against a slice of entries.
+func TestMapRandom(t *testing.T) {
+	type entry struct {
+		key   string
+		value int
+	}
+	var model []entry
+	find := func(key string) int {
+		for i, e := range model {
+			if EqualFold(e.key, key) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	words := []string{
+		"", "a", "b", "k", "s", "ss", "abc", "kelvin", "stop", "straße",
+		"σας", "αβγ", "i", "ı", "i̇", "\xff", "\u212A\u017F",
+	}
+	seed := time.Now().UnixNano()
+	rr := rand.New(rand.NewSource(seed))
+	var m Map[int]
+	for i := 0; i < 50_000; i++ {
+		key := randCase(rr, words[rr.Intn(len(words))])
+		switch n := rr.Intn(8); {
+		case n < 4:
+			m.Set(key, i)
+			if j := find(key); j != -1 {
+				model[j].value = i
+			} else {
+				model = append(model, entry{key, i})
+			}
+		case n < 7:
+			want := find(key) != -1
+			if got := m.Delete(key); got != want {
+				t.Fatalf("%d: Delete(%q) = %t; want: %t (seed: %d)", i, key, got, want, seed)
+			}
+			if j := find(key); j != -1 {
+				model = append(model[:j], model[j+1:]...)
+			}
+		default:
+			stored, v, ok := m.Lookup(key)
+			var want entry
+			j := find(key)
+			if j != -1 {
+				want = model[j]
+			}
+			if ok != (j != -1) || stored != want.key || v != want.value {
+				t.Fatalf("%d: Lookup(%q) = %q, %d, %t; want: %q, %d, %t (seed: %d)",
+					i, key, stored, v, ok, want.key, want.value, j != -1, seed)
+			}
+		}
+		if m.Len() != len(model) {
+			t.Fatalf("%d: Len() = %d; want: %d (seed: %d)", i, m.Len(), len(model), seed)
+		}
+	}
+	var got []entry
+	m.Range(func(key string, value int) bool {
+		got = append(got, entry{key, value})
+		return true
+	})
+	if !reflect.DeepEqual(got, model) && (len(got) != 0 || len(model) != 0) {
+		t.Errorf("Range: got: %q\nwant: %q (seed: %d)", got, model, seed)
+	}
+}
+
+func TestMapAllocs(t *testing.T) {
+	var m Map[int]
+	m.Set("Content-Type", 1)
+	m.Set("\u212Aelvin", 2)
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := m.Get("CONTENT-TYPE"); !ok {
+			t.Fatal("Get failed")
+		}
+		if _, _, ok := m.Lookup("KELVIN"); !ok {
+			t.Fatal("Lookup failed")
+		}
+		m.Set("content-type", 3)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
+
+var mapHeaders = []string{
+	"Accept", "Accept-Encoding", "Accept-Language", "Authorization",
+	"Cache-Control", "Connection", "Content-Length", "Content-Type",
+	"Cookie", "Host", "If-Modified-Since", "Origin", "Referer",
+	"User-Agent", "X-Forwarded-For", "X-Request-Id",
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	lookup := make([]string, len(mapHeaders))
+	for i, s := range mapHeaders {
+		lookup[i] = strings.ToUpper(s)
+	}
+	b.Run("Map", func(b *testing.B) {
+		var m Map[int]
+		for i, s := range mapHeaders {
+			m.Set(s, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.Get(lookup[i%len(lookup)])
+		}
+	})
+	b.Run("ToLower", func(b *testing.B) {
+		m := make(map[string]int)
+		for i, s := range mapHeaders {
+			m[strings.ToLower(s)] = i
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m[strings.ToLower(lookup[i%len(lookup)])]
+		}
+	})
+}
@@ -2,39 +2,96 @@ package strcase
 
 import (
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 )
 
-func parseFuncs(t *testing.T, filename string) []string {
-	fset := token.NewFileSet()
-	af, err := parser.ParseFile(fset, filename, nil, parser.AllErrors)
+// parseFuncs returns the names of the exported functions and methods of
+// the package in dir. Methods are named "Type.Method".
+func parseFuncs(t *testing.T, dir string) []string {
+	pkg, err := build.ImportDir(dir, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	fset := token.NewFileSet()
 	var names []string
-	for _, d := range af.Decls {
-		if fd, _ := d.(*ast.FuncDecl); fd != nil {
-			if fd.Name == nil {
+	for _, name := range pkg.GoFiles {
+		af, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.AllErrors)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range af.Decls {
+			fd, _ := d.(*ast.FuncDecl)
+			if fd == nil || fd.Name == nil || !ast.IsExported(fd.Name.Name) {
 				continue
 			}
 			name := fd.Name.Name
-			if ast.IsExported(name) {
-				names = append(names, name)
+			if fd.Recv != nil && len(fd.Recv.List) == 1 {
+				typ := fd.Recv.List[0].Type
+				if star, ok := typ.(*ast.StarExpr); ok {
+					typ = star.X
+				}
+				// Strip the type parameters of generic receivers.
+				switch x := typ.(type) {
+				case *ast.IndexExpr:
+					typ = x.X
+				case *ast.IndexListExpr:
+					typ = x.X
+				}
+				id, ok := typ.(*ast.Ident)
+				if !ok || !ast.IsExported(id.Name) {
+					continue
+				}
+				name = id.Name + "." + name
 			}
+			names = append(names, name)
 		}
 	}
 	sort.Strings(names)
 	return names
 }
 
+// bytcaseRenamed maps the names of bytcase methods to the name of the
+// equivalent strcase method when they differ.
+var bytcaseRenamed = map[string]string{
+	"Replacer.Write": "Replacer.WriteString",
+}
+
+// strcaseOnly is the set of strcase types that intentionally have no
+// bytcase equivalent.
+var strcaseOnly = map[string]bool{
+	"Map": true, // []byte cannot be a map key
+}
+
 // Test that the strcase and bytcase packages have the same API
 func TestPackageParity(t *testing.T) {
-	strnames := parseFuncs(t, "strcase.go")
-	bytenames := parseFuncs(t, "bytcase/bytcase.go")
+	found := make(map[string]bool)
+	var strnames []string
+	for _, name := range parseFuncs(t, ".") {
+		if typ, _, ok := strings.Cut(name, "."); ok && strcaseOnly[typ] {
+			found[typ] = true
+			continue
+		}
+		strnames = append(strnames, name)
+	}
+	for typ := range strcaseOnly {
+		if !found[typ] {
+			t.Errorf("strcaseOnly: strcase does not have any methods on type: %s", typ)
+		}
+	}
+	bytenames := parseFuncs(t, "bytcase")
+	for i, name := range bytenames {
+		if s, ok := bytcaseRenamed[name]; ok {
+			bytenames[i] = s
+		}
+	}
+	sort.Strings(bytenames)
 	if !reflect.DeepEqual(strnames, bytenames) {
 		t.Fatalf("The API of the strcase and bytcase packages differs:\n"+
 			"strcase: %q\n"+
@@ -0,0 +1,79 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import "unicode/utf8"
+
+// byteFrequencies lists lower-case ASCII bytes from the most to the least
+// common in typical English text and source code. Bytes that are not listed
+// (including all non-ASCII bytes) are considered rare.
+const byteFrequencies = " etaoinsrhldcumfpgwybv,.k\n-\"'x0(1)_=j2/:;q*z3\t459867<>{}[]#$%&+!?@\\|^`~"
+
+// byteRank ranks each byte by how common it is (higher is more common).
+var byteRank = func() (rank [256]uint8) {
+	for i := 0; i < len(byteFrequencies); i++ {
+		rank[byteFrequencies[i]] = uint8(len(byteFrequencies) - i)
+	}
+	return rank
+}()
+
+// rareOffset returns the byte offset in substr of the pair of adjacent runes
+// that are least likely to occur in text. Index searches for this pair
+// instead of the first two runes of substr since common leading runes (e.g.
+// the "th" of "the") produce too many false positives.
+//
+// Pairs that contain or follow an invalid rune (utf8.RuneError) are not
+// considered since the runes before the pair are matched with
+// hasSuffixUnicode, which requires the encoded lengths of s and the suffix
+// to be similar. If there are no such pairs zero is returned.
+func rareOffset(substr string) int {
+	var n0, rank0 int
+	best, bestRank := 0, -1
+	for i := 0; i < len(substr); {
+		var r1 rune
+		var n1, rank1 int
+		if c := substr[i]; c < utf8.RuneSelf {
+			r1, n1, rank1 = rune(c), 1, int(byteRank[_lower[c]])
+		} else {
+			r1, n1 = utf8.DecodeRuneInString(substr[i:])
+		}
+		if r1 == utf8.RuneError {
+			break
+		}
+		if i > 0 {
+			if rank := rank0 + rank1; bestRank == -1 || rank < bestRank {
+				best, bestRank = i-n0, rank
+				if rank == 0 {
+					break
+				}
+			}
+		}
+		n0, rank0 = n1, rank1
+		i += n1
+	}
+	return best
+}
+
+// rareBytes returns the offsets i1 < i2 of the two bytes of substr that are
+// least likely to occur in text. These are used as the pair of bytes that
+// bytealg.IndexStringCase searches for. The length of substr must be at
+// least 2.
+func rareBytes(substr string) (i1, i2 int) {
+	i1, i2 = 0, 1
+	if byteRank[_lower[substr[1]]] < byteRank[_lower[substr[0]]] {
+		i1, i2 = 1, 0
+	}
+	for i := 2; i < len(substr); i++ {
+		rank := byteRank[_lower[substr[i]]]
+		if rank < byteRank[_lower[substr[i1]]] {
+			i1, i2 = i, i1
+		} else if rank < byteRank[_lower[substr[i2]]] {
+			i2 = i
+		}
+	}
+	if i1 > i2 {
+		i1, i2 = i2, i1
+	}
+	return i1, i2
+}
@@ -0,0 +1,371 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// Replace returns a copy of the string s with the first n non-overlapping
+// instances of old replaced by new, ignoring case.
+// If old is empty, it matches at the beginning of the string
+// and after each UTF-8 sequence, yielding up to k+1 replacements
+// for a k-rune string.
+// If n < 0, there is no limit on the number of replacements.
+//
+// The length of a match in s may differ from len(old), for example
+// Kelvin K (U+212A) is encoded with three bytes but matches the single
+// byte 'k'.
+func Replace(s, old, new string, n int) string {
+	if n == 0 || len(old) == 0 {
+		// The empty string matches everywhere regardless of case.
+		return strings.Replace(s, old, new, n)
+	}
+	var f Finder
+	f.init(old)
+	i, size := f.indexLen(s)
+	if i == -1 {
+		return s // avoid allocation
+	}
+	var b strings.Builder
+	if len(new) > len(old) {
+		b.Grow(len(s) + len(new) - len(old))
+	} else {
+		b.Grow(len(s))
+	}
+	for {
+		b.WriteString(s[:i])
+		b.WriteString(new)
+		s = s[i+size:]
+		if n--; n == 0 {
+			break
+		}
+		if i, size = f.indexLen(s); i == -1 {
+			break
+		}
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// ReplaceAll returns a copy of the string s with all non-overlapping
+// instances of old replaced by new, ignoring case.
+// If old is empty, it matches at the beginning of the string
+// and after each UTF-8 sequence, yielding up to k+1 replacements
+// for a k-rune string.
+func ReplaceAll(s, old, new string) string {
+	return Replace(s, old, new, -1)
+}
+
+// ReplacePreserveCase is like [Replace] but the case of each replaced
+// instance of old is applied to new. If the instance is all lower case, all
+// upper case, or title case, new is converted to the same case. Otherwise,
+// the case of each character of the instance is applied to the
+// corresponding character of new and any remaining characters of new are
+// left unchanged. For example:
+//
+//	ReplacePreserveCase("color Color COLOR", "color", "colour", -1)
+//	// returns "colour Colour COLOUR"
+//
+// If old is empty, new is inserted unchanged.
+func ReplacePreserveCase(s, old, new string, n int) string {
+	if n == 0 || len(old) == 0 {
+		return strings.Replace(s, old, new, n)
+	}
+	var f Finder
+	f.init(old)
+	i, size := f.indexLen(s)
+	if i == -1 {
+		return s // avoid allocation
+	}
+	var b strings.Builder
+	if len(new) > len(old) {
+		b.Grow(len(s) + len(new) - len(old))
+	} else {
+		b.Grow(len(s))
+	}
+	for {
+		b.WriteString(s[:i])
+		writeCase(&b, s[i:i+size], new)
+		s = s[i+size:]
+		if n--; n == 0 {
+			break
+		}
+		if i, size = f.indexLen(s); i == -1 {
+			break
+		}
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// ReplaceAllPreserveCase is like [ReplaceAll] but the case of each replaced
+// instance of old is applied to new (see [ReplacePreserveCase]).
+func ReplaceAllPreserveCase(s, old, new string) string {
+	return ReplacePreserveCase(s, old, new, -1)
+}
+
+// A casePattern describes the case of the characters in a string.
+type casePattern uint8
+
+const (
+	caseNone  casePattern = iota // no characters with case
+	caseLower                    // all lower case
+	caseUpper                    // all upper case
+	caseTitle                    // first character upper case, the rest lower case
+	caseMixed                    // any other combination
+)
+
+// runeCase returns 1 if r is upper case, -1 if r is lower case, and 0 if r
+// does not have case.
+func runeCase(r rune) int {
+	u, l, ok := tables.ToUpperLower(r)
+	switch {
+	case !ok || u == l:
+		return 0
+	case r == u:
+		return 1
+	case r == l:
+		return -1
+	}
+	return 0 // title case (e.g. 'ǅ')
+}
+
+func matchCase(s string) casePattern {
+	var upper, lower int
+	firstUpper := false
+	for _, r := range s {
+		switch runeCase(r) {
+		case 1:
+			if upper == 0 && lower == 0 {
+				firstUpper = true
+			}
+			upper++
+		case -1:
+			lower++
+		}
+	}
+	switch {
+	case upper == 0 && lower == 0:
+		return caseNone
+	case upper == 0:
+		return caseLower
+	case firstUpper && upper == 1:
+		return caseTitle // includes a single upper case character
+	case lower == 0:
+		return caseUpper
+	}
+	return caseMixed
+}
+
+// toCase returns the upper case of r if c is greater than zero, the lower
+// case of r if c is less than zero, and r otherwise.
+func toCase(r rune, c int) rune {
+	if c == 0 {
+		return r
+	}
+	u, l, ok := tables.ToUpperLower(r)
+	if !ok {
+		return r
+	}
+	if c > 0 {
+		return u
+	}
+	return l
+}
+
+// writeCase writes new to b with the case pattern of match applied to it.
+func writeCase(b *strings.Builder, match, new string) {
+	p := matchCase(match)
+	if p == caseNone {
+		b.WriteString(new)
+		return
+	}
+	first := true // next character with case is the first
+	for len(new) > 0 {
+		r, size := utf8.DecodeRuneInString(new)
+		if r == utf8.RuneError && size == 1 {
+			b.WriteByte(new[0]) // preserve invalid UTF-8
+			new = new[1:]
+			continue
+		}
+		new = new[size:]
+		var c int
+		switch p {
+		case caseLower:
+			c = -1
+		case caseUpper:
+			c = 1
+		case caseTitle:
+			c = -1
+			if first && runeCase(r) != 0 {
+				c = 1
+				first = false
+			}
+		case caseMixed:
+			if len(match) > 0 {
+				mr, size := utf8.DecodeRuneInString(match)
+				match = match[size:]
+				c = runeCase(mr)
+			}
+		}
+		b.WriteRune(toCase(r, c))
+	}
+}
+
+// Replacer replaces a list of strings with replacements ignoring case.
+// It is safe for concurrent use by multiple goroutines.
+type Replacer struct {
+	oldnew  []string
+	finders []Finder // finders[i] searches for oldnew[2*i]
+	empty   bool     // one of the old strings is empty
+}
+
+// NewReplacer returns a new [Replacer] from a list of old, new string
+// pairs. Replacements are performed in the order they appear in the
+// target string, without overlapping matches. The old string
+// comparisons are done in argument order and ignore case.
+//
+// Unlike [strings.Replacer], an empty old string only matches at the
+// beginning of the string and after each UTF-8 sequence.
+//
+// NewReplacer panics if given an odd number of arguments.
+func NewReplacer(oldnew ...string) *Replacer {
+	if len(oldnew)%2 == 1 {
+		panic("strcase.NewReplacer: odd argument count")
+	}
+	r := &Replacer{
+		oldnew:  append([]string(nil), oldnew...),
+		finders: make([]Finder, len(oldnew)/2),
+	}
+	for i := range r.finders {
+		old := r.oldnew[2*i]
+		r.finders[i].init(old)
+		if len(old) == 0 {
+			r.empty = true
+		}
+	}
+	return r
+}
+
+// Replace returns a copy of s with all replacements performed.
+func (r *Replacer) Replace(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	r.replace(&b, s)
+	return b.String()
+}
+
+type stringWriter struct {
+	w io.Writer
+}
+
+func (w stringWriter) WriteString(s string) (int, error) {
+	return w.w.Write([]byte(s))
+}
+
+// WriteString writes s to w with all replacements performed.
+func (r *Replacer) WriteString(w io.Writer, s string) (n int, err error) {
+	sw, ok := w.(io.StringWriter)
+	if !ok {
+		sw = stringWriter{w}
+	}
+	return r.replace(sw, s)
+}
+
+func (r *Replacer) replace(w io.StringWriter, s string) (n int, err error) {
+	// next[j] is the index of the next match of finders[j] in s or
+	// len(s)+1 if there are no more matches. It is only valid if it
+	// is greater than or equal to the current position. end[j] is
+	// the index of the end of that match.
+	var buf, endBuf [16]int
+	var next, end []int
+	if len(r.finders) <= len(buf) {
+		next, end = buf[:len(r.finders)], endBuf[:len(r.finders)]
+	} else {
+		next, end = make([]int, len(r.finders)), make([]int, len(r.finders))
+	}
+	for j := range next {
+		next[j] = -1
+	}
+
+	var last, wn int
+	var prevMatchEmpty bool
+	for i := 0; i <= len(s); {
+		// Find the first old string, in argument order, that matches at i
+		// and the index of the next match if none do.
+		match := -1
+		min := len(s) + 1
+		for j := range r.finders {
+			f := &r.finders[j]
+			if len(f.substr) == 0 {
+				// The empty string matches everywhere, but two empty matches
+				// at the same position are ignored.
+				if !prevMatchEmpty {
+					match = j
+					break
+				}
+				continue
+			}
+			if next[j] < i {
+				if o, size := f.indexLen(s[i:]); o >= 0 {
+					next[j] = i + o
+					end[j] = i + o + size
+				} else {
+					next[j] = len(s) + 1
+				}
+			}
+			if next[j] == i {
+				match = j
+				break
+			}
+			if next[j] < min {
+				min = next[j]
+			}
+		}
+		if match >= 0 {
+			f := &r.finders[match]
+			wn, err = w.WriteString(s[last:i])
+			n += wn
+			if err != nil {
+				return
+			}
+			wn, err = w.WriteString(r.oldnew[2*match+1])
+			n += wn
+			if err != nil {
+				return
+			}
+			prevMatchEmpty = len(f.substr) == 0
+			if !prevMatchEmpty {
+				i = end[match]
+			}
+			last = i
+			continue
+		}
+		prevMatchEmpty = false
+		if !r.empty {
+			i = min // skip to the next match
+			continue
+		}
+		// The empty string matched at i so advance by one character.
+		if i == len(s) {
+			break
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+		} else {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+	}
+	if last != len(s) {
+		wn, err = w.WriteString(s[last:])
+		n += wn
+	}
+	return
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestReplace(t *testing.T) {
+	test.Replace(t, Replace)
+}
+
+func TestReplaceAll(t *testing.T) {
+	test.Replace(t, func(s, old, new string, n int) string {
+		if n >= 0 {
+			return Replace(s, old, new, n)
+		}
+		return ReplaceAll(s, old, new)
+	})
+}
+
+func TestReplacer(t *testing.T) {
+	test.Replacer(t, func(oldnew ...string) func(string) string {
+		return NewReplacer(oldnew...).Replace
+	})
+}
+
+func TestReplacerWriteString(t *testing.T) {
+	test.Replacer(t, func(oldnew ...string) func(string) string {
+		r := NewReplacer(oldnew...)
+		return func(s string) string {
+			var b bytes.Buffer // does not implement io.StringWriter
+			n, err := r.WriteString(struct{ *bytes.Buffer }{&b}, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != b.Len() {
+				t.Errorf("WriteString(%q) = %d; want: %d", s, n, b.Len())
+			}
+			return b.String()
+		}
+	})
+}
+
+type errWriter struct{ n int }
+
+var errShortWrite = errors.New("short write")
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errShortWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestReplacerWriteStringError(t *testing.T) {
+	r := NewReplacer("a", "123")
+	n, err := r.WriteString(&errWriter{n: 5}, "xaxa")
+	if n != 5 || err != errShortWrite {
+		t.Errorf("WriteString() = %d, %v; want: %d, %v", n, err, 5, errShortWrite)
+	}
+}
+
+func TestReplacerOddArgs(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Error("NewReplacer with an odd number of arguments should panic")
+		}
+	}()
+	NewReplacer("a")
+}
+
+func TestReplaceAllocs(t *testing.T) {
+	s := strings.Repeat("abc", 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Replace(s, "XYZ", "x", -1)
+	})
+	if allocs != 0 {
+		t.Errorf("Replace without a match allocated: %.1f", allocs)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = Replace(s, "B", "x", -1)
+	})
+	if allocs != 1 {
+		t.Errorf("Replace allocated: %.1f; want: 1", allocs)
+	}
+}
+
+func BenchmarkReplace(b *testing.B) {
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		Replace(s, "FOX", "cat", -1)
+	}
+}
+
+func BenchmarkReplacer(b *testing.B) {
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 32)
+	r := NewReplacer("FOX", "cat", "DOG", "bird", "the", "a")
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		r.Replace(s)
+	}
+}
+
+func TestReplacePreserveCase(t *testing.T) {
+	test.ReplacePreserveCase(t, ReplacePreserveCase)
+	test.ReplacePreserveCase(t, func(s, old, new string, n int) string {
+		if n >= 0 {
+			return ReplacePreserveCase(s, old, new, n)
+		}
+		return ReplaceAllPreserveCase(s, old, new)
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// AppendSortKey appends a binary sort key for s to dst and returns the
+// extended buffer. Sort keys compare the same as the strings they were
+// created from do with Compare:
+//
+//	bytes.Compare(AppendSortKey(nil, a), AppendSortKey(nil, b)) == Compare(a, b)
+//
+// and the sort keys of two strings are equal if and only if EqualFold
+// reports that the strings are equal. This allows case-insensitive indexes
+// to be stored in systems that only compare raw bytes.
+//
+// The sort key is the UTF-8 encoding of the simple case-fold of each rune
+// in s, which preserves the order of the case-folded runes that Compare
+// uses. Invalid UTF-8 sequences are encoded as U+FFFD.
+func AppendSortKey(dst []byte, s string) []byte {
+	i := indexUnfolded(s)
+	if i == -1 {
+		return append(dst, s...)
+	}
+	if n := len(s); cap(dst)-len(dst) < n {
+		dst = append(dst, make([]byte, n)...)[:len(dst)]
+	}
+	dst = append(dst, s[:i]...)
+	for i < len(s) {
+		if c := s[i]; c < utf8.RuneSelf {
+			dst = append(dst, _lower[c])
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		dst = utf8.AppendRune(dst, tables.CaseFold(r))
+		i += size
+	}
+	return dst
+}
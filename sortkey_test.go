@@ -0,0 +1,74 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestAppendSortKey(t *testing.T) {
+	test.AppendSortKey(t, AppendSortKey, Compare)
+}
+
+func TestAppendSortKeyFuzz(t *testing.T) {
+	test.AppendSortKeyFuzz(t, AppendSortKey, Compare)
+}
+
+// Test that sorting by sort key produces the same order as sorting with
+// Compare.
+func TestAppendSortKeySort(t *testing.T) {
+	words := strings.Fields("\u212Aelvin kelvin Kelvin Stop \u017Ftop STOP " +
+		"straße STRASSE αβγ ΑΒΓ Zebra apple Apple İstanbul istanbul " +
+		"ıstanbul")
+	byCompare := append([]string(nil), words...)
+	sort.SliceStable(byCompare, func(i, j int) bool {
+		return Compare(byCompare[i], byCompare[j]) < 0
+	})
+	byKey := append([]string(nil), words...)
+	sort.SliceStable(byKey, func(i, j int) bool {
+		return string(AppendSortKey(nil, byKey[i])) < string(AppendSortKey(nil, byKey[j]))
+	})
+	for i := range byCompare {
+		if byCompare[i] != byKey[i] {
+			t.Errorf("sort by key = %q\nsort by Compare = %q", byKey, byCompare)
+			break
+		}
+	}
+}
+
+func TestAppendSortKeyAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendSortKey(buf[:0], "Hello, Kelvin!")
+		if string(buf) != "hello, kelvin!" {
+			t.Fatalf("AppendSortKey = %q", buf)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
+
+func BenchmarkAppendSortKey(b *testing.B) {
+	bench := func(b *testing.B, s string) {
+		buf := make([]byte, 0, len(s)*2)
+		b.SetBytes(int64(len(s)))
+		for i := 0; i < b.N; i++ {
+			buf = AppendSortKey(buf[:0], s)
+		}
+	}
+	b.Run("Lower", func(b *testing.B) {
+		bench(b, "content-type")
+	})
+	b.Run("Mixed", func(b *testing.B) {
+		bench(b, "Content-Type")
+	})
+	b.Run("Unicode", func(b *testing.B) {
+		bench(b, "ΑΒΓΔΕ αβγδε")
+	})
+}
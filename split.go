@@ -0,0 +1,104 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import "strings"
+
+// genSplit splits s around each instance of sep, ignoring case, including
+// the matched separator in the subarrays if sepSave is true.
+// The length of a matched separator may differ from len(sep).
+func genSplit(s, sep string, sepSave bool, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	if sep == "" {
+		// Case does not matter when exploding s into UTF-8 sequences.
+		return strings.SplitN(s, sep, n)
+	}
+	var f Finder
+	f.init(sep)
+	if n < 0 {
+		n = f.Count(s) + 1
+	}
+	if n > len(s)+1 {
+		n = len(s) + 1
+	}
+	a := make([]string, n)
+	n--
+	i := 0
+	for i < n {
+		m, size := f.indexLen(s)
+		if m < 0 {
+			break
+		}
+		e := m + size
+		if sepSave {
+			a[i] = s[:e]
+		} else {
+			a[i] = s[:m]
+		}
+		s = s[e:]
+		i++
+	}
+	a[i] = s
+	return a[:i+1]
+}
+
+// SplitN slices s into substrings separated by sep, ignoring case, and
+// returns a slice of the substrings between those separators.
+//
+// The count determines the number of substrings to return:
+//   - n > 0: at most n substrings; the last substring will be the unsplit remainder;
+//   - n == 0: the result is nil (zero substrings);
+//   - n < 0: all substrings.
+//
+// Edge cases for s and sep (for example, empty strings) are handled
+// as described in the documentation for [Split].
+//
+// To split around the first instance of a separator, see [Cut].
+func SplitN(s, sep string, n int) []string { return genSplit(s, sep, false, n) }
+
+// SplitAfterN slices s into substrings after each instance of sep, ignoring
+// case, and returns a slice of those substrings. The substrings include the
+// separator as it appears in s.
+//
+// The count determines the number of substrings to return:
+//   - n > 0: at most n substrings; the last substring will be the unsplit remainder;
+//   - n == 0: the result is nil (zero substrings);
+//   - n < 0: all substrings.
+//
+// Edge cases for s and sep (for example, empty strings) are handled
+// as described in the documentation for [SplitAfter].
+func SplitAfterN(s, sep string, n int) []string {
+	return genSplit(s, sep, true, n)
+}
+
+// Split slices s into all substrings separated by sep, ignoring case, and
+// returns a slice of the substrings between those separators.
+//
+// If s does not contain sep and sep is not empty, Split returns a
+// slice of length 1 whose only element is s.
+//
+// If sep is empty, Split splits after each UTF-8 sequence. If both s
+// and sep are empty, Split returns an empty slice.
+//
+// It is equivalent to [SplitN] with a count of -1.
+//
+// To split around the first instance of a separator, see [Cut].
+func Split(s, sep string) []string { return genSplit(s, sep, false, -1) }
+
+// SplitAfter slices s into all substrings after each instance of sep,
+// ignoring case, and returns a slice of those substrings. The substrings
+// include the separator as it appears in s.
+//
+// If s does not contain sep and sep is not empty, SplitAfter returns
+// a slice of length 1 whose only element is s.
+//
+// If sep is empty, SplitAfter splits after each UTF-8 sequence. If
+// both s and sep are empty, SplitAfter returns an empty slice.
+//
+// It is equivalent to [SplitAfterN] with a count of -1.
+func SplitAfter(s, sep string) []string {
+	return genSplit(s, sep, true, -1)
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestSplit(t *testing.T) {
+	test.Split(t, func(s, sep string, n int) []string {
+		if n >= 0 {
+			return SplitN(s, sep, n)
+		}
+		return Split(s, sep)
+	})
+}
+
+func TestSplitN(t *testing.T) {
+	test.Split(t, SplitN)
+}
+
+func TestSplitAfter(t *testing.T) {
+	test.SplitAfter(t, func(s, sep string, n int) []string {
+		if n >= 0 {
+			return SplitAfterN(s, sep, n)
+		}
+		return SplitAfter(s, sep)
+	})
+}
+
+func TestSplitAfterN(t *testing.T) {
+	test.SplitAfter(t, SplitAfterN)
+}
+
+func BenchmarkSplit(b *testing.B) {
+	s := "a,B,c,D,e,F,g,H,i,J,k,L,m,N,o,P,q,R,s,T,u,V,w,X,y,Z"
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Split(s, ",")
+	}
+}
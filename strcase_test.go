@@ -43,6 +43,10 @@ func TestIndexInvalid(t *testing.T) {
 	test.IndexInvalid(t, Index)
 }
 
+func TestIndexPeriodic(t *testing.T) {
+	test.IndexPeriodic(t, Index)
+}
+
 func TestIndexRuneIndexParity(t *testing.T) {
 	test.IndexRuneIndexParity(t, Index, IndexRune)
 }
@@ -73,17 +77,19 @@ func TestIndexKelvin(t *testing.T) {
 // Test the Rabin-Karp fallback logic directly since not all test cases will
 // trigger it.
 func TestRabinKarp(t *testing.T) {
-	test.Index(t, test.WrapRabinKarp(indexRabinKarpUnicode))
+	fn := test.IndexLenCheckFunc(t, "indexRabinKarpUnicode", indexRabinKarpUnicode)
+	test.Index(t, test.WrapRabinKarp(fn))
 }
 
 // Test the Rabin-Karp fallback logic directly since not all test cases will
 // trigger it.
 func TestRabinKarpUnicode(t *testing.T) {
-	test.IndexUnicode(t, test.WrapRabinKarp(indexRabinKarpUnicode))
+	fn := test.IndexLenCheckFunc(t, "indexRabinKarpUnicode", indexRabinKarpUnicode)
+	test.IndexUnicode(t, test.WrapRabinKarp(fn))
 }
 
 func TestBruteForceIndexUnicode(t *testing.T) {
-	test.IndexUnicode(t, func(s, substr string) int {
+	test.IndexUnicode(t, test.IndexLenCheckFunc(t, "bruteForceIndexUnicode", func(s, substr string) (int, int) {
 		n := len(substr)
 		var size int
 		if n > 0 {
@@ -95,10 +101,10 @@ func TestBruteForceIndexUnicode(t *testing.T) {
 		}
 		if len(s) == 0 || len(substr) == 0 || n == size {
 			// Can't use brute-force here
-			return Index(s, substr)
+			return IndexLen(s, substr)
 		}
 		return bruteForceIndexUnicode(s, substr)
-	})
+	}))
 }
 
 func TestIndexAllocs(t *testing.T) {
@@ -135,6 +141,10 @@ func TestLastIndexInvalid(t *testing.T) {
 	test.LastIndexInvalid(t, LastIndex)
 }
 
+func TestLastIndexPeriodic(t *testing.T) {
+	test.LastIndexPeriodic(t, LastIndex)
+}
+
 func TestIndexRune(t *testing.T) {
 	test.IndexRune(t, IndexRune)
 }
@@ -166,7 +176,13 @@ func TestContainsRune(t *testing.T) {
 }
 
 func TestLastIndexRune(t *testing.T) {
-	test.LastIndexRune(t, lastIndexRune)
+	test.LastIndexRune(t, func(s string, r rune) int {
+		i, n := lastIndexRune(s, r)
+		if i >= 0 && !strings.EqualFold(s[i:i+n], string(r)) {
+			t.Errorf("lastIndexRune(%q, %q) = %d, %d: invalid match length", s, r, i, n)
+		}
+		return i
+	})
 }
 
 func TestIndexByte(t *testing.T) {
@@ -189,6 +205,19 @@ func TestHasPrefix(t *testing.T) {
 	test.HasPrefix(t, hasPrefixUnicode)
 }
 
+func TestHasPrefixUnicodeLen(t *testing.T) {
+	fn := func(s, prefix string) (bool, bool) {
+		match, exhausted, n := hasPrefixUnicodeLen(s, prefix)
+		if (match && !strings.EqualFold(s[:n], prefix)) || (!match && n != 0) {
+			t.Errorf("hasPrefixUnicodeLen(%q, %q) = %t, %t, %d: invalid match length",
+				s, prefix, match, exhausted, n)
+		}
+		return match, exhausted
+	}
+	test.HasPrefix(t, fn)
+	test.HasPrefixFuzz(t, fn)
+}
+
 func TestTrimPrefix(t *testing.T) {
 	test.TrimPrefix(t, TrimPrefix)
 }
@@ -213,6 +242,34 @@ func TestTestLastIndexAny(t *testing.T) {
 	test.LastIndexAny(t, LastIndexAny)
 }
 
+func TestIndexLen(t *testing.T) {
+	test.IndexLen(t, IndexLen)
+	fn := test.IndexLenCheckFunc(t, "IndexLen", IndexLen)
+	test.Index(t, fn)
+	test.IndexUnicode(t, fn)
+	test.IndexKelvin(t, fn)
+	test.IndexInvalid(t, fn)
+	test.IndexPeriodic(t, fn)
+	test.IndexFuzz(t, fn)
+}
+
+func TestLastIndexLen(t *testing.T) {
+	test.LastIndexLen(t, LastIndexLen)
+	fn := test.IndexLenCheckFunc(t, "LastIndexLen", LastIndexLen)
+	test.LastIndex(t, fn)
+	test.LastIndexInvalid(t, fn)
+	test.LastIndexPeriodic(t, fn)
+	test.LastIndexFuzz(t, fn)
+}
+
+func TestHasPrefixLen(t *testing.T) {
+	test.HasPrefixLen(t, HasPrefixLen)
+}
+
+func TestHasSuffixLen(t *testing.T) {
+	test.HasSuffixLen(t, HasSuffixLen)
+}
+
 func TestCut(t *testing.T) {
 	test.Cut(t, Cut)
 }
@@ -299,6 +356,12 @@ func BenchmarkCompare(b *testing.B) {
 		bench(b, s, t)
 	})
 
+	b.Run("ASCII_4K", func(b *testing.B) {
+		s := strings.Repeat(s1, 4096/len(s1))
+		t := strings.Repeat(s2, 4096/len(s2))
+		bench(b, s, t)
+	})
+
 	b.Run("UnicodePrefix", func(b *testing.B) {
 		// WARN
 		const s1 = "AbCdCfghIjKz"
@@ -998,6 +1061,18 @@ func BenchmarkHasPrefixLonger(b *testing.B) {
 	})
 }
 
+func BenchmarkHasSuffixASCII(b *testing.B) {
+	s0 := strings.Repeat("a", 64)
+	s1 := strings.Repeat("A", 64)
+	if !HasSuffix(s0, s1) {
+		b.Fatalf("HasSuffix(%[1]q, %[1]q) = false; want: true", s0, s1)
+	}
+	b.SetBytes(int64(len(s0)))
+	for i := 0; i < b.N; i++ {
+		HasSuffix(s0, s1)
+	}
+}
+
 // TODO: need to compare against the stdlib
 func BenchmarkHasSuffix(b *testing.B) {
 	if !HasSuffix(benchmarkString, benchmarkString) {
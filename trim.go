@@ -0,0 +1,95 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import "unicode/utf8"
+
+// Trim returns a slice of the string s with all leading and trailing Unicode
+// code points contained in cutset removed, ignoring case. For example, the
+// cutset "s" removes 's', 'S' and 'ſ' (U+017F).
+func Trim(s, cutset string) string {
+	if s == "" || cutset == "" {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimLeftASCII(trimRightASCII(s, &as), &as)
+	}
+	return trimRightUnicode(trimLeftUnicode(s, cutset), cutset)
+}
+
+// TrimLeft returns a slice of the string s with all leading Unicode code
+// points contained in cutset removed, ignoring case.
+//
+// To remove a prefix, use [TrimPrefix] instead.
+func TrimLeft(s, cutset string) string {
+	if s == "" || cutset == "" {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimLeftASCII(s, &as)
+	}
+	return trimLeftUnicode(s, cutset)
+}
+
+// TrimRight returns a slice of the string s with all trailing Unicode code
+// points contained in cutset removed, ignoring case.
+//
+// To remove a suffix, use [TrimSuffix] instead.
+func TrimRight(s, cutset string) string {
+	if s == "" || cutset == "" {
+		return s
+	}
+	if as, ok := makeASCIISet(s, cutset); ok {
+		return trimRightASCII(s, &as)
+	}
+	return trimRightUnicode(s, cutset)
+}
+
+func trimLeftASCII(s string, as *asciiSet) string {
+	for len(s) > 0 {
+		if !as.contains(s[0]) {
+			break
+		}
+		s = s[1:]
+	}
+	return s
+}
+
+func trimRightASCII(s string, as *asciiSet) string {
+	for len(s) > 0 {
+		if !as.contains(s[len(s)-1]) {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func trimLeftUnicode(s, cutset string) string {
+	for len(s) > 0 {
+		r, n := rune(s[0]), 1
+		if r >= utf8.RuneSelf {
+			r, n = utf8.DecodeRuneInString(s)
+		}
+		if IndexRune(cutset, r) < 0 {
+			break
+		}
+		s = s[n:]
+	}
+	return s
+}
+
+func trimRightUnicode(s, cutset string) string {
+	for len(s) > 0 {
+		r, size := rune(s[len(s)-1]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeLastRuneInString(s)
+		}
+		if IndexRune(cutset, r) < 0 {
+			break
+		}
+		s = s[:len(s)-size]
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+func TestTrim(t *testing.T) {
+	test.Trim(t, Trim)
+}
+
+func TestTrimLeft(t *testing.T) {
+	test.TrimLeft(t, TrimLeft)
+}
+
+func TestTrimRight(t *testing.T) {
+	test.TrimRight(t, TrimRight)
+}
+
+func BenchmarkTrim(b *testing.B) {
+	s := strings.Repeat("xX", 8) + "hello, world" + strings.Repeat("Xx", 8)
+	b.Run("ASCII", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Trim(s, "x")
+		}
+	})
+	b.Run("Unicode", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Trim(s, "x☺")
+		}
+	})
+}
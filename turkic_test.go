@@ -0,0 +1,152 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"testing"
+
+	"github.com/charlievieth/strcase/internal/test"
+)
+
+// Most of these tests map their input from the default to the Turkic
+// case-folding rules so that the Turkic functions are held to the same
+// test suites as the default functions (see test.TurkicIndexFunc).
+
+func TestTurkicCompare(t *testing.T) {
+	test.TurkicCompare(t, Turkic.Compare)
+	test.Compare(t, test.TurkicCompareFunc(Turkic.Compare))
+}
+
+func TestTurkicEqualFold(t *testing.T) {
+	test.TurkicEqualFold(t, Turkic.EqualFold)
+	test.EqualFold(t, test.TurkicContainsFunc(Turkic.EqualFold))
+}
+
+func TestTurkicIndex(t *testing.T) {
+	test.TurkicIndex(t, Turkic.Index)
+	test.Index(t, test.TurkicIndexFunc(Turkic.Index))
+}
+
+func TestTurkicIndexUnicode(t *testing.T) {
+	test.IndexUnicode(t, test.TurkicIndexFunc(Turkic.Index))
+}
+
+func TestTurkicIndexInvalid(t *testing.T) {
+	test.IndexInvalid(t, test.TurkicIndexFunc(Turkic.Index))
+}
+
+func TestTurkicIndexKelvin(t *testing.T) {
+	test.IndexKelvin(t, test.TurkicIndexFunc(Turkic.Index))
+}
+
+func TestTurkicIndexRuneIndexParity(t *testing.T) {
+	test.IndexRuneIndexParity(t, test.TurkicIndexFunc(Turkic.Index),
+		test.TurkicIndexRuneFunc(Turkic.IndexRune))
+}
+
+func TestTurkicIndexAllAssigned(t *testing.T) {
+	test.IndexAllAssigned(t,
+		test.TestFunc{Name: "Compare", Index: test.TurkicCompareFunc(Turkic.Compare)},
+		test.TestFunc{Name: "Contains", Contains: test.TurkicContainsFunc(Turkic.Contains)},
+		test.TestFunc{Name: "EqualFold", Contains: test.TurkicContainsFunc(Turkic.EqualFold)},
+		test.TestFunc{Name: "HasPrefix", Contains: test.TurkicContainsFunc(Turkic.HasPrefix)},
+		test.TestFunc{Name: "HasSuffix", Contains: test.TurkicContainsFunc(Turkic.HasSuffix)},
+		test.TestFunc{Name: "Index", Index: test.TurkicIndexFunc(Turkic.Index)},
+		test.TestFunc{Name: "LastIndex", Index: test.TurkicIndexFunc(Turkic.LastIndex)},
+	)
+}
+
+func TestTurkicContains(t *testing.T) {
+	test.Contains(t, test.TurkicContainsFunc(Turkic.Contains))
+}
+
+func TestTurkicContainsAny(t *testing.T) {
+	test.ContainsAny(t, test.TurkicContainsFunc(Turkic.ContainsAny))
+}
+
+func TestTurkicLastIndex(t *testing.T) {
+	test.TurkicLastIndex(t, Turkic.LastIndex)
+	test.LastIndex(t, test.TurkicIndexFunc(Turkic.LastIndex))
+}
+
+func TestTurkicLastIndexInvalid(t *testing.T) {
+	test.LastIndexInvalid(t, test.TurkicIndexFunc(Turkic.LastIndex))
+}
+
+func TestTurkicIndexRune(t *testing.T) {
+	test.IndexRune(t, test.TurkicIndexRuneFunc(Turkic.IndexRune))
+}
+
+func TestTurkicContainsRune(t *testing.T) {
+	test.ContainsRune(t, test.TurkicContainsRuneFunc(Turkic.ContainsRune))
+}
+
+func TestTurkicIndexByte(t *testing.T) {
+	test.IndexByte(t, test.TurkicIndexByteFunc(Turkic.IndexByte))
+}
+
+func TestTurkicLastIndexByte(t *testing.T) {
+	test.LastIndexByte(t, test.TurkicIndexByteFunc(Turkic.LastIndexByte))
+}
+
+func TestTurkicTrimPrefix(t *testing.T) {
+	test.TrimPrefix(t, test.TurkicTrimFunc(Turkic.TrimPrefix))
+}
+
+func TestTurkicHasSuffix(t *testing.T) {
+	test.HasSuffix(t, test.TurkicContainsFunc(Turkic.HasSuffix))
+}
+
+func TestTurkicTrimSuffix(t *testing.T) {
+	test.TrimSuffix(t, test.TurkicTrimFunc(Turkic.TrimSuffix))
+}
+
+func TestTurkicCount(t *testing.T) {
+	test.Count(t, test.TurkicCountFunc(Turkic.Count))
+}
+
+func TestTurkicIndexAny(t *testing.T) {
+	test.IndexAny(t, test.TurkicIndexFunc(Turkic.IndexAny))
+}
+
+func TestTurkicLastIndexAny(t *testing.T) {
+	test.LastIndexAny(t, test.TurkicIndexFunc(Turkic.LastIndexAny))
+}
+
+func TestTurkicCut(t *testing.T) {
+	test.Cut(t, test.TurkicCutFunc(Turkic.Cut))
+}
+
+func TestTurkicCutPrefix(t *testing.T) {
+	test.CutPrefix(t, test.TurkicCutAffixFunc(Turkic.CutPrefix))
+}
+
+func TestTurkicCutSuffix(t *testing.T) {
+	test.CutSuffix(t, test.TurkicCutAffixFunc(Turkic.CutSuffix))
+}
+
+func TestTurkicIndexFuzz(t *testing.T) {
+	test.IndexFuzz(t, test.TurkicIndexFunc(Turkic.Index))
+}
+
+func TestTurkicLastIndexFuzz(t *testing.T) {
+	test.LastIndexFuzz(t, test.TurkicIndexFunc(Turkic.LastIndex))
+}
+
+func TestTurkicHasSuffixFuzz(t *testing.T) {
+	test.HasSuffixFuzz(t, test.TurkicContainsFunc(Turkic.HasSuffix))
+}
+
+func TestTurkicCompareFuzz(t *testing.T) {
+	test.CompareFuzz(t, test.TurkicCompareFunc(Turkic.Compare))
+}
+
+func TestTurkicEqualFoldFuzz(t *testing.T) {
+	test.EqualFoldFuzz(t,
+		test.TestFunc{Name: "Contains", Contains: test.TurkicContainsFunc(Turkic.Contains)},
+		test.TestFunc{Name: "EqualFold", Contains: test.TurkicContainsFunc(Turkic.EqualFold)},
+		test.TestFunc{Name: "HasPrefix", Contains: test.TurkicContainsFunc(Turkic.HasPrefix)},
+		test.TestFunc{Name: "HasSuffix", Contains: test.TurkicContainsFunc(Turkic.HasSuffix)},
+	)
+}
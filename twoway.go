@@ -0,0 +1,357 @@
+// Copyright 2023 Charlie Vieth. All rights reserved.
+// Use of this source code is governed by the MIT license.
+
+package strcase
+
+import (
+	"unicode/utf8"
+
+	"github.com/charlievieth/strcase/internal/tables"
+)
+
+// twoWayMinLen is the minimum length of a needle for which Index and
+// LastIndex use the Two-Way algorithm instead of searching for the first
+// runes of the needle (or Rabin-Karp). Two-Way is slower on average, but its
+// worst case is linear, while the cost of the other searches is
+// proportional to the length of the needle times the length of s.
+const twoWayMinLen = 64
+
+// twoWay is a case-insensitive implementation of the Two-Way string
+// matching algorithm of Crochemore and Perrin that operates on the simple
+// case-folds of runes. It performs at most 2*len(s) rune comparisons and
+// does not require random access to s, which allows it to search UTF-8
+// encoded text without decoding it into a buffer.
+//
+// See: https://en.wikipedia.org/wiki/Two-way_string-matching_algorithm
+type twoWay struct {
+	needle   []rune // case-folded runes of the needle (reversed for lastIndex)
+	ell      int    // the needle is factored into needle[:ell+1] and needle[ell+1:]
+	per      int    // period of the needle or the shift used if not periodic
+	periodic bool   // needle[:ell+1] is a suffix of needle[:ell+1+per]
+}
+
+// twoWayBufLen is the size of the stack buffer indexTwoWay and
+// lastIndexTwoWay use to store the folded runes of the needle. Longer
+// needles are rare and allocate.
+const twoWayBufLen = 256
+
+// init initializes tw to search for substr. If reverse is true tw searches
+// for the last instance of substr. The folded runes of substr are appended
+// to buf, which may be nil.
+func (tw *twoWay) init(substr string, reverse bool, buf []rune) {
+	if n := len(substr); n > cap(buf) {
+		if n = utf8.RuneCountInString(substr); n > cap(buf) {
+			buf = make([]rune, 0, n)
+		}
+	}
+	x := buf[:0]
+	for i := 0; i < len(substr); {
+		r, n := foldAt(substr, i)
+		x = append(x, r)
+		i += n
+	}
+	if reverse {
+		for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	tw.needle = x
+
+	// Critical factorization
+	ms1, p1 := maximalSuffix(x, false)
+	ms2, p2 := maximalSuffix(x, true)
+	if ms1 > ms2 {
+		tw.ell, tw.per = ms1, p1
+	} else {
+		tw.ell, tw.per = ms2, p2
+	}
+	tw.periodic = tw.ell+1+tw.per <= len(x)
+	for i := 0; tw.periodic && i <= tw.ell; i++ {
+		if x[i] != x[i+tw.per] {
+			tw.periodic = false
+		}
+	}
+	if !tw.periodic {
+		if tw.ell+1 > len(x)-tw.ell-1 {
+			tw.per = tw.ell + 2
+		} else {
+			tw.per = len(x) - tw.ell
+		}
+	}
+}
+
+// maximalSuffix returns the start of the lexicographically maximal suffix
+// of x and the period of that suffix. If rev is true the order of runes is
+// reversed.
+func maximalSuffix(x []rune, rev bool) (ms, p int) {
+	ms, p = -1, 1
+	j, k := 0, 1
+	for j+k < len(x) {
+		a, b := x[j+k], x[ms+k]
+		if rev {
+			a, b = b, a
+		}
+		switch {
+		case a < b:
+			// Suffix is smaller, period is the entire prefix so far.
+			j += k
+			k = 1
+			p = j - ms
+		case a == b:
+			// Advance through the repetition of the current period.
+			if k != p {
+				k++
+			} else {
+				j += p
+				k = 1
+			}
+		default:
+			// Suffix is larger, start over from the current location.
+			ms = j
+			j = ms + 1
+			k, p = 1, 1
+		}
+	}
+	return ms, p
+}
+
+// foldAt returns the folded rune that starts at s[i] and its size.
+func foldAt(s string, i int) (rune, int) {
+	if c := s[i]; c < utf8.RuneSelf {
+		return rune(_lower[c]), 1
+	}
+	return foldAtUnicode(s, i)
+}
+
+// foldAtUnicode is the non-ASCII path of foldAt, it is separate so that
+// foldAt can be inlined.
+func foldAtUnicode(s string, i int) (rune, int) {
+	r, n := utf8.DecodeRuneInString(s[i:])
+	return tables.CaseFold(r), n
+}
+
+// foldBefore returns the folded rune that ends at s[i-1] and its size.
+func foldBefore(s string, i int) (rune, int) {
+	if c := s[i-1]; c < utf8.RuneSelf {
+		return rune(_lower[c]), 1
+	}
+	return foldBeforeUnicode(s, i)
+}
+
+// foldBeforeUnicode is the non-ASCII path of foldBefore.
+func foldBeforeUnicode(s string, i int) (rune, int) {
+	r, n := utf8.DecodeLastRuneInString(s[:i])
+	return tables.CaseFold(r), n
+}
+
+// index returns the index and length in bytes of the first instance of the
+// needle in s, or -1, 0 if the needle is not present in s.
+//
+// The current window of s is not stored. Instead, p is the offset in s of
+// the rune at position ell+1 of the window, from which the right half of
+// the needle is compared forwards and the left half backwards.
+func (tw *twoWay) index(s string) (int, int) {
+	x := tw.needle
+	m := len(x)
+	ell, per := tw.ell, tw.per
+	if m == 0 {
+		return 0, 0
+	}
+
+	p := 0
+	for k := 0; k <= ell; k++ {
+		if p == len(s) {
+			return -1, 0
+		}
+		_, n := foldAt(s, p)
+		p += n
+	}
+
+	// memory is the length of the prefix of the needle that is known to
+	// match the current window (minus one) and e is the offset of the rune
+	// following that prefix.
+	memory := -1
+	e := 0
+	for {
+		if memory == -1 {
+			// Fast path: skip ASCII runes that do not match the first
+			// rune of the right half of the needle.
+			r0 := x[ell+1]
+			for p < len(s) && s[p] < utf8.RuneSelf && rune(_lower[s[p]]) != r0 {
+				p++
+			}
+		}
+		i := ell + 1
+		if memory > ell {
+			i = memory + 1
+		} else {
+			e = p
+		}
+		// Compare the right half.
+		for i < m {
+			if e == len(s) {
+				return -1, 0
+			}
+			// NB: manually inlined ASCII fast path of foldAt
+			var r rune
+			n := 1
+			if c := s[e]; c < utf8.RuneSelf {
+				r = rune(_lower[c])
+			} else {
+				r, n = foldAtUnicode(s, e)
+			}
+			if r != x[i] {
+				// Shift the window past the mismatched rune.
+				p = e + n
+				break
+			}
+			e += n
+			i++
+		}
+		if i < m {
+			memory = -1
+			continue
+		}
+		// Compare the left half.
+		b := p
+		k := ell
+		for k > memory {
+			r, n := foldBefore(s, b)
+			if r != x[k] {
+				break
+			}
+			b -= n
+			k--
+		}
+		if k <= memory {
+			for ; k >= 0; k-- {
+				_, n := foldBefore(s, b)
+				b -= n
+			}
+			return b, e - b
+		}
+		if tw.periodic {
+			memory = m - per - 1
+		}
+		for k := 0; k < per; k++ {
+			if p == len(s) {
+				return -1, 0
+			}
+			_, n := foldAt(s, p)
+			p += n
+		}
+	}
+}
+
+// lastIndex returns the index and length in bytes of the last instance of
+// the needle in s, or -1, 0 if the needle is not present in s. The needle
+// must have been reversed by init.
+//
+// This is the mirror image of index: the window moves from the end of s
+// towards its start, p is the offset in s of the end of the rune at
+// position ell+1 of the window, the right half of the needle is compared
+// backwards from p, and the left half forwards.
+func (tw *twoWay) lastIndex(s string) (int, int) {
+	x := tw.needle
+	m := len(x)
+	ell, per := tw.ell, tw.per
+	if m == 0 {
+		return len(s), 0
+	}
+
+	p := len(s)
+	for k := 0; k <= ell; k++ {
+		if p == 0 {
+			return -1, 0
+		}
+		_, n := foldBefore(s, p)
+		p -= n
+	}
+
+	memory := -1
+	e := len(s)
+	for {
+		if memory == -1 {
+			r0 := x[ell+1]
+			for p > 0 && s[p-1] < utf8.RuneSelf && rune(_lower[s[p-1]]) != r0 {
+				p--
+			}
+		}
+		i := ell + 1
+		if memory > ell {
+			i = memory + 1
+		} else {
+			e = p
+		}
+		for i < m {
+			if e == 0 {
+				return -1, 0
+			}
+			// NB: manually inlined ASCII fast path of foldBefore
+			var r rune
+			n := 1
+			if c := s[e-1]; c < utf8.RuneSelf {
+				r = rune(_lower[c])
+			} else {
+				r, n = foldBeforeUnicode(s, e)
+			}
+			if r != x[i] {
+				p = e - n
+				break
+			}
+			e -= n
+			i++
+		}
+		if i < m {
+			memory = -1
+			continue
+		}
+		b := p
+		k := ell
+		for k > memory {
+			r, n := foldAt(s, b)
+			if r != x[k] {
+				break
+			}
+			b += n
+			k--
+		}
+		if k <= memory {
+			for ; k >= 0; k-- {
+				_, n := foldAt(s, b)
+				b += n
+			}
+			return e, b - e
+		}
+		if tw.periodic {
+			memory = m - per - 1
+		}
+		for k := 0; k < per; k++ {
+			if p == 0 {
+				return -1, 0
+			}
+			_, n := foldBefore(s, p)
+			p -= n
+		}
+	}
+}
+
+// indexTwoWay returns the index and length in bytes of the first instance
+// of substr in s using the Two-Way algorithm, or -1, 0 if substr is not
+// present in s.
+func indexTwoWay(s, substr string) (int, int) {
+	var buf [twoWayBufLen]rune
+	var tw twoWay
+	tw.init(substr, false, buf[:])
+	return tw.index(s)
+}
+
+// lastIndexTwoWay returns the index and length in bytes of the last
+// instance of substr in s using the Two-Way algorithm, or -1, 0 if substr
+// is not present in s.
+func lastIndexTwoWay(s, substr string) (int, int) {
+	var buf [twoWayBufLen]rune
+	var tw twoWay
+	tw.init(substr, true, buf[:])
+	return tw.lastIndex(s)
+}